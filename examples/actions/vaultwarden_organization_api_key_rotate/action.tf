@@ -0,0 +1,7 @@
+action "vaultwarden_organization_api_key_rotate" "example" {
+  config {
+    organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+  }
+}
+
+# Invoke with: terraform apply -invoke=action.vaultwarden_organization_api_key_rotate.example
@@ -0,0 +1,21 @@
+resource "vaultwarden_organization_user" "example" {
+  organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+  email           = "user@example.com"
+
+  # Confirm the user once the invitation has been accepted
+  wait_for_status = "Accepted"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.vaultwarden_organization_user_confirm.example]
+    }
+  }
+}
+
+action "vaultwarden_organization_user_confirm" "example" {
+  config {
+    organization_id = vaultwarden_organization_user.example.organization_id
+    user_id         = vaultwarden_organization_user.example.id
+  }
+}
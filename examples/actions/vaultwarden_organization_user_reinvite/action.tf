@@ -0,0 +1,8 @@
+action "vaultwarden_organization_user_reinvite" "example" {
+  config {
+    organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+    user_id         = "7c1b4c6e-0b7e-4c8a-9d3f-2a6e5f8b1c2d"
+  }
+}
+
+# Invoke with: terraform apply -invoke=action.vaultwarden_organization_user_reinvite.example
@@ -0,0 +1,7 @@
+action "vaultwarden_user_deauthorize" "example" {
+  config {
+    user_id = "9f2c5e1a-3b4d-4e6f-8a7b-1c2d3e4f5a6b"
+  }
+}
+
+# Invoke with: terraform apply -invoke=action.vaultwarden_user_deauthorize.example
@@ -0,0 +1,8 @@
+data "vaultwarden_admin_config" "current" {}
+
+check "signups_disabled" {
+  assert {
+    condition     = !data.vaultwarden_admin_config.current.signups_allowed
+    error_message = "Signups must be disabled on the Vaultwarden server."
+  }
+}
@@ -0,0 +1,7 @@
+data "vaultwarden_admin_organizations" "example" {}
+
+output "organization_members" {
+  value = {
+    for org in data.vaultwarden_admin_organizations.example.organizations : org.name => org.user_count
+  }
+}
@@ -0,0 +1,8 @@
+data "vaultwarden_auth_context" "current" {}
+
+# Only manage users through the admin API when an admin token is configured
+resource "vaultwarden_user" "example" {
+  count = data.vaultwarden_auth_context.current.admin_auth_configured ? 1 : 0
+
+  email = "foo@example.com"
+}
@@ -0,0 +1,8 @@
+data "vaultwarden_devices" "current" {}
+
+output "stale_devices" {
+  value = [
+    for device in data.vaultwarden_devices.current.devices : device.id
+    if !device.current && device.name == "Vaultwarden_Terraform_Provider"
+  ]
+}
@@ -0,0 +1,9 @@
+data "vaultwarden_emergency_access" "example" {}
+
+# Ensure the account has at least one confirmed emergency contact
+check "emergency_contact" {
+  assert {
+    condition     = anytrue([for grant in data.vaultwarden_emergency_access.example.trusted : grant.status == "Confirmed"])
+    error_message = "The account has no confirmed emergency contact."
+  }
+}
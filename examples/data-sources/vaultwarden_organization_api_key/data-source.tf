@@ -0,0 +1,11 @@
+data "vaultwarden_organization_api_key" "example" {
+  organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+}
+
+# Fail the plan when an existing API key has not been rotated for 90 days
+check "api_key_rotation" {
+  assert {
+    condition     = timecmp(timeadd(coalesce(data.vaultwarden_organization_api_key.example.revision_date, plantimestamp()), "2160h"), plantimestamp()) > 0
+    error_message = "The organization API key has not been rotated for 90 days."
+  }
+}
@@ -0,0 +1,8 @@
+data "vaultwarden_organization_collection_items" "example" {
+  organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+  collection_id   = "ae8b9f46-5e8d-4ab4-b1c7-0c6e4b2b9d7e"
+}
+
+output "item_names" {
+  value = data.vaultwarden_organization_collection_items.example.items[*].name
+}
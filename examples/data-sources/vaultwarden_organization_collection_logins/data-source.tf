@@ -0,0 +1,14 @@
+data "vaultwarden_organization_collection_logins" "example" {
+  organization_id   = "53878c48-51e9-416d-b31a-1b4209c93832"
+  collection_id     = "ae8b9f46-5e8d-4ab4-b1c7-0c6e4b2b9d7e"
+  include_passwords = true
+}
+
+# Create a database role for every login item in the collection
+resource "postgresql_role" "example" {
+  for_each = data.vaultwarden_organization_collection_logins.example.logins
+
+  name     = each.value.username
+  login    = true
+  password = each.value.password
+}
@@ -0,0 +1,11 @@
+data "vaultwarden_organization_collection_tree" "example" {
+  organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+}
+
+# The IDs of all collections below "Engineering", e.g. to grant a team access to the whole subtree
+locals {
+  engineering_collection_ids = [
+    for path, node in data.vaultwarden_organization_collection_tree.example.nodes : node.id
+    if node.id != null && startswith(path, "Engineering/")
+  ]
+}
@@ -0,0 +1,8 @@
+data "vaultwarden_organization_export" "example" {
+  organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+}
+
+resource "local_sensitive_file" "backup" {
+  content  = data.vaultwarden_organization_export.example.export
+  filename = "${path.module}/vaultwarden-export.json"
+}
@@ -0,0 +1,11 @@
+data "vaultwarden_organization_item_attachments" "example" {
+  item_id = "8f3c1d2a-6b7e-4f90-a1b2-c3d4e5f60718"
+}
+
+# Fail the plan if the item holds more than 10 MB of attachments
+check "attachments_size" {
+  assert {
+    condition     = sum(concat([0], data.vaultwarden_organization_item_attachments.example.attachments[*].size)) <= 10 * 1024 * 1024
+    error_message = "The attachments of the item exceed 10 MB."
+  }
+}
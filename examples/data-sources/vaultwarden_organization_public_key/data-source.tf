@@ -0,0 +1,3 @@
+data "vaultwarden_organization_public_key" "example" {
+  organization_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+}
@@ -0,0 +1,10 @@
+data "vaultwarden_prelogin" "example" {
+  email = "ci@example.com"
+}
+
+check "argon2id" {
+  assert {
+    condition     = data.vaultwarden_prelogin.example.kdf_type == "Argon2id"
+    error_message = "The CI account must use Argon2id."
+  }
+}
@@ -0,0 +1,8 @@
+data "vaultwarden_send" "example" {
+  id = "0b5c8a5e-3f4e-4f7c-9f8a-1b2c3d4e5f60"
+}
+
+output "send_url" {
+  value     = data.vaultwarden_send.example.access_url
+  sensitive = true
+}
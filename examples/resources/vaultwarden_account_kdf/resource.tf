@@ -0,0 +1,19 @@
+resource "random_password" "example" {
+  length = 32
+}
+
+resource "vaultwarden_account_register" "example" {
+  name     = "Example Service Account"
+  email    = "service@example.com"
+  password = random_password.example.result
+}
+
+# Migrate the account from PBKDF2 to Argon2id
+resource "vaultwarden_account_kdf" "example" {
+  email           = vaultwarden_account_register.example.email
+  password        = vaultwarden_account_register.example.password
+  kdf_type        = "Argon2id"
+  kdf_iterations  = 3
+  kdf_memory      = 64
+  kdf_parallelism = 4
+}
@@ -7,3 +7,26 @@ resource "vaultwarden_account_register" "example" {
   email    = "foo@example.com"
   password = random_password.example.result
 }
+
+# Register a service account after inviting it to an organization, completing the invitation right away
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+resource "vaultwarden_organization_user" "service" {
+  organization_id = vaultwarden_organization.example.id
+  email           = "service@example.com"
+}
+
+resource "random_password" "service" {
+  length = 32
+}
+
+resource "vaultwarden_account_register" "service" {
+  name               = "Service Account"
+  email              = "service@example.com"
+  password           = random_password.service.result
+  accept_invitations = true
+
+  depends_on = [vaultwarden_organization_user.service]
+}
@@ -0,0 +1,19 @@
+resource "random_password" "example" {
+  length = 32
+}
+
+resource "vaultwarden_account_register" "example" {
+  name     = "Example Service Account"
+  email    = "service@example.com"
+  password = random_password.example.result
+}
+
+resource "vaultwarden_account_totp" "example" {
+  email    = vaultwarden_account_register.example.email
+  password = vaultwarden_account_register.example.password
+}
+
+output "totp_secret" {
+  value     = vaultwarden_account_totp.example.secret
+  sensitive = true
+}
@@ -0,0 +1,20 @@
+data "vaultwarden_devices" "current" {}
+
+# Deactivate the devices left behind by previous CI runs
+resource "vaultwarden_device_deauthorization" "stale" {
+  for_each = {
+    for device in data.vaultwarden_devices.current.devices : device.id => device
+    if !device.current && device.name == "Vaultwarden_Terraform_Provider"
+  }
+
+  device_id = each.key
+}
+
+# Log out a user from all devices, again whenever the rotation changes
+resource "vaultwarden_device_deauthorization" "user" {
+  user_id = "53878c48-51e9-416d-b31a-1b4209c93832"
+
+  triggers = {
+    rotation = "2024-01"
+  }
+}
@@ -0,0 +1 @@
+terraform import vaultwarden_item_collection_assignment.example <item_id>
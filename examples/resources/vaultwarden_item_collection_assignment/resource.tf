@@ -0,0 +1,14 @@
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+resource "vaultwarden_organization_collection" "example" {
+  organization_id = vaultwarden_organization.example.id
+  name            = "Example"
+}
+
+# Share an item created in the Vaultwarden clients with the collection
+resource "vaultwarden_item_collection_assignment" "example" {
+  item_id        = "00000000-0000-0000-0000-000000000000"
+  collection_ids = [vaultwarden_organization_collection.example.id]
+}
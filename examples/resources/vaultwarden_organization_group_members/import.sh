@@ -0,0 +1 @@
+terraform import vaultwarden_organization_group_members.example <organization_id>/<group_id>
@@ -0,0 +1,17 @@
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+resource "vaultwarden_organization_user" "example" {
+  for_each = toset(["foo@example.com", "bar@example.com"])
+
+  organization_id = vaultwarden_organization.example.id
+  email           = each.value
+}
+
+# The group has to exist already, e.g. created in the web vault
+resource "vaultwarden_organization_group_members" "example" {
+  organization_id = vaultwarden_organization.example.id
+  group_id        = "00000000-0000-0000-0000-000000000000"
+  member_ids      = [for user in vaultwarden_organization_user.example : user.id]
+}
@@ -0,0 +1 @@
+terraform import vaultwarden_organization_members.example <organization_id>/<email>,<email>
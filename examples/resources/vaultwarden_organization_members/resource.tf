@@ -0,0 +1,21 @@
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+resource "vaultwarden_organization_collection" "example" {
+  organization_id = vaultwarden_organization.example.id
+  name            = "Example"
+}
+
+resource "vaultwarden_organization_members" "example" {
+  organization_id = vaultwarden_organization.example.id
+  emails          = ["foo@example.com", "bar@example.com"]
+  type            = "User"
+
+  collections = [
+    {
+      id        = vaultwarden_organization_collection.example.id
+      read_only = true
+    },
+  ]
+}
@@ -0,0 +1 @@
+terraform import vaultwarden_organization_policy_personal_ownership.example <organization_id>
@@ -0,0 +1 @@
+terraform import vaultwarden_organization_policy_require_sso.example <organization_id>
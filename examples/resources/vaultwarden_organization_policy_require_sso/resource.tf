@@ -0,0 +1,7 @@
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+resource "vaultwarden_organization_policy_require_sso" "example" {
+  organization_id = vaultwarden_organization.example.id
+}
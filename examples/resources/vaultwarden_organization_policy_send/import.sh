@@ -0,0 +1 @@
+terraform import vaultwarden_organization_policy_send.example <organization_id>
@@ -0,0 +1,8 @@
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+resource "vaultwarden_organization_policy_send" "example" {
+  organization_id    = vaultwarden_organization.example.id
+  disable_hide_email = true
+}
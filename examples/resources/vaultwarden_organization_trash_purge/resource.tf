@@ -0,0 +1,13 @@
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+# Purge the items which have been in the trash for a week, again every month
+resource "vaultwarden_organization_trash_purge" "example" {
+  organization_id = vaultwarden_organization.example.id
+  older_than_days = 7
+
+  triggers = {
+    month = formatdate("YYYY-MM", plantimestamp())
+  }
+}
@@ -7,3 +7,10 @@ resource "vaultwarden_organization_user" "example" {
   email           = "foo@example.com"
   type            = "User"
 }
+
+# Revoke the access of a person on destroy instead of removing them from the organization
+resource "vaultwarden_organization_user" "employee" {
+  organization_id   = vaultwarden_organization.example.id
+  email             = "jane@example.com"
+  revoke_on_destroy = true
+}
@@ -0,0 +1 @@
+terraform import vaultwarden_organization_user_collections.example <organization_id>/<user_id>
@@ -0,0 +1,25 @@
+resource "vaultwarden_organization" "example" {
+  name = "Example"
+}
+
+resource "vaultwarden_organization_collection" "example" {
+  organization_id = vaultwarden_organization.example.id
+  name            = "Example"
+}
+
+resource "vaultwarden_organization_user" "example" {
+  organization_id = vaultwarden_organization.example.id
+  email           = "foo@example.com"
+}
+
+resource "vaultwarden_organization_user_collections" "example" {
+  organization_id = vaultwarden_organization.example.id
+  user_id         = vaultwarden_organization_user.example.id
+
+  collections = [
+    {
+      id        = vaultwarden_organization_collection.example.id
+      read_only = true
+    },
+  ]
+}
@@ -1,3 +1,9 @@
 resource "vaultwarden_user" "example" {
   email = "foo@example.com"
 }
+
+# Without mail delivery, share the link with the user out of band to register the account
+output "registration_url" {
+  value     = vaultwarden_user.example.registration_url
+  sensitive = true
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &OrganizationAPIKeyRotate{}
+var _ action.ActionWithConfigure = &OrganizationAPIKeyRotate{}
+
+func OrganizationAPIKeyRotateAction() action.Action {
+	return &OrganizationAPIKeyRotate{}
+}
+
+// OrganizationAPIKeyRotate defines the action implementation.
+type OrganizationAPIKeyRotate struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationAPIKeyRotateModel describes the action data model.
+type OrganizationAPIKeyRotateModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func (a *OrganizationAPIKeyRotate) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_api_key_rotate"
+}
+
+func (a *OrganizationAPIKeyRotate) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This action rotates the API key of an organization, which is used by the Directory Connector. The previous API key stops working right away.\n\n" +
+			"The new API key is not exposed, the `revision_date` of the `vaultwarden_organization_api_key` data source changes once the key has been rotated.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization to rotate the API key of",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *OrganizationAPIKeyRotate) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *OrganizationAPIKeyRotate) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data OrganizationAPIKeyRotateModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	keyInfo, err := a.client.RotateOrganizationAPIKey(ctx, data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Rotating Organization API Key",
+			"Could not rotate the API key of organization with ID "+data.OrganizationID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("rotated API key of organization with ID %s, revision date: %s", data.OrganizationID, keyInfo.RevisionDate))
+}
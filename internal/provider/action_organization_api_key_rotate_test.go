@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestAccOrganizationAPIKeyRotateAction(t *testing.T) {
+	// Generate random data for the test
+	name := gofakeit.Company()
+
+	var revisionDate string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invoke testing, which generates the API key the first time
+			{
+				Config: testAccOrganizationAPIKeyDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_api_key.test", "exists", "false"),
+					testAccCheckInvokeAction("vaultwarden_organization_api_key_rotate", map[string]string{
+						"organization_id": "vaultwarden_organization.test.id",
+					}, nil),
+				),
+			},
+			// Invoke testing with an existing API key
+			{
+				Config: testAccOrganizationAPIKeyDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.vaultwarden_organization_api_key.test", "revision_date", func(value string) error {
+						revisionDate = value
+						return nil
+					}),
+					testAccCheckInvokeAction("vaultwarden_organization_api_key_rotate", map[string]string{
+						"organization_id": "vaultwarden_organization.test.id",
+					}, nil),
+				),
+			},
+			// The rotated API key has a new revision date
+			{
+				Config: testAccOrganizationAPIKeyDataSourceConfig(name),
+				Check: resource.TestCheckResourceAttrWith("data.vaultwarden_organization_api_key.test", "revision_date", func(value string) error {
+					if value == revisionDate {
+						return fmt.Errorf("expected the revision date to change from %s", revisionDate)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &OrganizationUserConfirm{}
+var _ action.ActionWithConfigure = &OrganizationUserConfirm{}
+
+func OrganizationUserConfirmAction() action.Action {
+	return &OrganizationUserConfirm{}
+}
+
+// OrganizationUserConfirm defines the action implementation.
+type OrganizationUserConfirm struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationUserConfirmModel describes the action data model.
+type OrganizationUserConfirmModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	UserID         types.String `tfsdk:"user_id"`
+}
+
+func (a *OrganizationUserConfirm) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_user_confirm"
+}
+
+func (a *OrganizationUserConfirm) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This action confirms a user who has accepted the invitation to an organization, which shares the organization key with the user and grants access to the items of the organization.\n\n" +
+			"The organization key is encrypted with the public key of the user as reported by the Vaultwarden server. Unlike the official clients, the fingerprint of the user can't be verified before.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization",
+				Required:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the accepted user in the organization, e.g. the `id` of a `vaultwarden_organization_user` resource",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *OrganizationUserConfirm) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *OrganizationUserConfirm) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data OrganizationUserConfirmModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := a.client.ConfirmOrganizationUser(ctx, data.UserID.ValueString(), data.OrganizationID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Confirming Organization User",
+			"Could not confirm user with ID "+data.UserID.ValueString()+" in organization with ID "+data.OrganizationID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("confirmed user with ID %s in organization with ID: %s", data.UserID, data.OrganizationID))
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
+	"testing"
+)
+
+func TestAccOrganizationUserConfirmAction(t *testing.T) {
+	// Generate random data for the test
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, false, 16)
+	invitedEmail := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invoke testing, only the registered user has accepted the invitation
+			{
+				Config: testAccOrganizationUserConfirmActionConfig(orgName, email, password, invitedEmail),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "status", "Accepted"),
+					testAccCheckInvokeAction("vaultwarden_organization_user_confirm", map[string]string{
+						"organization_id": "vaultwarden_organization.test.id",
+						"user_id":         "vaultwarden_organization_user.test.id",
+					}, nil),
+					testAccCheckInvokeAction("vaultwarden_organization_user_confirm", map[string]string{
+						"organization_id": "vaultwarden_organization.test.id",
+						"user_id":         "vaultwarden_organization_user.invited.id",
+					}, regexp.MustCompile(`only accepted users can be confirmed`)),
+				),
+			},
+			// The confirmed status is read on refresh
+			{
+				Config: testAccOrganizationUserConfirmActionConfig(orgName, email, password, invitedEmail),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "status", "Confirmed"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationUserConfirmActionConfig(orgName, email, password, invitedEmail string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_account_register" "test" {
+    name     = "Confirm Test Account"
+    email    = %[6]q
+    password = %[7]q
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id = vaultwarden_organization.test.id
+    email           = vaultwarden_account_register.test.email
+}
+
+resource "vaultwarden_organization_user" "invited" {
+    organization_id = vaultwarden_organization.test.id
+    email           = %[8]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email, password, invitedEmail)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &OrganizationUserReinvite{}
+var _ action.ActionWithConfigure = &OrganizationUserReinvite{}
+
+func OrganizationUserReinviteAction() action.Action {
+	return &OrganizationUserReinvite{}
+}
+
+// OrganizationUserReinvite defines the action implementation.
+type OrganizationUserReinvite struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationUserReinviteModel describes the action data model.
+type OrganizationUserReinviteModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	UserID         types.String `tfsdk:"user_id"`
+}
+
+func (a *OrganizationUserReinvite) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_user_reinvite"
+}
+
+func (a *OrganizationUserReinvite) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This action sends the invitation email to a user invited to an organization again, e.g. after the invitation has expired. " +
+			"Only users who haven't accepted the invitation yet can be invited again.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization",
+				Required:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the invited user in the organization, e.g. the `id` of a `vaultwarden_organization_user` resource",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *OrganizationUserReinvite) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *OrganizationUserReinvite) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data OrganizationUserReinviteModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := a.client.ReinviteOrganizationUser(ctx, data.UserID.ValueString(), data.OrganizationID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reinviting Organization User",
+			"Could not reinvite user with ID "+data.UserID.ValueString()+" to organization with ID "+data.OrganizationID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("reinvited user with ID %s to organization with ID: %s", data.UserID, data.OrganizationID))
+}
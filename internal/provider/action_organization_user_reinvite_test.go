@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccOrganizationUserReinviteAction(t *testing.T) {
+	// Generate random data for the test
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invoke testing
+			{
+				Config: testAccOrganizationUserReinviteActionConfig(orgName, email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "status", "Invited"),
+					testAccCheckInvokeAction("vaultwarden_organization_user_reinvite", map[string]string{
+						"organization_id": "vaultwarden_organization.test.id",
+						"user_id":         "vaultwarden_organization_user.test.id",
+					}, nil),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationUserReinviteActionConfig(orgName, email string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id = vaultwarden_organization.test.id
+    email           = %[6]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &UserDeauthorize{}
+var _ action.ActionWithConfigure = &UserDeauthorize{}
+
+func UserDeauthorizeAction() action.Action {
+	return &UserDeauthorize{}
+}
+
+// UserDeauthorize defines the action implementation.
+type UserDeauthorize struct {
+	client *vaultwarden.Client
+}
+
+// UserDeauthorizeModel describes the action data model.
+type UserDeauthorizeModel struct {
+	UserID types.String `tfsdk:"user_id"`
+}
+
+func (a *UserDeauthorize) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_deauthorize"
+}
+
+func (a *UserDeauthorize) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This action deauthorizes all sessions of a user through the admin API, so all devices of the user have to log in again. Requires the `admin_token` provider option.\n\n" +
+			"Unlike the `vaultwarden_device_deauthorization` resource, nothing is saved to the state.",
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user to deauthorize all sessions of",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *UserDeauthorize) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *UserDeauthorize) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data UserDeauthorizeModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := a.client.DeauthorizeUser(ctx, data.UserID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deauthorizing User",
+			"Could not deauthorize sessions of user with ID "+data.UserID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("deauthorized sessions of user with ID: %s", data.UserID))
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
+	"testing"
+)
+
+func TestAccUserDeauthorizeAction(t *testing.T) {
+	// Generate random data for the test
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, false, 16)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invoke testing
+			{
+				Config: testAccUserDeauthorizeActionConfig(email, password),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckInvokeAction("vaultwarden_user_deauthorize", map[string]string{
+						"user_id": "vaultwarden_account_register.test.id",
+					}, nil),
+					testAccCheckInvokeAction("vaultwarden_user_deauthorize", map[string]string{
+						"user_id": "vaultwarden_account_register.test.email",
+					}, regexp.MustCompile(`Could not deauthorize sessions of user`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserDeauthorizeActionConfig(email, password string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_account_register" "test" {
+    name     = "Deauthorize Test Account"
+    email    = %[5]q
+    password = %[6]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, email, password)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AdminConfigDataSource{}
+var _ datasource.DataSourceWithConfigure = &AdminConfigDataSource{}
+
+func NewAdminConfigDataSource() datasource.DataSource {
+	return &AdminConfigDataSource{}
+}
+
+// AdminConfigDataSource defines the data source implementation.
+type AdminConfigDataSource struct {
+	client *vaultwarden.Client
+}
+
+// AdminConfigDataSourceModel describes the data source data model.
+type AdminConfigDataSourceModel struct {
+	Settings       types.Map  `tfsdk:"settings"`
+	SignupsAllowed types.Bool `tfsdk:"signups_allowed"`
+	SMTPConfigured types.Bool `tfsdk:"smtp_configured"`
+}
+
+func (d *AdminConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_config"
+}
+
+func (d *AdminConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to read the effective configuration of a Vaultwarden server through the admin API. " +
+			"The configuration is not managed, use it to check invariants such as disabled signups.\n\n" +
+			"Requires the `admin_token` provider option.",
+
+		Attributes: map[string]schema.Attribute{
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "The settings of the server keyed by their name, e.g. `signups_allowed`. " +
+					"Values which are not strings are JSON encoded, unset settings are left out and secrets are redacted by the server",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"signups_allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether new users can sign up without an invitation",
+				Computed:            true,
+			},
+			"smtp_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether an SMTP server is configured to send emails",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AdminConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AdminConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AdminConfigDataSourceModel
+
+	// Get the configuration from the Vaultwarden server
+	config, err := d.client.GetAdminConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Admin Config",
+			"Could not read the configuration of the server: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Flatten the settings into strings, keeping strings as they are
+	settings := make(map[string]string, len(config))
+	for name, raw := range config {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil || value == nil {
+			continue
+		}
+
+		if s, ok := value.(string); ok {
+			settings[name] = s
+		} else {
+			settings[name] = string(raw)
+		}
+	}
+
+	// Map response body to schema
+	settingsValue, diags := types.MapValueFrom(ctx, types.StringType, settings)
+	resp.Diagnostics.Append(diags...)
+	data.Settings = settingsValue
+
+	data.SignupsAllowed = types.BoolValue(settings["signups_allowed"] == "true")
+	data.SMTPConfigured = types.BoolValue(settings["smtp_host"] != "")
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccAdminConfigDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccRecordedProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAdminConfigDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vaultwarden_admin_config.test", "settings.signups_allowed"),
+					resource.TestCheckResourceAttrSet("data.vaultwarden_admin_config.test", "signups_allowed"),
+					resource.TestCheckResourceAttrSet("data.vaultwarden_admin_config.test", "smtp_configured"),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccAdminConfigDataSourceConfig() string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  admin_token = %[2]q
+}
+
+data "vaultwarden_admin_config" "test" {}
+`, test.TestBaseURL, test.TestAdminToken)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AdminOrganizationsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AdminOrganizationsDataSource{}
+
+func NewAdminOrganizationsDataSource() datasource.DataSource {
+	return &AdminOrganizationsDataSource{}
+}
+
+// AdminOrganizationsDataSource defines the data source implementation.
+type AdminOrganizationsDataSource struct {
+	client *vaultwarden.Client
+}
+
+// AdminOrganizationsDataSourceModel describes the data source data model.
+type AdminOrganizationsDataSourceModel struct {
+	Organizations []AdminOrganizationModel `tfsdk:"organizations"`
+}
+
+// AdminOrganizationModel describes an organization of the data source.
+type AdminOrganizationModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	UserCount types.Int64  `tfsdk:"user_count"`
+}
+
+func (d *AdminOrganizationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_organizations"
+}
+
+func (d *AdminOrganizationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to list all organizations of a Vaultwarden server through the admin API, not only those the provider user is a member of.\n\n" +
+			"Vaultwarden only lists the organizations in the HTML overview of the admin panel, so they are collected from the users of the server. " +
+			"Organizations without confirmed members are not included and only confirmed members are counted.\n\n" +
+			"Requires the `admin_token` provider option.",
+
+		Attributes: map[string]schema.Attribute{
+			"organizations": schema.ListNestedAttribute{
+				MarkdownDescription: "The organizations of the server, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the organization",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the organization",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the organization is enabled",
+							Computed:            true,
+						},
+						"user_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of confirmed members of the organization",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AdminOrganizationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AdminOrganizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AdminOrganizationsDataSourceModel
+
+	// Get the organizations from the Vaultwarden server
+	organizations, err := d.client.GetAllOrganizations(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Organizations",
+			"Could not read the organizations of the server: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.Organizations = make([]AdminOrganizationModel, 0, len(organizations))
+	for _, org := range organizations {
+		data.Organizations = append(data.Organizations, AdminOrganizationModel{
+			ID:        types.StringValue(org.ID),
+			Name:      types.StringValue(org.Name),
+			Enabled:   types.BoolValue(org.Enabled),
+			UserCount: types.Int64Value(int64(org.UserCount)),
+		})
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccAdminOrganizationsDataSource(t *testing.T) {
+	// Generate random data for the test
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAdminOrganizationsDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.vaultwarden_admin_organizations.test", "organizations.*", map[string]string{
+						"name":       name,
+						"enabled":    "true",
+						"user_count": "1",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("data.vaultwarden_admin_organizations.test", "organizations.*.id", "vaultwarden_organization.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccAdminOrganizationsDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+}
+
+data "vaultwarden_admin_organizations" "test" {
+  depends_on = [vaultwarden_organization.test]
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name)
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"time"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuthContextDataSource{}
+var _ datasource.DataSourceWithConfigure = &AuthContextDataSource{}
+
+func NewAuthContextDataSource() datasource.DataSource {
+	return &AuthContextDataSource{}
+}
+
+// AuthContextDataSource defines the data source implementation.
+type AuthContextDataSource struct {
+	client *vaultwarden.Client
+}
+
+// AuthContextDataSourceModel describes the data source data model.
+type AuthContextDataSourceModel struct {
+	UserID              types.String `tfsdk:"user_id"`
+	Email               types.String `tfsdk:"email"`
+	AuthMethod          types.String `tfsdk:"auth_method"`
+	TokenExpiresAt      types.String `tfsdk:"token_expires_at"`
+	AdminAuthConfigured types.Bool   `tfsdk:"admin_auth_configured"`
+}
+
+func (d *AuthContextDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_context"
+}
+
+func (d *AuthContextDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to get the user the provider is authenticated as and how.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authenticated user, unset if no user credentials are configured",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email of the authenticated user, unset if no user credentials are configured",
+				Computed:            true,
+			},
+			"auth_method": schema.StringAttribute{
+				MarkdownDescription: "The method used to authenticate the user (password, api_key), or `none` if no user credentials are configured",
+				Computed:            true,
+			},
+			"token_expires_at": schema.StringAttribute{
+				MarkdownDescription: "The expiry of the access token of the user in RFC 3339 format, unset if no user credentials are configured",
+				Computed:            true,
+			},
+			"admin_auth_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether an admin token is configured",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AuthContextDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AuthContextDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuthContextDataSourceModel
+
+	// Get the authentication context, logging in the user if necessary
+	authContext, err := d.client.AuthContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Authentication Context",
+			"Could not read the authenticated user: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.UserID = types.StringNull()
+	data.Email = types.StringNull()
+	data.TokenExpiresAt = types.StringNull()
+	if authContext.UserID != "" {
+		data.UserID = types.StringValue(authContext.UserID)
+		data.Email = types.StringValue(authContext.Email)
+		data.TokenExpiresAt = types.StringValue(authContext.TokenExpiresAt.Format(time.RFC3339))
+	}
+	data.AuthMethod = types.StringValue(authContext.Method.String())
+	data.AdminAuthConfigured = types.BoolValue(authContext.AdminAuthConfigured)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccAuthContextDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccRecordedProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAuthContextDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_auth_context.test", "email", test.TestEmail),
+					resource.TestCheckResourceAttr("data.vaultwarden_auth_context.test", "auth_method", "password"),
+					resource.TestCheckResourceAttr("data.vaultwarden_auth_context.test", "admin_auth_configured", "true"),
+					resource.TestCheckResourceAttrSet("data.vaultwarden_auth_context.test", "user_id"),
+					resource.TestCheckResourceAttrSet("data.vaultwarden_auth_context.test", "token_expires_at"),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccAuthContextDataSourceConfig() string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+data "vaultwarden_auth_context" "test" {}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken)
+}
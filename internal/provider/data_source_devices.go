@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DevicesDataSource{}
+var _ datasource.DataSourceWithConfigure = &DevicesDataSource{}
+
+func NewDevicesDataSource() datasource.DataSource {
+	return &DevicesDataSource{}
+}
+
+// DevicesDataSource defines the data source implementation.
+type DevicesDataSource struct {
+	client *vaultwarden.Client
+}
+
+// DevicesDataSourceModel describes the data source data model.
+type DevicesDataSourceModel struct {
+	Devices []DeviceModel `tfsdk:"devices"`
+}
+
+// DeviceModel describes a device of the data source.
+type DeviceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Identifier       types.String `tfsdk:"identifier"`
+	Name             types.String `tfsdk:"name"`
+	Type             types.Int64  `tfsdk:"type"`
+	CreationDate     types.String `tfsdk:"creation_date"`
+	LastActivityDate types.String `tfsdk:"last_activity_date"`
+	Trusted          types.Bool   `tfsdk:"trusted"`
+	Current          types.Bool   `tfsdk:"current"`
+}
+
+func (d *DevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devices"
+}
+
+func (d *DevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to list the devices the authenticated user has logged in with, e.g. to find stale devices of CI accounts.",
+
+		Attributes: map[string]schema.Attribute{
+			"devices": schema.ListNestedAttribute{
+				MarkdownDescription: "The devices of the authenticated user",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the device",
+							Computed:            true,
+						},
+						"identifier": schema.StringAttribute{
+							MarkdownDescription: "The identifier the device logs in with",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the device",
+							Computed:            true,
+						},
+						"type": schema.Int64Attribute{
+							MarkdownDescription: "The type of the device as defined by Bitwarden, e.g. `21` for SDKs such as this provider",
+							Computed:            true,
+						},
+						"creation_date": schema.StringAttribute{
+							MarkdownDescription: "The date the device first logged in",
+							Computed:            true,
+						},
+						"last_activity_date": schema.StringAttribute{
+							MarkdownDescription: "The date the device was last used, unset if the server doesn't report it",
+							Computed:            true,
+						},
+						"trusted": schema.BoolAttribute{
+							MarkdownDescription: "Whether the device is trusted",
+							Computed:            true,
+						},
+						"current": schema.BoolAttribute{
+							MarkdownDescription: "Whether the device is the one the provider is logged in with",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DevicesDataSourceModel
+
+	// Get the devices from the Vaultwarden server
+	devices, err := d.client.GetDevices(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Devices",
+			"Could not read the devices of the authenticated user: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.Devices = make([]DeviceModel, 0, len(devices))
+	for _, device := range devices {
+		data.Devices = append(data.Devices, DeviceModel{
+			ID:               types.StringValue(device.ID),
+			Identifier:       types.StringValue(device.Identifier),
+			Name:             types.StringValue(device.Name),
+			Type:             types.Int64Value(device.Type),
+			CreationDate:     types.StringValue(device.CreationDate),
+			LastActivityDate: types.StringPointerValue(device.LastActivityDate),
+			Trusted:          types.BoolValue(device.IsTrusted),
+			Current:          types.BoolValue(device.Identifier == d.client.DeviceInfo.DeviceIdentifier),
+		})
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccDevicesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing, the provider itself has logged in with a device
+			{
+				Config: testAccDevicesDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.vaultwarden_devices.test", "devices.*", map[string]string{
+						"current": "true",
+						"type":    "21",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccDevicesDataSourceConfig() string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+data "vaultwarden_devices" "test" {}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken)
+}
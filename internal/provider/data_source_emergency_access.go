@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmergencyAccessDataSource{}
+var _ datasource.DataSourceWithConfigure = &EmergencyAccessDataSource{}
+
+func NewEmergencyAccessDataSource() datasource.DataSource {
+	return &EmergencyAccessDataSource{}
+}
+
+// EmergencyAccessDataSource defines the data source implementation.
+type EmergencyAccessDataSource struct {
+	client *vaultwarden.Client
+}
+
+// EmergencyAccessDataSourceModel describes the data source data model.
+type EmergencyAccessDataSourceModel struct {
+	Trusted []EmergencyAccessModel `tfsdk:"trusted"`
+	Granted []EmergencyAccessModel `tfsdk:"granted"`
+}
+
+// EmergencyAccessModel describes an emergency access grant of the data source.
+type EmergencyAccessModel struct {
+	ID           types.String `tfsdk:"id"`
+	UserID       types.String `tfsdk:"user_id"`
+	Email        types.String `tfsdk:"email"`
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Status       types.String `tfsdk:"status"`
+	WaitTimeDays types.Int64  `tfsdk:"wait_time_days"`
+}
+
+// emergencyAccessAttributes returns the attributes of a grant, where the user is the other side of the grant
+func emergencyAccessAttributes(userDescription string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the emergency access grant",
+			Computed:            true,
+		},
+		"user_id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the " + userDescription + ", unset while the invitation has not been accepted",
+			Computed:            true,
+		},
+		"email": schema.StringAttribute{
+			MarkdownDescription: "The email of the " + userDescription,
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name of the " + userDescription,
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The access granted once emergency access is approved (View, Takeover)",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "The status of the grant (Invited, Accepted, Confirmed, RecoveryInitiated, RecoveryApproved)",
+			Computed:            true,
+		},
+		"wait_time_days": schema.Int64Attribute{
+			MarkdownDescription: "The number of days after which a recovery request is approved automatically",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *EmergencyAccessDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_emergency_access"
+}
+
+func (d *EmergencyAccessDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to list the emergency access grants of the authenticated user from a Vaultwarden server, " +
+			"e.g. to audit the break-glass configuration of an account. Both lists are empty if emergency access is disabled on the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"trusted": schema.ListNestedAttribute{
+				MarkdownDescription: "The emergency contacts the authenticated user has granted access to their vault",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: emergencyAccessAttributes("grantee"),
+				},
+			},
+			"granted": schema.ListNestedAttribute{
+				MarkdownDescription: "The users who have granted the authenticated user emergency access to their vault",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: emergencyAccessAttributes("grantor"),
+				},
+			},
+		},
+	}
+}
+
+func (d *EmergencyAccessDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EmergencyAccessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EmergencyAccessDataSourceModel
+
+	// Get the emergency access grants from the Vaultwarden server
+	trusted, err := d.client.GetTrustedEmergencyAccess(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Emergency Access",
+			"Could not read the emergency contacts of the authenticated user: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	granted, err := d.client.GetGrantedEmergencyAccess(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Emergency Access",
+			"Could not read the emergency access granted to the authenticated user: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.Trusted = make([]EmergencyAccessModel, 0, len(trusted))
+	for _, grant := range trusted {
+		// Vaultwarden lists a placeholder without an ID when emergency access is disabled
+		if grant.ID == "" {
+			continue
+		}
+		data.Trusted = append(data.Trusted, flattenEmergencyAccess(grant, grant.GranteeID))
+	}
+
+	data.Granted = make([]EmergencyAccessModel, 0, len(granted))
+	for _, grant := range granted {
+		data.Granted = append(data.Granted, flattenEmergencyAccess(grant, grant.GrantorID))
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// flattenEmergencyAccess converts an emergency access grant into its model, userID being the other side of the grant
+func flattenEmergencyAccess(grant models.EmergencyAccess, userID string) EmergencyAccessModel {
+	model := EmergencyAccessModel{
+		ID:           types.StringValue(grant.ID),
+		UserID:       types.StringNull(),
+		Email:        types.StringValue(grant.Email),
+		Name:         types.StringValue(grant.Name),
+		Type:         types.StringValue(grant.Type.String()),
+		Status:       types.StringValue(grant.Status.String()),
+		WaitTimeDays: types.Int64Value(grant.WaitTimeDays),
+	}
+
+	if userID != "" {
+		model.UserID = types.StringValue(userID)
+	}
+
+	return model
+}
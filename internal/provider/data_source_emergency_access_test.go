@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccEmergencyAccessDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing, the test user has no emergency contacts
+			{
+				Config: testAccEmergencyAccessDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_emergency_access.test", "trusted.#", "0"),
+					resource.TestCheckResourceAttr("data.vaultwarden_emergency_access.test", "granted.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccEmergencyAccessDataSourceConfig() string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+data "vaultwarden_emergency_access" "test" {}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken)
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -26,9 +26,14 @@ type OrganizationDataSource struct {
 
 // OrganizationDataSourceModel describes the data source data model.
 type OrganizationDataSourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	BillingEmail types.String `tfsdk:"billing_email"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	BillingEmail  types.String `tfsdk:"billing_email"`
+	SSOIdentifier types.String `tfsdk:"sso_identifier"`
+	UseGroups     types.Bool   `tfsdk:"use_groups"`
+	UseEvents     types.Bool   `tfsdk:"use_events"`
+	UseAPI        types.Bool   `tfsdk:"use_api"`
+	UseDirectory  types.Bool   `tfsdk:"use_directory"`
 }
 
 func (d *OrganizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -53,6 +58,26 @@ func (d *OrganizationDataSource) Schema(ctx context.Context, req datasource.Sche
 				MarkdownDescription: "The billing email of the organization",
 				Computed:            true,
 			},
+			"sso_identifier": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the organization that members enter to log in with SSO",
+				Computed:            true,
+			},
+			"use_groups": schema.BoolAttribute{
+				MarkdownDescription: "Whether groups are enabled for the organization",
+				Computed:            true,
+			},
+			"use_events": schema.BoolAttribute{
+				MarkdownDescription: "Whether event logs are enabled for the organization",
+				Computed:            true,
+			},
+			"use_api": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization API key can be used",
+				Computed:            true,
+			},
+			"use_directory": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization can be synced with Directory Connector",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -93,7 +118,7 @@ func (d *OrganizationDataSource) Read(ctx context.Context, req datasource.ReadRe
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Organization",
-			fmt.Sprintf("Could not read organization ID %s: %s", data.ID.ValueString(), err),
+			fmt.Sprintf("Could not read organization ID %s: %s", data.ID.ValueString(), clientErrorDetail(err)),
 		)
 		return
 	}
@@ -102,6 +127,11 @@ func (d *OrganizationDataSource) Read(ctx context.Context, req datasource.ReadRe
 	data.ID = types.StringValue(org.ID)
 	data.Name = types.StringValue(org.Name)
 	data.BillingEmail = types.StringValue(org.BillingEmail)
+	data.SSOIdentifier = organizationSSOIdentifier(org)
+	data.UseGroups = types.BoolValue(org.UseGroups)
+	data.UseEvents = types.BoolValue(org.UseEvents)
+	data.UseAPI = types.BoolValue(org.UseAPI)
+	data.UseDirectory = types.BoolValue(org.UseDirectory)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
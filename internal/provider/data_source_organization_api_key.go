@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationAPIKeyDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrganizationAPIKeyDataSource{}
+
+func NewOrganizationAPIKeyDataSource() datasource.DataSource {
+	return &OrganizationAPIKeyDataSource{}
+}
+
+// OrganizationAPIKeyDataSource defines the data source implementation.
+type OrganizationAPIKeyDataSource struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationAPIKeyDataSourceModel describes the data source data model.
+type OrganizationAPIKeyDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Exists         types.Bool   `tfsdk:"exists"`
+	ClientID       types.String `tfsdk:"client_id"`
+	RevisionDate   types.String `tfsdk:"revision_date"`
+}
+
+func (d *OrganizationAPIKeyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_api_key"
+}
+
+func (d *OrganizationAPIKeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to get whether an organization has an API key and when it was last rotated from a Vaultwarden server, without revealing the secret.\n\n" +
+			"The metadata is read through the API key information endpoint of the Bitwarden API, as requesting the API key itself generates it if it does not exist yet. " +
+			"Vaultwarden releases without that endpoint can't report the metadata without changing the organization, so the data source fails on them instead. " +
+			"The authenticated user has to be an admin or owner of the organization.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Required:            true,
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization has an API key",
+				Computed:            true,
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "The client ID to authenticate with the API key of the organization, if it exists",
+				Computed:            true,
+			},
+			"revision_date": schema.StringAttribute{
+				MarkdownDescription: "The date the API key was created or last rotated in RFC 3339 format, if it exists",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *OrganizationAPIKeyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationAPIKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationAPIKeyDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the API key metadata from the Vaultwarden server
+	keyInfo, err := d.client.GetOrganizationAPIKeyInfo(ctx, data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Organization API Key",
+			fmt.Sprintf("Could not read the API key metadata of organization ID %s: %s", data.OrganizationID.ValueString(), clientErrorDetail(err)),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.Exists = types.BoolValue(keyInfo != nil)
+	data.ClientID = types.StringNull()
+	data.RevisionDate = types.StringNull()
+	if keyInfo != nil {
+		data.ClientID = types.StringValue("organization." + data.OrganizationID.ValueString())
+		data.RevisionDate = types.StringValue(keyInfo.RevisionDate)
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
+	"testing"
+)
+
+func TestAccOrganizationAPIKeyDataSource(t *testing.T) {
+	// Generate random data for the test
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing, which doesn't generate the API key
+			{
+				Config: testAccOrganizationAPIKeyDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_api_key.test", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.vaultwarden_organization_api_key.test", "client_id"),
+					resource.TestCheckNoResourceAttr("data.vaultwarden_organization_api_key.test", "revision_date"),
+					testAccCheckInvokeAction("vaultwarden_organization_api_key_rotate", map[string]string{
+						"organization_id": "vaultwarden_organization.test.id",
+					}, nil),
+				),
+			},
+			// Read testing after the API key has been generated by the rotation
+			{
+				Config: testAccOrganizationAPIKeyDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_api_key.test", "exists", "true"),
+					resource.TestMatchResourceAttr("data.vaultwarden_organization_api_key.test", "client_id", regexp.MustCompile(`^organization\.`)),
+					resource.TestMatchResourceAttr("data.vaultwarden_organization_api_key.test", "revision_date", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccOrganizationAPIKeyDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+}
+
+data "vaultwarden_organization_api_key" "test" {
+  organization_id = vaultwarden_organization.test.id
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name)
+}
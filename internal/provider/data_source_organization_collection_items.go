@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"slices"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationCollectionItemsDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrganizationCollectionItemsDataSource{}
+
+func NewOrganizationCollectionItemsDataSource() datasource.DataSource {
+	return &OrganizationCollectionItemsDataSource{}
+}
+
+// OrganizationCollectionItemsDataSource defines the data source implementation.
+type OrganizationCollectionItemsDataSource struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationCollectionItemsDataSourceModel describes the data source data model.
+type OrganizationCollectionItemsDataSourceModel struct {
+	OrganizationID types.String                 `tfsdk:"organization_id"`
+	CollectionID   types.String                 `tfsdk:"collection_id"`
+	ItemIDs        types.List                   `tfsdk:"item_ids"`
+	Items          []OrganizationCollectionItem `tfsdk:"items"`
+}
+
+// OrganizationCollectionItem describes an item of the data source.
+type OrganizationCollectionItem struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func (d *OrganizationCollectionItemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_collection_items"
+}
+
+func (d *OrganizationCollectionItemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to list the items of an organization collection from a Vaultwarden server, e.g. to check that a collection is empty before destroying it.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Required:            true,
+			},
+			"collection_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the collection",
+				Required:            true,
+			},
+			"item_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the items in the collection",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"items": schema.ListNestedAttribute{
+				MarkdownDescription: "The items in the collection",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the item",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The decrypted name of the item, unset if it cannot be decrypted and `ignore_decryption_errors` is enabled",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the item (Login, SecureNote, Card, Identity, SshKey)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationCollectionItemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationCollectionItemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationCollectionItemsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := data.OrganizationID.ValueString()
+	collectionID := data.CollectionID.ValueString()
+
+	// Get the items of the organization from the Vaultwarden server
+	ciphers, err := d.client.GetOrganizationCiphers(ctx, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Organization Items",
+			fmt.Sprintf("Could not read the items of organization ID %s: %s", orgID, clientErrorDetail(err)),
+		)
+		return
+	}
+
+	// Map response body to schema
+	itemIDs := []string{}
+	data.Items = []OrganizationCollectionItem{}
+	for _, cipher := range ciphers {
+		// Items in the trash are no longer part of the collection
+		if cipher.DeletedDate != nil || !slices.Contains(cipher.CollectionIDs, collectionID) {
+			continue
+		}
+
+		item := OrganizationCollectionItem{
+			ID:   types.StringValue(cipher.ID),
+			Name: types.StringNull(),
+			Type: types.StringValue(cipher.Type.String()),
+		}
+
+		name, err := d.client.DecryptOrganizationCipherString(ctx, cipher, cipher.Name)
+		if err != nil {
+			if !d.client.IgnoreDecryptionErrors() {
+				resp.Diagnostics.AddError(
+					"Error Decrypting Item Name",
+					"Could not decrypt the name of item "+cipher.ID+": "+err.Error(),
+				)
+				return
+			}
+
+			resp.Diagnostics.AddWarning(
+				"Could Not Decrypt Item Name",
+				"The name of item "+cipher.ID+" could not be decrypted and is left unset: "+err.Error(),
+			)
+		} else {
+			item.Name = types.StringValue(name)
+		}
+
+		itemIDs = append(itemIDs, cipher.ID)
+		data.Items = append(data.Items, item)
+	}
+
+	itemIDsValue, diags := types.ListValueFrom(ctx, types.StringType, itemIDs)
+	resp.Diagnostics.Append(diags...)
+	data.ItemIDs = itemIDsValue
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
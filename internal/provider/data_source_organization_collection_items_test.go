@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccOrganizationCollectionItemsDataSource(t *testing.T) {
+	// Generate random data for the test
+	orgName := gofakeit.Company()
+	collectionName := gofakeit.Word()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccOrganizationCollectionItemsDataSourceConfig(orgName, collectionName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_items.test", "item_ids.#", "0"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_items.test", "items.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccOrganizationCollectionItemsDataSourceConfig(orgName, collectionName string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+}
+
+resource "vaultwarden_organization_collection" "test" {
+  organization_id = vaultwarden_organization.test.id
+  name = %[6]q
+}
+
+data "vaultwarden_organization_collection_items" "test" {
+  organization_id = vaultwarden_organization.test.id
+  collection_id = vaultwarden_organization_collection.test.id
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, collectionName)
+}
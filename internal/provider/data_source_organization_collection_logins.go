@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"slices"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationCollectionLoginsDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrganizationCollectionLoginsDataSource{}
+
+func NewOrganizationCollectionLoginsDataSource() datasource.DataSource {
+	return &OrganizationCollectionLoginsDataSource{}
+}
+
+// OrganizationCollectionLoginsDataSource defines the data source implementation.
+type OrganizationCollectionLoginsDataSource struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationCollectionLoginsDataSourceModel describes the data source data model.
+type OrganizationCollectionLoginsDataSourceModel struct {
+	OrganizationID   types.String                                `tfsdk:"organization_id"`
+	CollectionID     types.String                                `tfsdk:"collection_id"`
+	IncludePasswords types.Bool                                  `tfsdk:"include_passwords"`
+	Logins           map[string]OrganizationCollectionLoginModel `tfsdk:"logins"`
+}
+
+// OrganizationCollectionLoginModel describes a login item of the data source.
+type OrganizationCollectionLoginModel struct {
+	ID       types.String `tfsdk:"id"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+func (d *OrganizationCollectionLoginsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_collection_logins"
+}
+
+func (d *OrganizationCollectionLoginsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to read the login items of an organization collection from a Vaultwarden server as a map keyed by the item name, e.g. to feed `for_each` of the resources which use the credentials.\n\n" +
+			"The item names have to be unique within the collection. Items whose name cannot be decrypted are skipped if `ignore_decryption_errors` is enabled.\n\n" +
+			"If `include_passwords` is enabled, this data source will save the passwords in plain text to the state! Use caution!",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Required:            true,
+			},
+			"collection_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the collection",
+				Required:            true,
+			},
+			"include_passwords": schema.BoolAttribute{
+				MarkdownDescription: "Whether to decrypt the passwords of the login items. Defaults to `false`",
+				Optional:            true,
+			},
+			"logins": schema.MapNestedAttribute{
+				MarkdownDescription: "The login items in the collection, keyed by their decrypted name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the item",
+							Computed:            true,
+						},
+						"username": schema.StringAttribute{
+							MarkdownDescription: "The decrypted username of the item, unset if the item has none",
+							Computed:            true,
+						},
+						"password": schema.StringAttribute{
+							MarkdownDescription: "The decrypted password of the item, unset if the item has none or `include_passwords` is not enabled",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationCollectionLoginsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationCollectionLoginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationCollectionLoginsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := data.OrganizationID.ValueString()
+	collectionID := data.CollectionID.ValueString()
+
+	// Get the items of the organization from the Vaultwarden server
+	ciphers, err := d.client.GetOrganizationCiphers(ctx, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Organization Items",
+			fmt.Sprintf("Could not read the items of organization ID %s: %s", orgID, clientErrorDetail(err)),
+		)
+		return
+	}
+
+	// Map response body to schema, remembering the item of each name to report duplicates
+	itemIDs := map[string]string{}
+	data.Logins = map[string]OrganizationCollectionLoginModel{}
+	for _, cipher := range ciphers {
+		// Items in the trash are no longer part of the collection
+		if cipher.Type != models.CipherTypeLogin || cipher.DeletedDate != nil || !slices.Contains(cipher.CollectionIDs, collectionID) {
+			continue
+		}
+
+		name, ok := d.decrypt(ctx, cipher, "name", cipher.Name, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		// The item can't be keyed without its name, so it is skipped
+		if name.IsNull() {
+			continue
+		}
+
+		if otherID, exists := itemIDs[name.ValueString()]; exists {
+			resp.Diagnostics.AddError(
+				"Duplicate Item Name",
+				fmt.Sprintf("The items %s and %s of the collection are both named %q, the login items of the collection must have unique names.", otherID, cipher.ID, name.ValueString()),
+			)
+			return
+		}
+		itemIDs[name.ValueString()] = cipher.ID
+
+		login := OrganizationCollectionLoginModel{
+			ID:       types.StringValue(cipher.ID),
+			Username: types.StringNull(),
+			Password: types.StringNull(),
+		}
+		if cipher.Login != nil {
+			if login.Username, ok = d.decrypt(ctx, cipher, "username", cipher.Login.Username, &resp.Diagnostics); !ok {
+				return
+			}
+			if data.IncludePasswords.ValueBool() {
+				if login.Password, ok = d.decrypt(ctx, cipher, "password", cipher.Login.Password, &resp.Diagnostics); !ok {
+					return
+				}
+			}
+		}
+
+		data.Logins[name.ValueString()] = login
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// decrypt decrypts a value of the item, which is unset if it is empty or cannot be decrypted and decryption errors
+// are ignored. It returns false if the value cannot be decrypted otherwise.
+func (d *OrganizationCollectionLoginsDataSource) decrypt(ctx context.Context, cipher models.Cipher, field, value string, diags *diag.Diagnostics) (types.String, bool) {
+	if value == "" {
+		return types.StringNull(), true
+	}
+
+	decrypted, err := d.client.DecryptOrganizationCipherString(ctx, cipher, value)
+	if err != nil {
+		if !d.client.IgnoreDecryptionErrors() {
+			diags.AddError(
+				"Error Decrypting Item",
+				"Could not decrypt the "+field+" of item "+cipher.ID+": "+err.Error(),
+			)
+			return types.StringNull(), false
+		}
+
+		diags.AddWarning(
+			"Could Not Decrypt Item",
+			"The "+field+" of item "+cipher.ID+" could not be decrypted: "+err.Error(),
+		)
+		return types.StringNull(), true
+	}
+
+	return types.StringValue(decrypted), true
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"os"
+	"testing"
+)
+
+func TestAccOrganizationCollectionLoginsDataSource(t *testing.T) {
+	// The items are created before the test case, which would otherwise only be skipped by it
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client, err := test.GetTestClient(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to get test client: %s", err)
+	}
+
+	// Create the items outside of Terraform, as if they had been created in the Vaultwarden clients
+	org, err := client.CreateOrganization(ctx, models.Organization{Name: gofakeit.Company(), CollectionName: "Default"})
+	if err != nil {
+		t.Fatalf("failed to create organization: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := client.DeleteOrganization(context.Background(), org.ID); err != nil {
+			t.Logf("failed to delete organization %s: %s", org.ID, err)
+		}
+	})
+
+	collection, err := client.CreateOrganizationCollection(ctx, org.ID, models.Collection{Name: gofakeit.ProductName()})
+	if err != nil {
+		t.Fatalf("failed to create collection: %s", err)
+	}
+
+	loginName := gofakeit.AppName()
+	username := gofakeit.Username()
+	password := gofakeit.Password(true, true, true, false, false, 20)
+	login := &models.Login{}
+	for value, encrypted := range map[string]*string{username: &login.Username, password: &login.Password} {
+		if *encrypted, err = client.EncryptOrganizationString(ctx, org.ID, value); err != nil {
+			t.Fatalf("failed to encrypt login: %s", err)
+		}
+	}
+
+	item, err := client.CreateOrganizationCipher(ctx, org.ID, models.Cipher{
+		Type:  models.CipherTypeLogin,
+		Name:  loginName,
+		Login: login,
+	}, []string{collection.ID})
+	if err != nil {
+		t.Fatalf("failed to create item: %s", err)
+	}
+
+	// Other types of items are not part of the logins
+	if _, err := client.CreateOrganizationCipher(ctx, org.ID, models.Cipher{
+		Type:       models.CipherTypeSecureNote,
+		Name:       gofakeit.AppName(),
+		SecureNote: &models.SecureNote{},
+	}, []string{collection.ID}); err != nil {
+		t.Fatalf("failed to create item: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing without the passwords
+			{
+				Config: testAccOrganizationCollectionLoginsDataSourceConfig(org.ID, collection.ID, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_logins.test", "logins.%", "1"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_logins.test", "logins."+loginName+".id", item.ID),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_logins.test", "logins."+loginName+".username", username),
+					resource.TestCheckNoResourceAttr("data.vaultwarden_organization_collection_logins.test", "logins."+loginName+".password"),
+				),
+			},
+			// Read testing with the passwords
+			{
+				Config: testAccOrganizationCollectionLoginsDataSourceConfig(org.ID, collection.ID, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_logins.test", "logins."+loginName+".password", password),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationCollectionLoginsDataSourceConfig(orgID, collectionID string, includePasswords bool) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+data "vaultwarden_organization_collection_logins" "test" {
+  organization_id = %[5]q
+  collection_id = %[6]q
+  include_passwords = %[7]t
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgID, collectionID, includePasswords)
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"slices"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationCollectionTreeDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrganizationCollectionTreeDataSource{}
+
+func NewOrganizationCollectionTreeDataSource() datasource.DataSource {
+	return &OrganizationCollectionTreeDataSource{}
+}
+
+// OrganizationCollectionTreeDataSource defines the data source implementation.
+type OrganizationCollectionTreeDataSource struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationCollectionTreeDataSourceModel describes the data source data model.
+type OrganizationCollectionTreeDataSourceModel struct {
+	OrganizationID types.String                                   `tfsdk:"organization_id"`
+	RootPaths      []string                                       `tfsdk:"root_paths"`
+	Nodes          map[string]OrganizationCollectionTreeNodeModel `tfsdk:"nodes"`
+}
+
+// OrganizationCollectionTreeNodeModel describes a node of the collection tree.
+type OrganizationCollectionTreeNodeModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Depth      types.Int64  `tfsdk:"depth"`
+	ParentPath types.String `tfsdk:"parent_path"`
+	ParentID   types.String `tfsdk:"parent_id"`
+	ChildPaths []string     `tfsdk:"child_paths"`
+}
+
+func (d *OrganizationCollectionTreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_collection_tree"
+}
+
+func (d *OrganizationCollectionTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to read the collections of an organization from a Vaultwarden server as a tree, which is built from the `/` separated collection names the same way as the Vaultwarden clients nest them, e.g. `Engineering/Backend` below `Engineering`.\n\n" +
+			"Terraform can't express recursive types, so the tree is returned as the `nodes` map keyed by the full path of every node, which are linked by their `parent_path` and `child_paths`. " +
+			"Parents which only exist as a prefix of a collection name are part of the tree without an `id`. The collection names have to be unique within the organization. Collections whose name cannot be decrypted are skipped if `ignore_decryption_errors` is enabled.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Required:            true,
+			},
+			"root_paths": schema.ListAttribute{
+				MarkdownDescription: "The paths of the top-level nodes of the tree, sorted by path",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"nodes": schema.MapNestedAttribute{
+				MarkdownDescription: "All nodes of the tree, keyed by their full path",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the collection, unset if there is no collection with this path and the node only groups its children",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The last segment of the path",
+							Computed:            true,
+						},
+						"depth": schema.Int64Attribute{
+							MarkdownDescription: "The depth of the node in the tree, starting with `0` for the top-level nodes",
+							Computed:            true,
+						},
+						"parent_path": schema.StringAttribute{
+							MarkdownDescription: "The path of the parent node, unset for the top-level nodes",
+							Computed:            true,
+						},
+						"parent_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the parent collection, unset for the top-level nodes and parents without a collection",
+							Computed:            true,
+						},
+						"child_paths": schema.ListAttribute{
+							MarkdownDescription: "The paths of the direct children of the node, sorted by path",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationCollectionTreeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationCollectionTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationCollectionTreeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := data.OrganizationID.ValueString()
+
+	// Get the collections of the organization from the Vaultwarden server
+	collections, err := d.client.GetOrganizationCollections(ctx, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Organization Collections",
+			fmt.Sprintf("Could not read the collections of organization ID %s: %s", orgID, clientErrorDetail(err)),
+		)
+		return
+	}
+
+	// Decrypt the collection names, which are the paths of the tree
+	collectionIDs := map[string]string{}
+	for _, collection := range collections.Data {
+		name, err := d.client.DecryptOrganizationString(ctx, orgID, collection.Name)
+		if err != nil {
+			if !d.client.IgnoreDecryptionErrors() {
+				resp.Diagnostics.AddError(
+					"Error Decrypting Collection Name",
+					"Could not decrypt the name of collection "+collection.ID+": "+err.Error(),
+				)
+				return
+			}
+
+			// The collection can't be placed in the tree without its name, so it is skipped
+			resp.Diagnostics.AddWarning(
+				"Could Not Decrypt Collection Name",
+				"The name of collection "+collection.ID+" could not be decrypted, the collection is skipped: "+err.Error(),
+			)
+			continue
+		}
+
+		if otherID, exists := collectionIDs[name]; exists {
+			resp.Diagnostics.AddError(
+				"Duplicate Collection Name",
+				fmt.Sprintf("The collections %s and %s of the organization are both named %q, the collections must have unique names to build the tree.", otherID, collection.ID, name),
+			)
+			return
+		}
+		collectionIDs[name] = collection.ID
+	}
+
+	data.RootPaths, data.Nodes = buildCollectionTree(collectionIDs)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// buildCollectionTree builds the tree of the collections keyed by their decrypted names, adding the parents which only
+// exist as a prefix of a name. The parents are found the same way as when creating them, empty segments don't form a
+// parent, e.g. in "/Backend" or "Engineering//Backend".
+func buildCollectionTree(collectionIDs map[string]string) ([]string, map[string]OrganizationCollectionTreeNodeModel) {
+	rootPaths := []string{}
+	nodes := map[string]*OrganizationCollectionTreeNodeModel{}
+
+	var addNode func(nodePath string) *OrganizationCollectionTreeNodeModel
+	addNode = func(nodePath string) *OrganizationCollectionTreeNodeModel {
+		if node, exists := nodes[nodePath]; exists {
+			return node
+		}
+
+		parts := strings.Split(nodePath, "/")
+		node := &OrganizationCollectionTreeNodeModel{
+			ID:         types.StringNull(),
+			Name:       types.StringValue(parts[len(parts)-1]),
+			Depth:      types.Int64Value(0),
+			ParentPath: types.StringNull(),
+			ParentID:   types.StringNull(),
+			ChildPaths: []string{},
+		}
+		if id, exists := collectionIDs[nodePath]; exists {
+			node.ID = types.StringValue(id)
+		}
+		nodes[nodePath] = node
+
+		// The parent is the longest prefix ending in a non-empty segment
+		for i := len(parts) - 1; i > 0; i-- {
+			if parts[i-1] == "" {
+				continue
+			}
+
+			parentPath := strings.Join(parts[:i], "/")
+			parent := addNode(parentPath)
+			parent.ChildPaths = append(parent.ChildPaths, nodePath)
+
+			node.ParentPath = types.StringValue(parentPath)
+			node.ParentID = parent.ID
+			node.Depth = types.Int64Value(parent.Depth.ValueInt64() + 1)
+			return node
+		}
+
+		rootPaths = append(rootPaths, nodePath)
+		return node
+	}
+
+	for name := range collectionIDs {
+		addNode(name)
+	}
+
+	slices.Sort(rootPaths)
+	tree := make(map[string]OrganizationCollectionTreeNodeModel, len(nodes))
+	for nodePath, node := range nodes {
+		slices.Sort(node.ChildPaths)
+		tree[nodePath] = *node
+	}
+
+	return rootPaths, tree
+}
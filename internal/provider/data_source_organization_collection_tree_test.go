@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"os"
+	"testing"
+)
+
+func TestAccOrganizationCollectionTreeDataSource(t *testing.T) {
+	// The collections are created before the test case, which would otherwise only be skipped by it
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client, err := test.GetTestClient(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to get test client: %s", err)
+	}
+
+	org, err := client.CreateOrganization(ctx, models.Organization{Name: gofakeit.Company(), CollectionName: "Default"})
+	if err != nil {
+		t.Fatalf("failed to create organization: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := client.DeleteOrganization(context.Background(), org.ID); err != nil {
+			t.Logf("failed to delete organization %s: %s", org.ID, err)
+		}
+	})
+
+	// "Engineering" only exists as the parent of the nested collections
+	collectionIDs := map[string]string{}
+	for _, name := range []string{"Engineering/Backend", "Engineering/Backend/Databases", "Engineering/Frontend"} {
+		collection, err := client.CreateOrganizationCollection(ctx, org.ID, models.Collection{Name: name})
+		if err != nil {
+			t.Fatalf("failed to create collection %s: %s", name, err)
+		}
+		collectionIDs[name] = collection.ID
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccOrganizationCollectionTreeDataSourceConfig(org.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "root_paths.#", "2"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "root_paths.0", "Default"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "root_paths.1", "Engineering"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.%", "5"),
+					resource.TestCheckNoResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering.id"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering.child_paths.#", "2"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering.child_paths.0", "Engineering/Backend"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering.child_paths.1", "Engineering/Frontend"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering/Backend.id", collectionIDs["Engineering/Backend"]),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering/Backend.name", "Backend"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering/Backend.parent_path", "Engineering"),
+					resource.TestCheckNoResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering/Backend.parent_id"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering/Backend/Databases.depth", "2"),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering/Backend/Databases.parent_id", collectionIDs["Engineering/Backend"]),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_collection_tree.test", "nodes.Engineering/Backend/Databases.child_paths.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationCollectionTreeDataSourceConfig(orgID string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+data "vaultwarden_organization_collection_tree" "test" {
+  organization_id = %[5]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgID)
+}
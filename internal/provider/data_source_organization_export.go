@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationExportDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrganizationExportDataSource{}
+
+func NewOrganizationExportDataSource() datasource.DataSource {
+	return &OrganizationExportDataSource{}
+}
+
+// OrganizationExportDataSource defines the data source implementation.
+type OrganizationExportDataSource struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationExportDataSourceModel describes the data source data model.
+type OrganizationExportDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	CollectionIDs  types.Set    `tfsdk:"collection_ids"`
+	Export         types.String `tfsdk:"export"`
+	ItemCount      types.Int64  `tfsdk:"item_count"`
+}
+
+func (d *OrganizationExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_export"
+}
+
+func (d *OrganizationExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to create an encrypted export of the vault of an organization from a Vaultwarden server, e.g. to store backups.\n\n" +
+			"The export uses the encrypted JSON format of the Bitwarden clients. It stays encrypted with the organization key and can only be imported by members of the organization. " +
+			"The export is created again on every read.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization to export",
+				Required:            true,
+			},
+			"collection_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the collections to export along with their items. Defaults to all collections and items of the organization",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"export": schema.StringAttribute{
+				MarkdownDescription: "The encrypted export in JSON format",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"item_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of exported items",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *OrganizationExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	var collectionIDs []string
+	if !data.CollectionIDs.IsNull() {
+		resp.Diagnostics.Append(data.CollectionIDs.ElementsAs(ctx, &collectionIDs, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Export the organization from the Vaultwarden server
+	export, err := d.client.ExportOrganization(ctx, data.OrganizationID.ValueString(), collectionIDs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Exporting Organization",
+			fmt.Sprintf("Could not export organization ID %s: %s", data.OrganizationID.ValueString(), clientErrorDetail(err)),
+		)
+		return
+	}
+
+	exportJSON, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Encoding Organization Export",
+			fmt.Sprintf("Could not encode the export of organization ID %s: %s", data.OrganizationID.ValueString(), err),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.Export = types.StringValue(string(exportJSON))
+	data.ItemCount = types.Int64Value(int64(len(export.Items)))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
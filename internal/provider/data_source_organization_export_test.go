@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
+	"testing"
+)
+
+func TestAccOrganizationExportDataSource(t *testing.T) {
+	// Generate random data for the test
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccOrganizationExportDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vaultwarden_organization_export.test", "export", regexp.MustCompile(`"encrypted": true`)),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_export.test", "item_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccOrganizationExportDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+}
+
+data "vaultwarden_organization_export" "test" {
+  organization_id = vaultwarden_organization.test.id
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name)
+}
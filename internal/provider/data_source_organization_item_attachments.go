@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"strconv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationItemAttachmentsDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrganizationItemAttachmentsDataSource{}
+
+func NewOrganizationItemAttachmentsDataSource() datasource.DataSource {
+	return &OrganizationItemAttachmentsDataSource{}
+}
+
+// OrganizationItemAttachmentsDataSource defines the data source implementation.
+type OrganizationItemAttachmentsDataSource struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationItemAttachmentsDataSourceModel describes the data source data model.
+type OrganizationItemAttachmentsDataSourceModel struct {
+	ItemID      types.String                      `tfsdk:"item_id"`
+	Attachments []OrganizationItemAttachmentModel `tfsdk:"attachments"`
+}
+
+// OrganizationItemAttachmentModel describes an attachment of the data source.
+type OrganizationItemAttachmentModel struct {
+	ID       types.String `tfsdk:"id"`
+	FileName types.String `tfsdk:"file_name"`
+	Size     types.Int64  `tfsdk:"size"`
+	SizeName types.String `tfsdk:"size_name"`
+}
+
+func (d *OrganizationItemAttachmentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_item_attachments"
+}
+
+func (d *OrganizationItemAttachmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to list the files attached to an item of an organization from a Vaultwarden server. " +
+			"Only the metadata of the attachments is read, the files themselves are not downloaded.",
+
+		Attributes: map[string]schema.Attribute{
+			"item_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the item",
+				Required:            true,
+			},
+			"attachments": schema.ListNestedAttribute{
+				MarkdownDescription: "The attachments of the item",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the attachment",
+							Computed:            true,
+						},
+						"file_name": schema.StringAttribute{
+							MarkdownDescription: "The decrypted file name of the attachment, unset if it cannot be decrypted and `ignore_decryption_errors` is enabled",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "The size of the file in bytes",
+							Computed:            true,
+						},
+						"size_name": schema.StringAttribute{
+							MarkdownDescription: "The human readable size of the file, e.g. `1.5 MB`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationItemAttachmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationItemAttachmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationItemAttachmentsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	itemID := data.ItemID.ValueString()
+
+	// Get the item from the Vaultwarden server, which includes the metadata of its attachments
+	cipher, err := d.client.GetOrganizationCipher(ctx, itemID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Organization Item",
+			fmt.Sprintf("Could not read item ID %s: %s", itemID, clientErrorDetail(err)),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.Attachments = []OrganizationItemAttachmentModel{}
+	for _, attachment := range cipher.Attachments {
+		size, err := strconv.ParseInt(attachment.Size, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Attachment Size",
+				fmt.Sprintf("Could not parse the size %q of attachment %s: %s", attachment.Size, attachment.ID, err),
+			)
+			return
+		}
+
+		model := OrganizationItemAttachmentModel{
+			ID:       types.StringValue(attachment.ID),
+			FileName: types.StringNull(),
+			Size:     types.Int64Value(size),
+			SizeName: types.StringValue(attachment.SizeName),
+		}
+
+		fileName, err := d.client.DecryptOrganizationCipherString(ctx, *cipher, attachment.FileName)
+		if err != nil {
+			if !d.client.IgnoreDecryptionErrors() {
+				resp.Diagnostics.AddError(
+					"Error Decrypting Attachment File Name",
+					"Could not decrypt the file name of attachment "+attachment.ID+": "+err.Error(),
+				)
+				return
+			}
+
+			resp.Diagnostics.AddWarning(
+				"Could Not Decrypt Attachment File Name",
+				"The file name of attachment "+attachment.ID+" could not be decrypted and is left unset: "+err.Error(),
+			)
+		} else {
+			model.FileName = types.StringValue(fileName)
+		}
+
+		data.Attachments = append(data.Attachments, model)
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"os"
+	"testing"
+)
+
+func TestAccOrganizationItemAttachmentsDataSource(t *testing.T) {
+	// The item is created before the test case, which would otherwise only be skipped by it
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client, err := test.GetTestClient(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to get test client: %s", err)
+	}
+
+	org, err := client.CreateOrganization(ctx, models.Organization{Name: gofakeit.Company(), CollectionName: "Default"})
+	if err != nil {
+		t.Fatalf("failed to create organization: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := client.DeleteOrganization(context.Background(), org.ID); err != nil {
+			t.Logf("failed to delete organization %s: %s", org.ID, err)
+		}
+	})
+
+	collection, err := client.CreateOrganizationCollection(ctx, org.ID, models.Collection{Name: gofakeit.ProductName()})
+	if err != nil {
+		t.Fatalf("failed to create collection: %s", err)
+	}
+
+	// Attachments can't be uploaded by the provider, so only an item without attachments is read
+	item, err := client.CreateOrganizationCipher(ctx, org.ID, models.Cipher{
+		Type:       models.CipherTypeSecureNote,
+		Name:       gofakeit.AppName(),
+		SecureNote: &models.SecureNote{},
+	}, []string{collection.ID})
+	if err != nil {
+		t.Fatalf("failed to create item: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccOrganizationItemAttachmentsDataSourceConfig(item.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_item_attachments.test", "item_id", item.ID),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization_item_attachments.test", "attachments.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationItemAttachmentsDataSourceConfig(itemID string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+data "vaultwarden_organization_item_attachments" "test" {
+  item_id = %[5]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, itemID)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationPublicKeyDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrganizationPublicKeyDataSource{}
+
+func NewOrganizationPublicKeyDataSource() datasource.DataSource {
+	return &OrganizationPublicKeyDataSource{}
+}
+
+// OrganizationPublicKeyDataSource defines the data source implementation.
+type OrganizationPublicKeyDataSource struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationPublicKeyDataSourceModel describes the data source data model.
+type OrganizationPublicKeyDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	PublicKey      types.String `tfsdk:"public_key"`
+	PublicKeyPEM   types.String `tfsdk:"public_key_pem"`
+	Fingerprint    types.String `tfsdk:"fingerprint"`
+}
+
+func (d *OrganizationPublicKeyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_public_key"
+}
+
+func (d *OrganizationPublicKeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to get the public key of an organization from a Vaultwarden server.",
+
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Required:            true,
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The public key of the organization, a base64 encoded DER key as returned by the Vaultwarden server",
+				Computed:            true,
+			},
+			"public_key_pem": schema.StringAttribute{
+				MarkdownDescription: "The public key of the organization in PEM format",
+				Computed:            true,
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The hex encoded SHA-256 hash of the DER encoded public key. " +
+					"This is not the fingerprint phrase shown by the Vaultwarden clients",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *OrganizationPublicKeyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationPublicKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationPublicKeyDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the public key from the Vaultwarden server
+	publicKey, err := d.client.GetOrganizationPublicKey(ctx, data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Organization Public Key",
+			fmt.Sprintf("Could not read the public key of organization ID %s: %s", data.OrganizationID.ValueString(), clientErrorDetail(err)),
+		)
+		return
+	}
+
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Decoding Organization Public Key",
+			fmt.Sprintf("Could not decode the public key of organization ID %s: %s", data.OrganizationID.ValueString(), err),
+		)
+		return
+	}
+
+	// Map response body to schema
+	fingerprint := sha256.Sum256(der)
+	data.PublicKey = types.StringValue(publicKey)
+	data.PublicKeyPEM = types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	data.Fingerprint = types.StringValue(hex.EncodeToString(fingerprint[:]))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
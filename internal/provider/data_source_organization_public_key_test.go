@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
+	"testing"
+)
+
+func TestAccOrganizationPublicKeyDataSource(t *testing.T) {
+	// Generate random data for the test
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccOrganizationPublicKeyDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vaultwarden_organization_public_key.test", "public_key"),
+					resource.TestMatchResourceAttr("data.vaultwarden_organization_public_key.test", "public_key_pem", regexp.MustCompile(`^-----BEGIN PUBLIC KEY-----\n`)),
+					resource.TestMatchResourceAttr("data.vaultwarden_organization_public_key.test", "fingerprint", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccOrganizationPublicKeyDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+}
+
+data "vaultwarden_organization_public_key" "test" {
+  organization_id = vaultwarden_organization.test.id
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name)
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
 	"testing"
 )
 
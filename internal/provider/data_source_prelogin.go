@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PreloginDataSource{}
+var _ datasource.DataSourceWithConfigure = &PreloginDataSource{}
+
+func NewPreloginDataSource() datasource.DataSource {
+	return &PreloginDataSource{}
+}
+
+// PreloginDataSource defines the data source implementation.
+type PreloginDataSource struct {
+	client *vaultwarden.Client
+}
+
+// PreloginDataSourceModel describes the data source data model.
+type PreloginDataSourceModel struct {
+	Email          types.String `tfsdk:"email"`
+	KdfType        types.String `tfsdk:"kdf_type"`
+	KdfIterations  types.Int64  `tfsdk:"kdf_iterations"`
+	KdfMemory      types.Int64  `tfsdk:"kdf_memory"`
+	KdfParallelism types.Int64  `tfsdk:"kdf_parallelism"`
+}
+
+func (d *PreloginDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prelogin"
+}
+
+func (d *PreloginDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to get the KDF parameters of an account from a Vaultwarden server, e.g. to check that accounts use Argon2id.\n\n" +
+			"The server returns its default parameters for emails without an account.",
+
+		Attributes: map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email of the account",
+				Required:            true,
+				Validators: []validator.String{
+					validEmail(),
+				},
+			},
+			"kdf_type": schema.StringAttribute{
+				MarkdownDescription: "The key derivation function of the account (PBKDF2_SHA256, Argon2id)",
+				Computed:            true,
+			},
+			"kdf_iterations": schema.Int64Attribute{
+				MarkdownDescription: "The number of iterations of the key derivation function",
+				Computed:            true,
+			},
+			"kdf_memory": schema.Int64Attribute{
+				MarkdownDescription: "The memory of the key derivation function in MiB, only set for Argon2id",
+				Computed:            true,
+			},
+			"kdf_parallelism": schema.Int64Attribute{
+				MarkdownDescription: "The parallelism of the key derivation function, only set for Argon2id",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PreloginDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PreloginDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PreloginDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the KDF parameters from the Vaultwarden server
+	preloginResp, err := d.client.PreLoginForEmail(ctx, data.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading KDF Parameters",
+			fmt.Sprintf("Could not read the KDF parameters of %s: %s", data.Email.ValueString(), clientErrorDetail(err)),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.KdfType = types.StringValue(preloginResp.Kdf.String())
+	data.KdfIterations = types.Int64Value(int64(preloginResp.KdfIterations))
+	data.KdfMemory = types.Int64Null()
+	data.KdfParallelism = types.Int64Null()
+	if preloginResp.Kdf == models.KdfTypeArgon2 {
+		data.KdfMemory = types.Int64Value(int64(preloginResp.KdfMemory))
+		data.KdfParallelism = types.Int64Value(int64(preloginResp.KdfParallelism))
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccPreloginDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccRecordedProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPreloginDataSourceConfig(test.TestEmail),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vaultwarden_prelogin.test", "email", test.TestEmail),
+					resource.TestCheckResourceAttrSet("data.vaultwarden_prelogin.test", "kdf_type"),
+					resource.TestCheckResourceAttrSet("data.vaultwarden_prelogin.test", "kdf_iterations"),
+				),
+			},
+		},
+	})
+}
+
+// Base configuration
+func testAccPreloginDataSourceConfig(email string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+data "vaultwarden_prelogin" "test" {
+  email = %[5]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, email)
+}
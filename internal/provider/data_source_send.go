@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SendDataSource{}
+var _ datasource.DataSourceWithConfigure = &SendDataSource{}
+
+func NewSendDataSource() datasource.DataSource {
+	return &SendDataSource{}
+}
+
+// SendDataSource defines the data source implementation.
+type SendDataSource struct {
+	client *vaultwarden.Client
+}
+
+// SendDataSourceModel describes the data source data model.
+type SendDataSourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	AccessID             types.String `tfsdk:"access_id"`
+	AccessURL            types.String `tfsdk:"access_url"`
+	AccessCount          types.Int64  `tfsdk:"access_count"`
+	MaxAccessCount       types.Int64  `tfsdk:"max_access_count"`
+	RemainingAccessCount types.Int64  `tfsdk:"remaining_access_count"`
+	Disabled             types.Bool   `tfsdk:"disabled"`
+	ExpirationDate       types.String `tfsdk:"expiration_date"`
+	DeletionDate         types.String `tfsdk:"deletion_date"`
+}
+
+func (d *SendDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_send"
+}
+
+func (d *SendDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source allows you to get the public access URL of a Send of the authenticated user from a Vaultwarden server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Send",
+				Required:            true,
+			},
+			"access_id": schema.StringAttribute{
+				MarkdownDescription: "The access ID of the Send, which is part of its public URL",
+				Computed:            true,
+			},
+			"access_url": schema.StringAttribute{
+				MarkdownDescription: "The public URL to access the Send. It contains the key of the Send, anyone with the URL can access it",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"access_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of times the Send has been accessed",
+				Computed:            true,
+			},
+			"max_access_count": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of times the Send can be accessed, unset if there is no limit",
+				Computed:            true,
+			},
+			"remaining_access_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of times the Send can still be accessed, unset if there is no limit",
+				Computed:            true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Send is disabled and can no longer be accessed",
+				Computed:            true,
+			},
+			"expiration_date": schema.StringAttribute{
+				MarkdownDescription: "The date after which the Send can no longer be accessed, unset if it doesn't expire",
+				Computed:            true,
+			},
+			"deletion_date": schema.StringAttribute{
+				MarkdownDescription: "The date the Send is deleted",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SendDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SendDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SendDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the Send from the Vaultwarden server
+	send, err := d.client.GetSend(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Send",
+			fmt.Sprintf("Could not read Send ID %s: %s", data.ID.ValueString(), clientErrorDetail(err)),
+		)
+		return
+	}
+
+	accessURL, err := d.client.SendAccessURL(ctx, send)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Building Send Access URL",
+			fmt.Sprintf("Could not build the access URL of Send ID %s: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Map response body to schema
+	data.ID = types.StringValue(send.ID)
+	data.AccessID = types.StringValue(send.AccessID)
+	data.AccessURL = types.StringValue(accessURL)
+	data.AccessCount = types.Int64Value(send.AccessCount)
+	data.MaxAccessCount = types.Int64PointerValue(send.MaxAccessCount)
+	data.RemainingAccessCount = types.Int64Null()
+	if send.MaxAccessCount != nil {
+		data.RemainingAccessCount = types.Int64Value(max(*send.MaxAccessCount-send.AccessCount, 0))
+	}
+	data.Disabled = types.BoolValue(send.Disabled)
+	data.ExpirationDate = types.StringPointerValue(send.ExpirationDate)
+	data.DeletionDate = types.StringValue(send.DeletionDate)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
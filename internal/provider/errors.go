@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"strings"
+)
+
+// isNotFoundError returns whether the error indicates that the requested object does not exist
+func isNotFoundError(err error) bool {
+	var vwErr *models.VaultwardenError
+	return errors.As(err, &vwErr) && vwErr.IsNotFound()
+}
+
+// clientErrorDetail returns the error message of a failed Vaultwarden client call, extended
+// with guidance for authentication and validation failures reported by the API. Any secrets
+// in the message are redacted.
+func clientErrorDetail(err error) string {
+	message := vaultwarden.RedactSecrets(err.Error())
+
+	var vwErr *models.VaultwardenError
+	if !errors.As(err, &vwErr) {
+		return message
+	}
+
+	switch {
+	case vwErr.IsAuthenticationError():
+		return message + "\n\nThe Vaultwarden server rejected the authentication. " +
+			"Verify that the provider credentials are correct and that the account has access to the requested object."
+	case vwErr.IsValidationError():
+		return message + validationErrorDetail(vwErr.ValidationMessages())
+	default:
+		return message
+	}
+}
+
+// addClientError adds the error of a failed Vaultwarden client call to the diagnostics. Validation errors of fields
+// in fieldPaths, keyed by their lowercase API name, are reported on the matching attribute, the remaining errors are
+// reported together with the detail.
+func addClientError(diags *diag.Diagnostics, summary, detail string, err error, fieldPaths map[string]path.Path) {
+	var vwErr *models.VaultwardenError
+	if !errors.As(err, &vwErr) || !vwErr.IsValidationError() || len(fieldPaths) == 0 {
+		diags.AddError(summary, detail+clientErrorDetail(err))
+		return
+	}
+
+	remaining := models.VaultwardenError{ValidationErrors: map[string][]string{}}
+	for field, messages := range vwErr.ValidationErrors {
+		attrPath, ok := validationErrorPath(field, fieldPaths)
+		if !ok {
+			remaining.ValidationErrors[field] = messages
+			continue
+		}
+
+		for _, message := range messages {
+			diags.AddAttributeError(attrPath, summary, detail+vaultwarden.RedactSecrets(err.Error())+"\n\nThe Vaultwarden server rejected the value: "+message)
+		}
+	}
+
+	// Report the error itself unless every validation error has been reported on an attribute
+	if len(remaining.ValidationErrors) > 0 || len(vwErr.ValidationErrors) == 0 {
+		diags.AddError(summary, detail+vaultwarden.RedactSecrets(err.Error())+validationErrorDetail(remaining.ValidationMessages()))
+	}
+}
+
+// validationErrorDetail returns the guidance for validation error messages, which is empty if there are none
+func validationErrorDetail(messages []string) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	return "\n\nThe Vaultwarden server rejected the request as invalid:\n  - " + strings.Join(messages, "\n  - ")
+}
+
+// validationErrorPath returns the attribute path of a validation error field, ignoring the index and nested fields
+// of collections and objects (e.g. "Emails[0]" or "Permissions.ManageUsers") if the field itself is not mapped
+func validationErrorPath(field string, fieldPaths map[string]path.Path) (path.Path, bool) {
+	field = strings.ToLower(field)
+	if attrPath, ok := fieldPaths[field]; ok {
+		return attrPath, true
+	}
+
+	if i := strings.IndexAny(field, "[."); i > 0 {
+		attrPath, ok := fieldPaths[field[:i]]
+		return attrPath, ok
+	}
+
+	return path.Empty(), false
+}
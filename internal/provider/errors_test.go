@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAddClientError(t *testing.T) {
+	fieldPaths := map[string]path.Path{
+		"name":   path.Root("name"),
+		"emails": path.Root("email"),
+	}
+
+	testCases := map[string]struct {
+		err            error
+		attributePaths []path.Path
+		generalError   bool
+		generalDetail  string
+	}{
+		"not a Vaultwarden error": {
+			err:          fmt.Errorf("connection refused"),
+			generalError: true,
+		},
+		"not a validation error": {
+			err:          fmt.Errorf("failed: %w", &models.VaultwardenError{StatusCode: http.StatusInternalServerError, Message: "boom"}),
+			generalError: true,
+		},
+		"all fields mapped": {
+			err: fmt.Errorf("failed: %w", &models.VaultwardenError{
+				StatusCode:       http.StatusBadRequest,
+				Message:          "The model state is invalid.",
+				ValidationErrors: map[string][]string{"Name": {"The field Name is required."}},
+			}),
+			attributePaths: []path.Path{path.Root("name")},
+		},
+		"indexed field mapped": {
+			err: fmt.Errorf("failed: %w", &models.VaultwardenError{
+				StatusCode:       http.StatusBadRequest,
+				Message:          "The model state is invalid.",
+				ValidationErrors: map[string][]string{"Emails[0]": {"Invalid email."}},
+			}),
+			attributePaths: []path.Path{path.Root("email")},
+		},
+		"unmapped fields remain in the general error": {
+			err: fmt.Errorf("failed: %w", &models.VaultwardenError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "The model state is invalid.",
+				ValidationErrors: map[string][]string{
+					"Name": {"The field Name is required."},
+					"":     {"Something else is wrong."},
+				},
+			}),
+			attributePaths: []path.Path{path.Root("name")},
+			generalError:   true,
+			generalDetail:  "Something else is wrong.",
+		},
+		"validation error without fields": {
+			err:          fmt.Errorf("failed: %w", &models.VaultwardenError{StatusCode: http.StatusBadRequest, Message: "Invalid request"}),
+			generalError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addClientError(&diags, "Error", "Could not do it: ", testCase.err, fieldPaths)
+
+			var attributePaths []path.Path
+			generalErrors := 0
+			for _, d := range diags {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					attributePaths = append(attributePaths, withPath.Path())
+					continue
+				}
+
+				generalErrors++
+				if !strings.HasPrefix(d.Detail(), "Could not do it: ") {
+					t.Errorf("unexpected detail: %s", d.Detail())
+				}
+				if testCase.generalDetail != "" && !strings.Contains(d.Detail(), testCase.generalDetail) {
+					t.Errorf("expected detail to contain %q, got: %s", testCase.generalDetail, d.Detail())
+				}
+				if strings.Contains(d.Detail(), "The field Name is required.") {
+					t.Errorf("expected mapped validation error to be left out of the detail, got: %s", d.Detail())
+				}
+			}
+
+			if len(attributePaths) != len(testCase.attributePaths) {
+				t.Fatalf("expected attribute errors on %v, got: %v", testCase.attributePaths, attributePaths)
+			}
+			for i, attributePath := range attributePaths {
+				if !attributePath.Equal(testCase.attributePaths[i]) {
+					t.Errorf("expected attribute error on %s, got: %s", testCase.attributePaths[i], attributePath)
+				}
+			}
+
+			if testCase.generalError != (generalErrors == 1) {
+				t.Errorf("expected general error: %t, got %d", testCase.generalError, generalErrors)
+			}
+		})
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		notFound bool
+	}{
+		"not a Vaultwarden error": {
+			err: fmt.Errorf("connection refused"),
+		},
+		"404 status": {
+			err:      &models.VaultwardenError{StatusCode: http.StatusNotFound},
+			notFound: true,
+		},
+		"400 status with a missing object": {
+			err:      fmt.Errorf("failed: %w", &models.VaultwardenError{StatusCode: http.StatusBadRequest, Message: "Cipher doesn't exist"}),
+			notFound: true,
+		},
+		"400 status with another message": {
+			err: &models.VaultwardenError{StatusCode: http.StatusBadRequest, Message: "Invalid key provided"},
+		},
+		"400 status with a generic not found message": {
+			err: &models.VaultwardenError{StatusCode: http.StatusBadRequest, Message: "Master password hash not found"},
+		},
+		"401 status with a missing object": {
+			err: &models.VaultwardenError{StatusCode: http.StatusUnauthorized, Message: "The current user isn't member of the organization"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := isNotFoundError(testCase.err); got != testCase.notFound {
+				t.Errorf("expected %t, got %t", testCase.notFound, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+// OrganizationIdentityModel describes the identity of an organization.
+type OrganizationIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// OrganizationResourceIdentityModel describes the identity of a resource which belongs to an organization.
+type OrganizationResourceIdentityModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// organizationResourceIdentitySchema returns the identity schema of a resource which belongs to an organization
+func organizationResourceIdentitySchema(idDescription string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization_id": identityschema.StringAttribute{
+				Description:       "ID of the organization",
+				RequiredForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       idDescription,
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// organizationResourceImportIDs returns the organization ID and ID of a resource to import, either from an import
+// identifier with the format organization_id/id or from the identity of an import block
+func organizationResourceImportIDs(ctx context.Context, req resource.ImportStateRequest, format string, diags *diag.Diagnostics) (string, string, bool) {
+	if req.ID == "" && req.Identity != nil {
+		var identity OrganizationResourceIdentityModel
+		diags.Append(req.Identity.Get(ctx, &identity)...)
+
+		if diags.HasError() {
+			return "", "", false
+		}
+
+		return identity.OrganizationID.ValueString(), identity.ID.ValueString(), true
+	}
+
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 {
+		diags.AddError(
+			"Invalid ID format",
+			"Expected import identifier with format: "+format,
+		)
+		return "", "", false
+	}
+
+	return idParts[0], idParts[1], true
+}
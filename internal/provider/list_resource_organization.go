@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &OrganizationList{}
+var _ list.ListResourceWithConfigure = &OrganizationList{}
+
+func OrganizationListResource() list.ListResource {
+	return &OrganizationList{}
+}
+
+// OrganizationList defines the list resource implementation.
+type OrganizationList struct {
+	client *vaultwarden.Client
+}
+
+func (r *OrganizationList) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (r *OrganizationList) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the organizations the authenticated user is a member of.",
+	}
+}
+
+func (r *OrganizationList) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationList) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	// The organizations of the user are part of the profile
+	profile, err := r.client.GetProfile(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error listing Vaultwarden organizations",
+			"Could not read the profile of the authenticated user: "+clientErrorDetail(err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, org := range profile.Organizations {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = org.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, OrganizationIdentityModel{ID: types.StringValue(org.ID)})...)
+
+			// Populate the resource the same way as an import
+			if req.IncludeResource {
+				orgResp, err := r.client.GetOrganization(ctx, org.ID)
+				if err != nil {
+					result.Diagnostics.AddError(
+						"Error reading Vaultwarden organization",
+						"Could not read organization with ID "+org.ID+": "+clientErrorDetail(err),
+					)
+				} else {
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), orgResp.ID)...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), orgResp.Name)...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("billing_email"), orgResp.BillingEmail)...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("sso_identifier"), organizationSSOIdentifier(orgResp))...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("use_groups"), orgResp.UseGroups)...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("use_events"), orgResp.UseEvents)...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("use_api"), orgResp.UseAPI)...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("use_directory"), orgResp.UseDirectory)...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &OrganizationCollectionList{}
+var _ list.ListResourceWithConfigure = &OrganizationCollectionList{}
+
+func OrganizationCollectionListResource() list.ListResource {
+	return &OrganizationCollectionList{}
+}
+
+// OrganizationCollectionList defines the list resource implementation.
+type OrganizationCollectionList struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationCollectionListModel describes the list resource configuration model.
+type OrganizationCollectionListModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func (r *OrganizationCollectionList) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_collection"
+}
+
+func (r *OrganizationCollectionList) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the collections of an organization.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization to list the collections of",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *OrganizationCollectionList) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationCollectionList) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data OrganizationCollectionListModel
+
+	// Read the list configuration into the model
+	diags := req.Config.Get(ctx, &data)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	orgID := data.OrganizationID.ValueString()
+
+	collections, err := r.client.GetOrganizationCollections(ctx, orgID)
+	if err != nil {
+		diags.AddError(
+			"Error listing Vaultwarden organization collections",
+			"Could not list collections of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, collection := range collections.Data {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = collection.ID
+			result.Diagnostics.Append(result.Identity.Set(ctx, OrganizationResourceIdentityModel{
+				OrganizationID: types.StringValue(orgID),
+				ID:             types.StringValue(collection.ID),
+			})...)
+
+			// Use the decrypted name for display, leaving it unset in the resource if it cannot be decrypted
+			name, err := r.client.DecryptOrganizationString(ctx, orgID, collection.Name)
+			if err != nil {
+				if !r.client.IgnoreDecryptionErrors() {
+					result.Diagnostics.AddError(
+						"Error decrypting collection name",
+						"Could not decrypt the name of organization collection "+collection.ID+": "+err.Error(),
+					)
+				}
+			} else {
+				result.DisplayName = name
+			}
+
+			// Populate the resource the same way as an import
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("organization_id"), orgID)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), collection.ID)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("create_parents"), false)...)
+				if err == nil {
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), name)...)
+				}
+				if collection.ExternalID != "" {
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("external_id"), collection.ExternalID)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
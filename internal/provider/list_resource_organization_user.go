@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &OrganizationUserList{}
+var _ list.ListResourceWithConfigure = &OrganizationUserList{}
+
+func OrganizationUserListResource() list.ListResource {
+	return &OrganizationUserList{}
+}
+
+// OrganizationUserList defines the list resource implementation.
+type OrganizationUserList struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationUserListModel describes the list resource configuration model.
+type OrganizationUserListModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func (r *OrganizationUserList) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_user"
+}
+
+func (r *OrganizationUserList) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the users of an organization.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization to list the users of",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *OrganizationUserList) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationUserList) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data OrganizationUserListModel
+
+	// Read the list configuration into the model
+	diags := req.Config.Get(ctx, &data)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	orgID := data.OrganizationID.ValueString()
+
+	users, err := r.client.GetOrganizationUsers(ctx, orgID)
+	if err != nil {
+		diags.AddError(
+			"Error listing Vaultwarden organization users",
+			"Could not list users of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, user := range users.Data {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = user.Email
+			result.Diagnostics.Append(result.Identity.Set(ctx, OrganizationResourceIdentityModel{
+				OrganizationID: types.StringValue(orgID),
+				ID:             types.StringValue(user.ID),
+			})...)
+
+			// Populate the resource the same way as an import
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("organization_id"), orgID)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), user.ID)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("email"), user.Email)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("type"), user.Type.String())...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("access_all"), user.AccessAll)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("status"), user.Status.String())...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("revoke_on_destroy"), false)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("registration_url"), organizationUserRegistrationURL(ctx, r.client, user.Status.String(), user.Email))...)
+
+				if user.Type == models.UserOrgTypeCustom {
+					permissions, permissionDiags := flattenOrganizationUserPermissions(ctx, user.Permissions)
+					result.Diagnostics.Append(permissionDiags...)
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("permissions"), permissions)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
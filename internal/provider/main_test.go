@@ -0,0 +1,11 @@
+package provider
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(test.Main(m))
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// logMetrics exposes the measurements of the Vaultwarden client in the logs of its subsystems
+type logMetrics struct{}
+
+var _ vaultwarden.Metrics = logMetrics{}
+
+func (logMetrics) RequestCompleted(ctx context.Context, request vaultwarden.RequestMetrics) {
+	fields := map[string]interface{}{
+		"method":      request.Method,
+		"route":       request.Route,
+		"status":      request.StatusCode,
+		"duration_ms": request.Duration.Milliseconds(),
+	}
+	if request.Err != nil {
+		fields["error"] = request.Err.Error()
+	}
+	tflog.SubsystemDebug(vaultwarden.WithLogSubsystems(ctx), vaultwarden.LogSubsystemHTTP, "Vaultwarden API request completed", fields)
+}
+
+func (logMetrics) RequestRetried(ctx context.Context, retry vaultwarden.RetryMetrics) {
+	tflog.SubsystemDebug(vaultwarden.WithLogSubsystems(ctx), vaultwarden.LogSubsystemHTTP, "Retrying Vaultwarden API request", map[string]interface{}{
+		"method": retry.Method,
+		"route":  retry.Route,
+		"reason": retry.Reason,
+	})
+}
+
+func (logMetrics) LoginCompleted(ctx context.Context, login vaultwarden.LoginMetrics) {
+	fields := map[string]interface{}{
+		"kind":        login.Kind,
+		"duration_ms": login.Duration.Milliseconds(),
+	}
+	if login.Err != nil {
+		fields["error"] = login.Err.Error()
+	}
+	tflog.SubsystemDebug(vaultwarden.WithLogSubsystems(ctx), vaultwarden.LogSubsystemAuth, "Vaultwarden login completed", fields)
+}
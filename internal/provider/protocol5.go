@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// NewProtocol5Server returns a factory for a protocol version 5 server of the provider, for Terraform
+// and OpenTofu releases that don't support protocol version 6. Protocol version 5 cannot represent
+// nested attributes, so they are served as attributes of object types or as nested blocks where that
+// keeps their behavior, and the types using other nested attributes are left out.
+func NewProtocol5Server(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	server, err := tf6to5server.DowngradeServer(ctx, func() tfprotov6.ProviderServer {
+		return protocol5CompatibleServer{
+			ProviderServer: providerserver.NewProtocol6(New(version)())(),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() tfprotov5.ProviderServer {
+		return server
+	}, nil
+}
+
+// protocol5CompatibleServer rewrites the schemas of a protocol version 6 server into schemas protocol
+// version 5 can represent, so that it can be downgraded to protocol version 5. The types whose schemas
+// cannot be rewritten are hidden. Terraform doesn't send requests for types that are not part of the
+// schema, so only the schema and metadata responses are filtered.
+type protocol5CompatibleServer struct {
+	tfprotov6.ProviderServer
+}
+
+// protocol6Types lists the types of each kind whose schemas require protocol version 6
+type protocol6Types struct {
+	resources          map[string]bool
+	dataSources        map[string]bool
+	ephemeralResources map[string]bool
+	listResources      map[string]bool
+	actions            map[string]bool
+}
+
+func (s protocol5CompatibleServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	excluded := downgradeProviderSchema(resp)
+	maps.DeleteFunc(resp.ResourceSchemas, excludedKey[*tfprotov6.Schema](excluded.resources))
+	maps.DeleteFunc(resp.DataSourceSchemas, excludedKey[*tfprotov6.Schema](excluded.dataSources))
+	maps.DeleteFunc(resp.EphemeralResourceSchemas, excludedKey[*tfprotov6.Schema](excluded.ephemeralResources))
+	maps.DeleteFunc(resp.ListResourceSchemas, excludedKey[*tfprotov6.Schema](excluded.listResources))
+	maps.DeleteFunc(resp.ActionSchemas, excludedKey[*tfprotov6.ActionSchema](excluded.actions))
+
+	return resp, nil
+}
+
+func (s protocol5CompatibleServer) GetMetadata(ctx context.Context, req *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	schemaResp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := downgradeProviderSchema(schemaResp)
+	resp.Resources = slices.DeleteFunc(resp.Resources, func(m tfprotov6.ResourceMetadata) bool {
+		return excluded.resources[m.TypeName]
+	})
+	resp.DataSources = slices.DeleteFunc(resp.DataSources, func(m tfprotov6.DataSourceMetadata) bool {
+		return excluded.dataSources[m.TypeName]
+	})
+	resp.EphemeralResources = slices.DeleteFunc(resp.EphemeralResources, func(m tfprotov6.EphemeralResourceMetadata) bool {
+		return excluded.ephemeralResources[m.TypeName]
+	})
+	resp.ListResources = slices.DeleteFunc(resp.ListResources, func(m tfprotov6.ListResourceMetadata) bool {
+		return excluded.listResources[m.TypeName]
+	})
+	resp.Actions = slices.DeleteFunc(resp.Actions, func(m tfprotov6.ActionMetadata) bool {
+		return excluded.actions[m.TypeName]
+	})
+
+	return resp, nil
+}
+
+// ConfigureProvider warns about the types left out of the schema, as Terraform doesn't report the
+// diagnostics of the schema response
+func (s protocol5CompatibleServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	resp, err := s.ProviderServer.ConfigureProvider(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	schemaResp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if names := downgradeProviderSchema(schemaResp).names(); len(names) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Summary:  "Types unavailable with plugin protocol version 5",
+			Detail: "Terraform uses plugin protocol version 5 to communicate with the provider, which cannot represent the schemas of the following types: " +
+				strings.Join(names, ", ") + ". Use a Terraform or OpenTofu release supporting plugin protocol version 6 to use them.",
+		})
+	}
+
+	return resp, nil
+}
+
+func (s protocol5CompatibleServer) GetResourceIdentitySchemas(ctx context.Context, req *tfprotov6.GetResourceIdentitySchemasRequest) (*tfprotov6.GetResourceIdentitySchemasResponse, error) {
+	resp, err := s.ProviderServer.GetResourceIdentitySchemas(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	schemaResp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := downgradeProviderSchema(schemaResp)
+	maps.DeleteFunc(resp.IdentitySchemas, excludedKey[*tfprotov6.ResourceIdentitySchema](excluded.resources))
+
+	return resp, nil
+}
+
+// names returns the sorted names of the excluded types
+func (t protocol6Types) names() []string {
+	var names []string
+	for _, excluded := range []map[string]bool{t.resources, t.dataSources, t.ephemeralResources, t.listResources, t.actions} {
+		for name, ok := range excluded {
+			if ok {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+// downgradeProviderSchema rewrites the schemas of the provider schema for protocol version 5 and returns
+// the types whose schemas cannot be rewritten. List resources are also excluded if their resource is, as
+// they cannot be used without it.
+func downgradeProviderSchema(resp *tfprotov6.GetProviderSchemaResponse) protocol6Types {
+	excluded := protocol6Types{
+		resources:          make(map[string]bool),
+		dataSources:        make(map[string]bool),
+		ephemeralResources: make(map[string]bool),
+		listResources:      make(map[string]bool),
+		actions:            make(map[string]bool),
+	}
+
+	for name, schema := range resp.ResourceSchemas {
+		resp.ResourceSchemas[name], excluded.resources[name] = downgradeSchema(schema)
+	}
+	for name, schema := range resp.DataSourceSchemas {
+		resp.DataSourceSchemas[name], excluded.dataSources[name] = downgradeSchema(schema)
+	}
+	for name, schema := range resp.EphemeralResourceSchemas {
+		resp.EphemeralResourceSchemas[name], excluded.ephemeralResources[name] = downgradeSchema(schema)
+	}
+	for name, schema := range resp.ListResourceSchemas {
+		resp.ListResourceSchemas[name], excluded.listResources[name] = downgradeSchema(schema)
+		excluded.listResources[name] = excluded.listResources[name] || excluded.resources[name]
+	}
+	for name, schema := range resp.ActionSchemas {
+		if schema == nil {
+			continue
+		}
+		downgraded := *schema
+		downgraded.Schema, excluded.actions[name] = downgradeSchema(schema.Schema)
+		resp.ActionSchemas[name] = &downgraded
+	}
+
+	return excluded
+}
+
+// downgradeSchema returns a copy of the schema which protocol version 5 can represent, and whether
+// the schema requires protocol version 6 instead
+func downgradeSchema(schema *tfprotov6.Schema) (*tfprotov6.Schema, bool) {
+	if schema == nil {
+		return nil, false
+	}
+
+	block, ok := downgradeBlock(schema.Block)
+	if !ok {
+		return schema, true
+	}
+
+	downgraded := *schema
+	downgraded.Block = block
+
+	return &downgraded, false
+}
+
+// downgradeBlock returns a copy of the block without nested attributes. Computed nested attributes
+// become attributes of the equivalent object type, as their values are not configured, and optional or
+// required single nested attributes become single nested blocks, which behave the same. Other nested
+// attributes have no equivalent, for instance a list of nested blocks is empty instead of null when it
+// is not configured.
+func downgradeBlock(block *tfprotov6.SchemaBlock) (*tfprotov6.SchemaBlock, bool) {
+	if block == nil {
+		return nil, true
+	}
+
+	downgraded := *block
+	downgraded.Attributes = nil
+	downgraded.BlockTypes = nil
+
+	for _, attribute := range block.Attributes {
+		switch {
+		case attribute.NestedType == nil:
+			downgraded.Attributes = append(downgraded.Attributes, attribute)
+		case attribute.Computed && !attribute.Optional:
+			objectAttribute := *attribute
+			objectAttribute.Type = attribute.ValueType()
+			objectAttribute.NestedType = nil
+			objectAttribute.Sensitive = attribute.Sensitive || hasSensitiveAttribute(attribute.NestedType.Attributes)
+			downgraded.Attributes = append(downgraded.Attributes, &objectAttribute)
+		case attribute.NestedType.Nesting == tfprotov6.SchemaObjectNestingModeSingle && !attribute.Computed && !attribute.Sensitive && !attribute.WriteOnly:
+			nestedBlock, ok := downgradeBlock(&tfprotov6.SchemaBlock{
+				Attributes:      attribute.NestedType.Attributes,
+				Description:     attribute.Description,
+				DescriptionKind: attribute.DescriptionKind,
+				Deprecated:      attribute.Deprecated,
+			})
+			if !ok {
+				return nil, false
+			}
+
+			var items int64
+			if attribute.Required {
+				items = 1
+			}
+			downgraded.BlockTypes = append(downgraded.BlockTypes, &tfprotov6.SchemaNestedBlock{
+				TypeName: attribute.Name,
+				Block:    nestedBlock,
+				Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+				MinItems: items,
+				MaxItems: items,
+			})
+		default:
+			return nil, false
+		}
+	}
+
+	for _, nestedBlock := range block.BlockTypes {
+		downgradedBlock, ok := downgradeBlock(nestedBlock.Block)
+		if !ok {
+			return nil, false
+		}
+
+		downgradedNestedBlock := *nestedBlock
+		downgradedNestedBlock.Block = downgradedBlock
+		downgraded.BlockTypes = append(downgraded.BlockTypes, &downgradedNestedBlock)
+	}
+
+	return &downgraded, true
+}
+
+// hasSensitiveAttribute reports whether any of the attributes or their nested attributes is sensitive
+func hasSensitiveAttribute(attributes []*tfprotov6.SchemaAttribute) bool {
+	for _, attribute := range attributes {
+		if attribute.Sensitive || (attribute.NestedType != nil && hasSensitiveAttribute(attribute.NestedType.Attributes)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// excludedKey returns a function reporting whether the key of a map entry is excluded
+func excludedKey[V any](excluded map[string]bool) func(string, V) bool {
+	return func(name string, _ V) bool {
+		return excluded[name]
+	}
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"strings"
+	"testing"
+)
+
+func TestProtocol5Server(t *testing.T) {
+	ctx := context.Background()
+
+	factory, err := NewProtocol5Server(ctx, "test")
+	if err != nil {
+		t.Fatalf("failed to create protocol version 5 server: %v", err)
+	}
+	server := factory()
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get provider schema: %v", err)
+	}
+	for _, diag := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", diag.Summary, diag.Detail)
+	}
+
+	// Types without nested attributes are available
+	if _, ok := resp.ResourceSchemas["vaultwarden_organization"]; !ok {
+		t.Error("expected vaultwarden_organization to be available")
+	}
+
+	// Computed nested attributes are served as attributes of object types
+	devices, ok := resp.DataSourceSchemas["vaultwarden_devices"]
+	if !ok {
+		t.Fatal("expected vaultwarden_devices to be available")
+	}
+	if attribute := findProtocol5Attribute(devices.Block, "devices"); attribute == nil || !attribute.Type.Is(tftypes.List{}) {
+		t.Errorf("expected devices to be an attribute of a list type, got %v", attribute)
+	}
+
+	// Optional single nested attributes are served as single nested blocks
+	organizationUser, ok := resp.ResourceSchemas["vaultwarden_organization_user"]
+	if !ok {
+		t.Fatal("expected vaultwarden_organization_user to be available")
+	}
+	if block := findProtocol5Block(organizationUser.Block, "permissions"); block == nil || block.Nesting != tfprotov5.SchemaNestedBlockNestingModeSingle {
+		t.Errorf("expected permissions to be a single nested block, got %v", block)
+	}
+
+	// Configurable collections of nested attributes have no equivalent
+	for _, name := range []string{"vaultwarden_organization_members", "vaultwarden_organization_user_collections"} {
+		if _, ok := resp.ResourceSchemas[name]; ok {
+			t.Errorf("expected %s to be left out", name)
+		}
+	}
+
+	// The metadata must list the same types as the schema
+	metadata, err := server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+	if err != nil {
+		t.Fatalf("failed to get metadata: %v", err)
+	}
+	if len(metadata.Resources) != len(resp.ResourceSchemas) {
+		t.Errorf("expected %d resources in the metadata, got %d", len(resp.ResourceSchemas), len(metadata.Resources))
+	}
+	for _, resource := range metadata.Resources {
+		if _, ok := resp.ResourceSchemas[resource.TypeName]; !ok {
+			t.Errorf("resource %s of the metadata has no schema", resource.TypeName)
+		}
+	}
+	if len(metadata.DataSources) != len(resp.DataSourceSchemas) {
+		t.Errorf("expected %d data sources in the metadata, got %d", len(resp.DataSourceSchemas), len(metadata.DataSources))
+	}
+	for _, dataSource := range metadata.DataSources {
+		if _, ok := resp.DataSourceSchemas[dataSource.TypeName]; !ok {
+			t.Errorf("data source %s of the metadata has no schema", dataSource.TypeName)
+		}
+	}
+
+	// Configuring the provider warns about the types left out
+	providerType := resp.Provider.ValueType()
+	providerValues := make(map[string]tftypes.Value)
+	for name, attributeType := range providerType.(tftypes.Object).AttributeTypes {
+		providerValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	config, err := tfprotov5.NewDynamicValue(providerType, tftypes.NewValue(providerType, providerValues))
+	if err != nil {
+		t.Fatalf("failed to create provider configuration: %v", err)
+	}
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatalf("failed to configure the provider: %v", err)
+	}
+	var warned bool
+	for _, diag := range configureResp.Diagnostics {
+		if diag.Severity == tfprotov5.DiagnosticSeverityWarning && strings.Contains(diag.Detail, "vaultwarden_organization_members, vaultwarden_organization_user_collections") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Error("expected a warning naming the types left out")
+	}
+}
+
+func findProtocol5Attribute(block *tfprotov5.SchemaBlock, name string) *tfprotov5.SchemaAttribute {
+	for _, attribute := range block.Attributes {
+		if attribute.Name == name {
+			return attribute
+		}
+	}
+
+	return nil
+}
+
+func findProtocol5Block(block *tfprotov5.SchemaBlock, name string) *tfprotov5.SchemaNestedBlock {
+	for _, nestedBlock := range block.BlockTypes {
+		if nestedBlock.TypeName == name {
+			return nestedBlock
+		}
+	}
+
+	return nil
+}
+
+func TestAccProtocol5OrganizationUserPermissions(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+			"vaultwarden": func() (tfprotov5.ProviderServer, error) {
+				factory, err := NewProtocol5Server(context.Background(), "test")
+				if err != nil {
+					return nil, err
+				}
+				return factory(), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// The permissions are configured as a block and the devices are read as an attribute of a list type
+			{
+				Config: testAccProtocol5OrganizationUserConfigPermissions(orgName, email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "permissions.manage_users", "true"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "permissions.manage_policies", "false"),
+					resource.TestCheckResourceAttrSet("data.vaultwarden_devices.test", "devices.#"),
+				),
+			},
+		},
+	})
+}
+
+// Configuration of granular permissions with the protocol version 5 block syntax
+func testAccProtocol5OrganizationUserConfigPermissions(orgName, email string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id = vaultwarden_organization.test.id
+    email           = %[6]q
+    type            = "Custom"
+
+    permissions {
+        manage_users = true
+    }
+}
+
+data "vaultwarden_devices" "test" {}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email)
+}
@@ -2,22 +2,29 @@ package provider
 
 import (
 	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
 	"os"
+	"strings"
 )
 
 // Ensure VaultwardenProvider satisfies various provider interfaces.
 var _ provider.Provider = &VaultwardenProvider{}
 var _ provider.ProviderWithFunctions = &VaultwardenProvider{}
+var _ provider.ProviderWithListResources = &VaultwardenProvider{}
+var _ provider.ProviderWithActions = &VaultwardenProvider{}
 
 // VaultwardenProvider defines the provider implementation.
 type VaultwardenProvider struct {
@@ -25,6 +32,10 @@ type VaultwardenProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// clientOptions are passed to the Vaultwarden client in addition to the configured ones,
+	// e.g. to record or replay the interactions in tests
+	clientOptions []vaultwarden.ClientOption
 }
 
 // VaultwardenProviderModel describes the provider data model.
@@ -41,6 +52,15 @@ type VaultwardenProviderModel struct {
 	// OAuth2 Authentication
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
+
+	// Behavior
+	HealthCheck            types.Bool `tfsdk:"health_check"`
+	IgnoreDecryptionErrors types.Bool `tfsdk:"ignore_decryption_errors"`
+	CryptoSafeMode         types.Bool `tfsdk:"crypto_safe_mode"`
+
+	// Connection
+	UnixSocket        types.String `tfsdk:"unix_socket"`
+	FailoverEndpoints types.List   `tfsdk:"failover_endpoints"`
 }
 
 func (p *VaultwardenProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -54,9 +74,27 @@ func (p *VaultwardenProvider) Schema(_ context.Context, _ provider.SchemaRequest
 			"More information about authentication methods can be found in the [provider repository](https://github.com/ottramst/terraform-provider-vaultwarden#authentication)",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "The endpoint of the Vaultwarden server",
+				MarkdownDescription: "The endpoint of the Vaultwarden server, including the sub-path if the server is served under one (e.g. `https://example.com/vaultwarden`)",
 				Required:            true,
 			},
+			"unix_socket": schema.StringAttribute{
+				MarkdownDescription: "Path of a Unix domain socket to connect to the Vaultwarden server through, instead of over TCP. " +
+					"The `endpoint` is still used to build request URLs, e.g. `http://localhost`. " +
+					"Can also be set with the `VAULTWARDEN_UNIX_SOCKET` environment variable.",
+				Optional: true,
+			},
+			"failover_endpoints": schema.ListAttribute{
+				MarkdownDescription: "Endpoints of replicas of the Vaultwarden server to fail over to, in order of preference, e.g. for highly available deployments behind regional URLs. " +
+					"When the active endpoint can't be reached or a reverse proxy reports it as unavailable (502, 503 or 504), requests are sent to the first endpoint, including the `endpoint`, that passes a health check of its `/alive` endpoint. " +
+					"Requests that change data are only sent again when the active endpoint couldn't be reached at all, as the server might have applied them otherwise. " +
+					"The replicas must share the database and the signing keys of the server, so that sessions stay valid. " +
+					"Can also be set with the `VAULTWARDEN_FAILOVER_ENDPOINTS` environment variable as a comma separated list.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("unix_socket")),
+				},
+			},
 			"admin_token": schema.StringAttribute{
 				MarkdownDescription: "Token for admin page operations. This requires the `/admin` endpoint to be enabled.",
 				Sensitive:           true,
@@ -108,6 +146,21 @@ func (p *VaultwardenProvider) Schema(_ context.Context, _ provider.SchemaRequest
 					}...),
 				},
 			},
+			"health_check": schema.BoolAttribute{
+				MarkdownDescription: "Whether to verify that the Vaultwarden server is reachable (via its `/alive` endpoint) when configuring the provider. " +
+					"This reports DNS, TLS and wrong endpoint path issues up front instead of failing later during resource operations. Defaults to `false`",
+				Optional: true,
+			},
+			"ignore_decryption_errors": schema.BoolAttribute{
+				MarkdownDescription: "Whether encrypted attributes (such as collection names) that cannot be decrypted, for example because the organization key is unavailable, should produce a warning instead of an error. " +
+					"When enabled, the previously known value of such attributes is kept in the state. Defaults to `false`",
+				Optional: true,
+			},
+			"crypto_safe_mode": schema.BoolAttribute{
+				MarkdownDescription: "Whether every encrypted value should be decrypted again to verify it before it is sent to the server. " +
+					"Disabling it halves the cryptographic work when creating many encrypted values, such as on large imports. Defaults to `true`",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -177,6 +230,24 @@ func (p *VaultwardenProvider) Configure(ctx context.Context, req provider.Config
 		)
 	}
 
+	if data.UnixSocket.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("unix_socket"),
+			"Unknown Vaultwarden Unix socket",
+			"The provider cannot create the Vaultwarden API client as there is an unknown configuration value for the Vaultwarden Unix socket. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the VAULTWARDEN_UNIX_SOCKET environment variable.",
+		)
+	}
+
+	if data.FailoverEndpoints.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("failover_endpoints"),
+			"Unknown Vaultwarden failover endpoints",
+			"The provider cannot create the Vaultwarden API client as there is an unknown configuration value for the Vaultwarden failover endpoints. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the VAULTWARDEN_FAILOVER_ENDPOINTS environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -189,6 +260,16 @@ func (p *VaultwardenProvider) Configure(ctx context.Context, req provider.Config
 	masterPassword := os.Getenv("VAULTWARDEN_MASTER_PASSWORD")
 	clientID := os.Getenv("VAULTWARDEN_CLIENT_ID")
 	clientSecret := os.Getenv("VAULTWARDEN_CLIENT_SECRET")
+	unixSocket := os.Getenv("VAULTWARDEN_UNIX_SOCKET")
+
+	var failoverEndpoints []string
+	if env := os.Getenv("VAULTWARDEN_FAILOVER_ENDPOINTS"); env != "" {
+		for _, failoverEndpoint := range strings.Split(env, ",") {
+			if failoverEndpoint = strings.TrimSpace(failoverEndpoint); failoverEndpoint != "" {
+				failoverEndpoints = append(failoverEndpoints, failoverEndpoint)
+			}
+		}
+	}
 
 	if !data.Endpoint.IsNull() {
 		endpoint = data.Endpoint.ValueString()
@@ -208,6 +289,13 @@ func (p *VaultwardenProvider) Configure(ctx context.Context, req provider.Config
 	if !data.ClientSecret.IsNull() {
 		clientSecret = data.ClientSecret.ValueString()
 	}
+	if !data.UnixSocket.IsNull() {
+		unixSocket = data.UnixSocket.ValueString()
+	}
+	if !data.FailoverEndpoints.IsNull() {
+		failoverEndpoints = nil
+		resp.Diagnostics.Append(data.FailoverEndpoints.ElementsAs(ctx, &failoverEndpoints, false)...)
+	}
 
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
@@ -264,11 +352,35 @@ func (p *VaultwardenProvider) Configure(ctx context.Context, req provider.Config
 		opts = append(opts, vaultwarden.WithAdminToken(adminToken))
 	}
 
+	// Connect through a Unix domain socket if requested
+	if unixSocket != "" {
+		opts = append(opts, vaultwarden.WithUnixSocket(unixSocket))
+	}
+
+	// Fail over to the replicas of the server if configured
+	if len(failoverEndpoints) > 0 {
+		opts = append(opts, vaultwarden.WithFailoverEndpoints(failoverEndpoints...))
+	}
+
+	// Report undecryptable values as warnings if requested
+	if data.IgnoreDecryptionErrors.ValueBool() {
+		opts = append(opts, vaultwarden.WithIgnoreDecryptionErrors(true))
+	}
+
+	// Verify encrypted values by decrypting them again unless disabled
+	if !data.CryptoSafeMode.IsNull() {
+		opts = append(opts, vaultwarden.WithCryptoSafeMode(data.CryptoSafeMode.ValueBool()))
+	}
+
+	// Log the request and login metrics of the client
+	opts = append(opts, vaultwarden.WithMetrics(logMetrics{}))
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Create a new Vaultwarden API client using the configuration values and options
+	opts = append(opts, p.clientOptions...)
 	client, err := vaultwarden.New(endpoint, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -280,25 +392,92 @@ func (p *VaultwardenProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	// Make the Vaultwarden client available during DataSource and Resource
+	// Verify connectivity to the server if requested
+	if data.HealthCheck.ValueBool() {
+		if err := client.CheckHealth(ctx); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Unable to reach Vaultwarden server",
+				"The provider could not reach the Vaultwarden server at the configured endpoint.\n\n"+
+					"Health Check Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Detect the server version to gate version specific behavior, an unknown version is not fatal
+	if serverVersion, err := client.DetectServerVersion(ctx); err != nil {
+		tflog.Warn(ctx, "Unable to detect the Vaultwarden server version, assuming all features are supported", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else {
+		tflog.Info(ctx, "Detected Vaultwarden server version", map[string]interface{}{
+			"version": serverVersion.String(),
+		})
+	}
+
+	// Make the Vaultwarden client available during DataSource, Resource and Action
 	// type Configure methods.
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ActionData = client
 }
 
 func (p *VaultwardenProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		AccountKdfResource,
 		AccountRegisterResource,
+		AccountTOTPResource,
+		DeviceDeauthorizationResource,
+		ItemCollectionAssignmentResource,
 		OrganizationCollectionResource,
+		OrganizationGroupMembersResource,
+		OrganizationMembersResource,
+		OrganizationPolicyPersonalOwnershipResource,
+		OrganizationPolicyRequireSSOResource,
+		OrganizationPolicySendResource,
 		OrganizationResource,
+		OrganizationTrashPurgeResource,
 		OrganizationUserResource,
+		OrganizationUserCollectionsResource,
 		UserResource,
 	}
 }
 
 func (p *VaultwardenProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAdminConfigDataSource,
+		NewAdminOrganizationsDataSource,
+		NewAuthContextDataSource,
+		NewDevicesDataSource,
+		NewEmergencyAccessDataSource,
 		NewOrganizationDataSource,
+		NewOrganizationAPIKeyDataSource,
+		NewOrganizationCollectionItemsDataSource,
+		NewOrganizationCollectionLoginsDataSource,
+		NewOrganizationCollectionTreeDataSource,
+		NewOrganizationExportDataSource,
+		NewOrganizationItemAttachmentsDataSource,
+		NewOrganizationPublicKeyDataSource,
+		NewPreloginDataSource,
+		NewSendDataSource,
+	}
+}
+
+func (p *VaultwardenProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		OrganizationCollectionListResource,
+		OrganizationListResource,
+		OrganizationUserListResource,
+	}
+}
+
+func (p *VaultwardenProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		OrganizationAPIKeyRotateAction,
+		OrganizationUserConfirmAction,
+		OrganizationUserReinviteAction,
+		UserDeauthorizeAction,
 	}
 }
 
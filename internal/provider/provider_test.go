@@ -2,9 +2,21 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/test"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/recorder"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -16,10 +28,221 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"vaultwarden": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccRecordedProtoV6ProviderFactories returns provider factories whose client records the
+// interactions of the test to a cassette in testdata/cassettes or replays them from it, depending on
+// the VW_TEST_RECORDER environment variable. When replaying, tests without a cassette are skipped.
+func testAccRecordedProtoV6ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+
+	mode, err := recorder.ModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := testAccCassettePath(t)
+	if mode == recorder.ModeReplay && !testAccHasCassette(t) {
+		t.Skipf("no cassette recorded at %s", path)
+	}
+
+	rec, err := recorder.New(path, mode)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+
+	// Only keep the interactions of passing tests
+	t.Cleanup(func() {
+		if t.Failed() || t.Skipped() {
+			return
+		}
+		if err := rec.Save(); err != nil {
+			t.Errorf("failed to save cassette: %v", err)
+		}
+	})
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"vaultwarden": providerserver.NewProtocol6WithError(&VaultwardenProvider{
+			version:       "test",
+			clientOptions: []vaultwarden.ClientOption{vaultwarden.WithMiddleware(rec.Middleware)},
+		}),
+	}
+}
+
+// testAccCassettePath returns the path of the cassette recorded for the test
+func testAccCassettePath(t *testing.T) string {
+	return filepath.Join("testdata", "cassettes", t.Name()+".json")
+}
+
+// testAccHasCassette reports whether a cassette has been recorded for the test
+func testAccHasCassette(t *testing.T) bool {
+	_, err := os.Stat(testAccCassettePath(t))
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
 func testAccPreCheck(t *testing.T) {
 	ctx := context.Background()
 
+	// Replayed tests don't need a server, tests without a cassette cannot be replayed
+	if mode, _ := recorder.ModeFromEnv(); mode == recorder.ModeReplay {
+		if !testAccHasCassette(t) {
+			t.Skipf("no cassette recorded at %s", testAccCassettePath(t))
+		}
+		return
+	}
+
 	if err := test.LoginTestClient(ctx, t); err != nil {
 		t.Fatalf("PreCheck failed: %v", err)
 	}
 }
+
+// testAccCheckInvokeAction returns a check which invokes an action with a provider configured for the test server.
+// The Terraform releases the acceptance tests run with don't support actions, so the action is invoked through the
+// protocol. Each attribute of the action is read from the state, given as "<resource address>.<attribute>".
+// The invocation has to fail with an error matching expectError, if set.
+func testAccCheckInvokeAction(actionType string, attributes map[string]string, expectError *regexp.Regexp) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		config := map[string]string{}
+		for name, address := range attributes {
+			separator := strings.LastIndex(address, ".")
+			rs, ok := s.RootModule().Resources[address[:separator]]
+			if !ok {
+				return fmt.Errorf("resource %s not found in state", address[:separator])
+			}
+			config[name] = rs.Primary.Attributes[address[separator+1:]]
+		}
+
+		server, err := testAccProtoV6ProviderFactories["vaultwarden"]()
+		if err != nil {
+			return fmt.Errorf("failed to create provider server: %w", err)
+		}
+
+		schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get provider schema: %w", err)
+		}
+		actionSchema, ok := schemaResp.ActionSchemas[actionType]
+		if !ok {
+			return fmt.Errorf("action %s not found in provider schema", actionType)
+		}
+
+		providerConfig, err := testAccDynamicValue(schemaResp.Provider, map[string]string{
+			"endpoint":        test.TestBaseURL,
+			"email":           test.TestEmail,
+			"master_password": test.TestPassword,
+			"admin_token":     test.TestAdminToken,
+		})
+		if err != nil {
+			return err
+		}
+		configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: providerConfig})
+		if err != nil {
+			return fmt.Errorf("failed to configure provider: %w", err)
+		}
+		if err := testAccDiagnosticsError(configureResp.Diagnostics); err != nil {
+			return err
+		}
+
+		actionConfig, err := testAccDynamicValue(actionSchema.Schema, config)
+		if err != nil {
+			return err
+		}
+		// The protocol version 6 server implements the action RPCs, which are not part of its interface yet
+		actionServer, ok := server.(tfprotov6.ActionServer)
+		if !ok {
+			return fmt.Errorf("provider server doesn't support actions")
+		}
+		invokeResp, err := actionServer.InvokeAction(ctx, &tfprotov6.InvokeActionRequest{
+			ActionType: actionType,
+			Config:     actionConfig,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to invoke action %s: %w", actionType, err)
+		}
+
+		var diags []*tfprotov6.Diagnostic
+		for event := range invokeResp.Events {
+			if completed, ok := event.Type.(tfprotov6.CompletedInvokeActionEventType); ok {
+				diags = append(diags, completed.Diagnostics...)
+			}
+		}
+
+		err = testAccDiagnosticsError(diags)
+		switch {
+		case expectError == nil:
+			return err
+		case err == nil:
+			return fmt.Errorf("expected action %s to fail with an error matching %s", actionType, expectError)
+		case !expectError.MatchString(err.Error()):
+			return fmt.Errorf("expected action %s to fail with an error matching %s, got: %w", actionType, expectError, err)
+		}
+		return nil
+	}
+}
+
+// testAccDynamicValue builds a configuration of the schema with the given string attributes, the others are null
+func testAccDynamicValue(schema *tfprotov6.Schema, attributes map[string]string) (*tfprotov6.DynamicValue, error) {
+	objectType := schema.ValueType().(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = tftypes.NewValue(attributeType, value)
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	value, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build configuration: %w", err)
+	}
+
+	return &value, nil
+}
+
+// testAccDiagnosticsError returns the error diagnostics as a single error, or nil if there are none
+func testAccDiagnosticsError(diags []*tfprotov6.Diagnostic) error {
+	var errs []error
+	for _, diag := range diags {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, fmt.Errorf("%s: %s", diag.Summary, diag.Detail))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func TestProviderSchema(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["vaultwarden"]()
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get provider schema: %v", err)
+	}
+
+	for _, diag := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", diag.Summary, diag.Detail)
+	}
+
+	// Every list resource must have a matching resource with an identity
+	for name := range resp.ListResourceSchemas {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("list resource %s has no matching resource", name)
+		}
+	}
+
+	identityResp, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("failed to get resource identity schemas: %v", err)
+	}
+
+	for name := range resp.ListResourceSchemas {
+		if _, ok := identityResp.IdentitySchemas[name]; !ok {
+			t.Errorf("list resource %s has no matching resource identity", name)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccountKdf{}
+var _ resource.ResourceWithConfigure = &AccountKdf{}
+var _ resource.ResourceWithValidateConfig = &AccountKdf{}
+
+func AccountKdfResource() resource.Resource {
+	return &AccountKdf{}
+}
+
+// AccountKdf defines the resource implementation.
+type AccountKdf struct {
+	client *vaultwarden.Client
+}
+
+// AccountKdfModel describes the resource data model.
+type AccountKdfModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Email          types.String   `tfsdk:"email"`
+	Password       types.String   `tfsdk:"password"`
+	KdfType        types.String   `tfsdk:"kdf_type"`
+	KdfIterations  types.Int64    `tfsdk:"kdf_iterations"`
+	KdfMemory      types.Int64    `tfsdk:"kdf_memory"`
+	KdfParallelism types.Int64    `tfsdk:"kdf_parallelism"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *AccountKdf) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_kdf"
+}
+
+func (r *AccountKdf) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages the key derivation function (KDF) of an account whose master password is known, e.g. to migrate accounts registered with `vaultwarden_account_register` from PBKDF2 to Argon2id.\n\n" +
+			"Changing the KDF derives the master key again, so the master password hash changes and the user key is encrypted again, the vault data stays the same. Vaultwarden logs out all sessions of the account. Destroying the resource keeps the KDF of the account.\n\n" +
+			"This resource will save the password in plain text to the state! Use caution!",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the account",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email of the account. Changing this forces a new resource to be created",
+				Required:            true,
+				Validators: []validator.String{
+					validEmail(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The master password of the account",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"kdf_type": schema.StringAttribute{
+				MarkdownDescription: "The key derivation function of the account (PBKDF2_SHA256, Argon2id)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("PBKDF2_SHA256", "Argon2id"),
+				},
+			},
+			"kdf_iterations": schema.Int64Attribute{
+				MarkdownDescription: "The number of iterations of the key derivation function. Vaultwarden requires at least 100000 for PBKDF2_SHA256 and at least 1 for Argon2id",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"kdf_memory": schema.Int64Attribute{
+				MarkdownDescription: "The memory of the key derivation function in MiB, required for Argon2id",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(15, 1024),
+				},
+			},
+			"kdf_parallelism": schema.Int64Attribute{
+				MarkdownDescription: "The parallelism of the key derivation function, required for Argon2id",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 16),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *AccountKdf) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// kdfMinIterations are the minimum iterations of the key derivation functions accepted by Vaultwarden, which match the
+// bounds of the key derivation for Argon2id
+var kdfMinIterations = map[string]int64{
+	"PBKDF2_SHA256": 100000,
+	"Argon2id":      1,
+}
+
+func (r *AccountKdf) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AccountKdfModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.KdfType.IsUnknown() || data.KdfType.IsNull() {
+		return
+	}
+
+	// Check the iterations before the account logs in, Vaultwarden would only reject them when changing the KDF
+	if minIterations := kdfMinIterations[data.KdfType.ValueString()]; !data.KdfIterations.IsUnknown() && !data.KdfIterations.IsNull() && data.KdfIterations.ValueInt64() < minIterations {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kdf_iterations"),
+			"Invalid KDF configuration",
+			fmt.Sprintf("The %s key derivation function requires at least %d iterations, got %d.", data.KdfType.ValueString(), minIterations, data.KdfIterations.ValueInt64()),
+		)
+	}
+
+	// The memory and parallelism only apply to Argon2id, which requires them
+	argon2 := data.KdfType.ValueString() == "Argon2id"
+	argon2Attributes := []struct {
+		name  string
+		value types.Int64
+	}{
+		{"kdf_memory", data.KdfMemory},
+		{"kdf_parallelism", data.KdfParallelism},
+	}
+	for _, attribute := range argon2Attributes {
+		switch {
+		case argon2 && attribute.value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Missing KDF configuration",
+				fmt.Sprintf("The %s attribute is required for the Argon2id key derivation function.", attribute.name),
+			)
+		case !argon2 && !attribute.value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Invalid KDF configuration",
+				fmt.Sprintf("The %s attribute can only be set for the Argon2id key derivation function.", attribute.name),
+			)
+		}
+	}
+}
+
+func (r *AccountKdf) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AccountKdfModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	account, err := r.client.ForUser(data.Email.ValueString(), data.Password.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Account Client",
+			"Could not create a client for account "+data.Email.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	profile, err := account.GetProfile(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Logging In",
+			"Could not log in as account "+data.Email.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	r.changeKdf(ctx, account, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(profile.ID)
+
+	tflog.Trace(ctx, fmt.Sprintf("configured KDF of account with ID: %s", data.ID))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountKdf) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AccountKdfModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// The prelogin doesn't require a login, which would be logged out by every change
+	preloginResp, err := r.client.PreLoginForEmail(ctx, data.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading KDF Parameters",
+			fmt.Sprintf("Could not read the KDF parameters of %s: %s", data.Email.ValueString(), clientErrorDetail(err)),
+		)
+		return
+	}
+
+	data.KdfType = types.StringValue(preloginResp.Kdf.String())
+	data.KdfIterations = types.Int64Value(int64(preloginResp.KdfIterations))
+	data.KdfMemory = types.Int64Null()
+	data.KdfParallelism = types.Int64Null()
+	if preloginResp.Kdf == models.KdfTypeArgon2 {
+		data.KdfMemory = types.Int64Value(int64(preloginResp.KdfMemory))
+		data.KdfParallelism = types.Int64Value(int64(preloginResp.KdfParallelism))
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("read KDF of account with ID: %s", data.ID))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountKdf) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AccountKdfModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	account, err := r.client.ForUser(data.Email.ValueString(), data.Password.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Account Client",
+			"Could not create a client for account "+data.Email.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	r.changeKdf(ctx, account, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("updated KDF of account with ID: %s", data.ID))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountKdf) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AccountKdfModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is no previous configuration to restore, the account keeps its KDF
+	tflog.Trace(ctx, fmt.Sprintf("removed KDF of account with ID %s from state", data.ID))
+}
+
+// changeKdf changes the KDF of the account to the planned configuration, unless it already uses it
+func (r *AccountKdf) changeKdf(ctx context.Context, account *vaultwarden.Client, data AccountKdfModel, diags *diag.Diagnostics) {
+	var kdfType models.KdfType
+	if err := kdfType.FromString(data.KdfType.ValueString()); err != nil {
+		diags.AddError(
+			"Error Parsing KDF Type",
+			"Could not parse KDF type: "+err.Error(),
+		)
+		return
+	}
+
+	kdfConfig := models.KdfConfiguration{
+		KdfType:        kdfType,
+		KdfIterations:  int(data.KdfIterations.ValueInt64()),
+		KdfMemory:      int(data.KdfMemory.ValueInt64()),
+		KdfParallelism: int(data.KdfParallelism.ValueInt64()),
+	}
+
+	if kdfType == models.KdfTypeArgon2 {
+		if err := account.RequireFeature(ctx, vaultwarden.FeatureArgon2id); err != nil {
+			diags.AddAttributeError(path.Root("kdf_type"), "Unsupported KDF Type", "The KDF type can't be set: "+err.Error())
+			return
+		}
+	}
+
+	preloginResp, err := account.PreLogin(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Reading KDF Parameters",
+			fmt.Sprintf("Could not read the KDF parameters of %s: %s", data.Email.ValueString(), clientErrorDetail(err)),
+		)
+		return
+	}
+
+	current := models.KdfConfiguration{
+		KdfType:       preloginResp.Kdf,
+		KdfIterations: preloginResp.KdfIterations,
+	}
+	if preloginResp.Kdf == models.KdfTypeArgon2 {
+		current.KdfMemory = preloginResp.KdfMemory
+		current.KdfParallelism = preloginResp.KdfParallelism
+	}
+	if current == kdfConfig {
+		return
+	}
+
+	if err := account.ChangeKdf(ctx, kdfConfig); err != nil {
+		diags.AddError(
+			"Error Changing KDF",
+			"Could not change the KDF of account "+data.Email.ValueString()+": "+clientErrorDetail(err),
+		)
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
+	"testing"
+)
+
+func TestAccAccountKdf(t *testing.T) {
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, false, 12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Argon2id requires the memory and parallelism
+			{
+				Config: testAccAccountKdfConfig(email, password, `
+    kdf_type       = "Argon2id"
+    kdf_iterations = 3`),
+				ExpectError: regexp.MustCompile(`Missing KDF configuration`),
+			},
+			// Vaultwarden rejects fewer PBKDF2 iterations
+			{
+				Config: testAccAccountKdfConfig(email, password, `
+    kdf_type       = "PBKDF2_SHA256"
+    kdf_iterations = 5000`),
+				ExpectError: regexp.MustCompile(`requires at least 100000 iterations`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccAccountKdfConfig(email, password, `
+    kdf_type        = "Argon2id"
+    kdf_iterations  = 3
+    kdf_memory      = 64
+    kdf_parallelism = 4`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vaultwarden_account_kdf.test", "id", "vaultwarden_account_register.test", "id"),
+					resource.TestCheckResourceAttr("vaultwarden_account_kdf.test", "kdf_type", "Argon2id"),
+					resource.TestCheckResourceAttr("vaultwarden_account_kdf.test", "kdf_iterations", "3"),
+					resource.TestCheckResourceAttr("vaultwarden_account_kdf.test", "kdf_memory", "64"),
+					resource.TestCheckResourceAttr("vaultwarden_account_kdf.test", "kdf_parallelism", "4"),
+				),
+			},
+			// Update and Read testing, the account can still log in with the changed master key
+			{
+				Config: testAccAccountKdfConfig(email, password, `
+    kdf_type       = "PBKDF2_SHA256"
+    kdf_iterations = 600000`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_account_kdf.test", "kdf_type", "PBKDF2_SHA256"),
+					resource.TestCheckResourceAttr("vaultwarden_account_kdf.test", "kdf_iterations", "600000"),
+					resource.TestCheckNoResourceAttr("vaultwarden_account_kdf.test", "kdf_memory"),
+					resource.TestCheckNoResourceAttr("vaultwarden_account_kdf.test", "kdf_parallelism"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAccountKdfConfig(email, password, kdf string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_account_register" "test" {
+    name     = "KDF Test Account"
+    email    = %[5]q
+    password = %[6]q
+}
+
+resource "vaultwarden_account_kdf" "test" {
+    email          = vaultwarden_account_register.test.email
+    password       = vaultwarden_account_register.test.password
+%[7]s
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, email, password, kdf)
+}
@@ -3,16 +3,23 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/crypt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/keybuilder"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/keybuilder"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -30,10 +37,20 @@ type AccountRegister struct {
 
 // AccountRegisterModel describes the resource data model.
 type AccountRegisterModel struct {
-	ID       types.String `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Email    types.String `tfsdk:"email"`
-	Password types.String `tfsdk:"password"`
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Email             types.String   `tfsdk:"email"`
+	Password          types.String   `tfsdk:"password"`
+	KeySize           types.Int64    `tfsdk:"key_size"`
+	AcceptInvitations types.Bool     `tfsdk:"accept_invitations"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// accountRegisterFieldPaths maps the request fields of an account registration to their attributes to report validation errors
+var accountRegisterFieldPaths = map[string]path.Path{
+	"name":               path.Root("name"),
+	"email":              path.Root("email"),
+	"masterpasswordhash": path.Root("password"),
 }
 
 func (r *AccountRegister) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -42,7 +59,7 @@ func (r *AccountRegister) Metadata(ctx context.Context, req resource.MetadataReq
 
 func (r *AccountRegister) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "This resource registers a new account on the Vaultwarden server.\n\nThis resource will save the password in plain text to the state! Use caution!\n\nRequires `admin_token` to be set in the provider configuration.",
+		MarkdownDescription: "This resource registers a new account with the given master password on the Vaultwarden server.\n\nUse `vaultwarden_user` instead to invite a user who chooses the master password on their own.\n\nThis resource will save the password in plain text to the state! Use caution!\n\nRequires `admin_token` to be set in the provider configuration.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -56,14 +73,47 @@ func (r *AccountRegister) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:            true,
 			},
 			"email": schema.StringAttribute{
-				MarkdownDescription: "The email of the account to register",
+				MarkdownDescription: "The email of the account to register. Changing this forces a new resource to be created",
 				Required:            true,
+				Validators: []validator.String{
+					validEmail(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"password": schema.StringAttribute{
 				MarkdownDescription: "The password of the account to register",
 				Required:            true,
 				Sensitive:           true,
 			},
+			"key_size": schema.Int64Attribute{
+				MarkdownDescription: "The size in bits of the RSA key pair generated for the account, either `2048` or `4096`. Changing this forces a new resource to be created. Defaults to `2048`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(keybuilder.RSAKeySize2048, keybuilder.RSAKeySize4096),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"accept_invitations": schema.BoolAttribute{
+				MarkdownDescription: "Whether to complete the pending invitations of the account to the organizations managed by the provider user when it is registered, " +
+					"so the account can access them right away regardless of whether the account or the `vaultwarden_organization_user` resources are created first. " +
+					"Vaultwarden accepts the invitations on registration if mail delivery is disabled, the accepted invitations are then confirmed with the keys of the new account. " +
+					"If mail delivery is enabled, the invitations can only be accepted with the link of the invitation email and are reported as warnings. " +
+					"Only applies when the account is registered. Defaults to `false`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -98,12 +148,22 @@ func (r *AccountRegister) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Do prelogin to get KDF parameters
 	preloginResp, err := r.client.PreLogin(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error prelogin",
-			"Could not prelogin, unexpected error: "+err.Error(),
+			"Could not prelogin, unexpected error: "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -149,7 +209,12 @@ func (r *AccountRegister) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Generate public/private key pair
-	publicKey, encryptedPrivateKey, err := keybuilder.GenerateEncryptedRSAKeyPair(*encryptionKey)
+	keySize := keybuilder.RSAKeySize2048
+	if !data.KeySize.IsNull() {
+		keySize = int(data.KeySize.ValueInt64())
+	}
+
+	publicKey, encryptedPrivateKey, err := keybuilder.GenerateEncryptedRSAKeyPairWithSize(*encryptionKey, keySize)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error generating RSA key pair",
@@ -175,10 +240,7 @@ func (r *AccountRegister) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	if err := r.client.RegisterUser(ctx, registerReq); err != nil {
-		resp.Diagnostics.AddError(
-			"Error registering user",
-			"Could not register user, unexpected error: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Error registering user", "Could not register user, unexpected error: ", err, accountRegisterFieldPaths)
 		return
 	}
 
@@ -187,7 +249,7 @@ func (r *AccountRegister) Create(ctx context.Context, req resource.CreateRequest
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error fetching registered user",
-			"Could not fetch registered user, unexpected error: "+err.Error(),
+			"Could not fetch registered user, unexpected error: "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -195,14 +257,71 @@ func (r *AccountRegister) Create(ctx context.Context, req resource.CreateRequest
 	// Map response body to schema and populate Computed attribute values
 	data.ID = types.StringValue(userResp.ID)
 
+	// The account is registered in any case, so failures to complete the invitations don't replace it
+	if data.AcceptInvitations.ValueBool() {
+		r.acceptInvitations(ctx, data.Email.ValueString(), &resp.Diagnostics)
+	}
+
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, fmt.Sprintf("created a new user_invite with ID: %s", data.ID))
+	tflog.Trace(ctx, fmt.Sprintf("registered a new account with ID: %s", data.ID))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// acceptInvitations confirms the memberships of the registered account in the organizations of the provider user,
+// which Vaultwarden has accepted on registration. Invitations which can't be completed are reported as warnings.
+func (r *AccountRegister) acceptInvitations(ctx context.Context, email string, diags *diag.Diagnostics) {
+	profile, err := r.client.GetProfile(ctx)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("accept_invitations"),
+			"Could not accept invitations",
+			"Could not read the organizations of the provider user, which requires the email and master_password provider options: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	for _, org := range profile.Organizations {
+		member, err := r.client.GetOrganizationUserByEmail(ctx, email, org.ID)
+		if err == nil {
+			// The listing of the organization users might have been cached before the registration
+			member, err = r.client.GetOrganizationUser(ctx, member.ID, org.ID)
+		}
+		if err != nil {
+			if !isNotFoundError(err) {
+				diags.AddAttributeWarning(
+					path.Root("accept_invitations"),
+					"Could not accept invitation",
+					"Could not look up the invitation to organization "+org.Name+": "+clientErrorDetail(err),
+				)
+			}
+			continue
+		}
+
+		switch member.Status {
+		case models.UserOrgStatusInvited:
+			diags.AddAttributeWarning(
+				path.Root("accept_invitations"),
+				"Invitation not accepted",
+				"The invitation to organization "+org.Name+" was not accepted on registration, as mail delivery is enabled on the Vaultwarden server. "+
+					"It has to be accepted with the link of the invitation email.",
+			)
+		case models.UserOrgStatusAccepted:
+			if err := r.client.ConfirmOrganizationUser(ctx, member.ID, org.ID); err != nil {
+				diags.AddAttributeWarning(
+					path.Root("accept_invitations"),
+					"Could not confirm invitation",
+					"Could not confirm the account in organization "+org.Name+": "+clientErrorDetail(err),
+				)
+				continue
+			}
+			tflog.Info(ctx, fmt.Sprintf("confirmed the registered account in organization with ID %s", org.ID))
+		}
+	}
+}
+
 func (r *AccountRegister) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data AccountRegisterModel
 
@@ -213,12 +332,22 @@ func (r *AccountRegister) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed data from the client
 	userResp, err := r.client.GetUser(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Vaultwarden user",
-			"Could not read user with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Could not read user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -255,11 +384,21 @@ func (r *AccountRegister) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the user
 	if err := r.client.DeleteUser(ctx, data.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Vaultwarden user",
-			"Could not delete user with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Could not delete user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
 	"regexp"
 	"testing"
 )
@@ -39,6 +39,37 @@ func TestAccAccountRegister(t *testing.T) {
 	})
 }
 
+func TestAccAccountRegisterAcceptInvitations(t *testing.T) {
+	// Generate random data for the test
+	orgName := gofakeit.Company()
+	name := gofakeit.Name()
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, false, 12) // min 12 chars
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Register the account after it has been invited to the organization
+			{
+				Config: testAccAccountRegisterConfigAcceptInvitations(orgName, name, email, password),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_account_register.test", "accept_invitations", "true"),
+					resource.TestCheckResourceAttrSet("vaultwarden_account_register.test", "id"),
+				),
+			},
+			// The refreshed invitation has been accepted and confirmed
+			{
+				Config: testAccAccountRegisterConfigAcceptInvitations(orgName, name, email, password),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "status", "Confirmed"),
+					resource.TestCheckNoResourceAttr("vaultwarden_organization_user.test", "registration_url"),
+				),
+			},
+		},
+	})
+}
+
 // Base configuration
 func testAccAccountRegisterConfig(name, email, password string) string {
 	return fmt.Sprintf(`
@@ -80,3 +111,33 @@ resource "vaultwarden_account_register" "test_duplicate" {
 }
 `, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name, email, password)
 }
+
+// Configuration that registers an account invited to an organization before
+func testAccAccountRegisterConfigAcceptInvitations(orgName, name, email, password string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id = vaultwarden_organization.test.id
+    email           = %[7]q
+}
+
+resource "vaultwarden_account_register" "test" {
+    name               = %[6]q
+    email              = %[7]q
+    password           = %[8]q
+    accept_invitations = true
+
+    depends_on = [vaultwarden_organization_user.test]
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, name, email, password)
+}
@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccountTOTP{}
+var _ resource.ResourceWithConfigure = &AccountTOTP{}
+
+func AccountTOTPResource() resource.Resource {
+	return &AccountTOTP{}
+}
+
+// AccountTOTP defines the resource implementation.
+type AccountTOTP struct {
+	client *vaultwarden.Client
+}
+
+// AccountTOTPModel describes the resource data model.
+type AccountTOTPModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Email        types.String   `tfsdk:"email"`
+	Password     types.String   `tfsdk:"password"`
+	Secret       types.String   `tfsdk:"secret"`
+	RecoveryCode types.String   `tfsdk:"recovery_code"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *AccountTOTP) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_totp"
+}
+
+func (r *AccountTOTP) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource enables two-step login with an authenticator app (TOTP) for an account whose master password is known, e.g. one registered with `vaultwarden_account_register`, so that service accounts meet two-step login policies.\n\n" +
+			"The provider logs in as the account with its master password, and with the TOTP secret once two-step login is enabled. Reading the resource requires the `admin_token` provider option. Destroying the resource disables two-step login with an authenticator app for the account.\n\n" +
+			"This resource will save the password, the TOTP secret and the recovery code in plain text to the state! Use caution!",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the account",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email of the account. Changing this forces a new resource to be created",
+				Required:            true,
+				Validators: []validator.String{
+					validEmail(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The master password of the account",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The base32 encoded TOTP secret, which can be added to an authenticator app",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"recovery_code": schema.StringAttribute{
+				MarkdownDescription: "The recovery code, which disables two-step login when used to log in",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *AccountTOTP) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AccountTOTP) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AccountTOTPModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	account, err := r.client.ForUser(data.Email.ValueString(), data.Password.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Account Client",
+			"Could not create a client for account "+data.Email.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	profile, err := account.GetProfile(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Logging In",
+			"Could not log in as account "+data.Email.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Vaultwarden generates the secret, which has to be confirmed with a code to enable the authenticator
+	authenticator, err := account.GetAuthenticator(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Enabling Two-Step Login",
+			"Could not get authenticator of account "+data.Email.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+	if authenticator.Enabled {
+		resp.Diagnostics.AddError(
+			"Two-Step Login Already Enabled",
+			"Two-step login with an authenticator app is already enabled for account "+data.Email.ValueString()+". Disable it first to manage it with Terraform.",
+		)
+		return
+	}
+
+	if _, err := account.EnableAuthenticator(ctx, authenticator.Key); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Enabling Two-Step Login",
+			"Could not enable authenticator of account "+data.Email.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Save the secret right away, so that the account can still be logged in to if the recovery code can't be read
+	data.ID = types.StringValue(profile.ID)
+	data.Secret = types.StringValue(authenticator.Key)
+	data.RecoveryCode = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recoveryCode, err := account.GetTwoFactorRecoveryCode(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Recovery Code",
+			"Could not read two-step login recovery code of account "+data.Email.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+	data.RecoveryCode = types.StringValue(recoveryCode)
+
+	tflog.Trace(ctx, fmt.Sprintf("enabled TOTP two-step login for account with ID: %s", data.ID))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountTOTP) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AccountTOTPModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Read through the admin API, as Vaultwarden accepts every TOTP code only once and refreshing
+	// right after an apply would otherwise run out of codes for the next login
+	userResp, err := r.client.GetUser(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Two-Step Login",
+			"Could not read user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Two-step login was disabled outside of Terraform, e.g. by logging in with the recovery code
+	if !userResp.TwoFactorEnabled {
+		tflog.Warn(ctx, "TOTP two-step login is disabled, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("read TOTP two-step login of account with ID: %s", data.ID))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountTOTP) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AccountTOTPModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the password can change, which is used for later logins
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountTOTP) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AccountTOTPModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	account, err := r.client.ForUser(data.Email.ValueString(), data.Password.ValueString(), vaultwarden.WithTOTPSecret(data.Secret.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Account Client",
+			"Could not create a client for account "+data.Email.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := account.DisableTwoFactor(ctx, models.TwoFactorTypeAuthenticator); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Disabling Two-Step Login",
+			"Could not disable authenticator of account "+data.Email.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccAccountTOTP(t *testing.T) {
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, false, 12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAccountTOTPConfig(email, password),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vaultwarden_account_totp.test", "id", "vaultwarden_account_register.test", "id"),
+					resource.TestCheckResourceAttr("vaultwarden_account_totp.test", "email", email),
+					resource.TestCheckResourceAttrSet("vaultwarden_account_totp.test", "secret"),
+					resource.TestCheckResourceAttrSet("vaultwarden_account_totp.test", "recovery_code"),
+				),
+			},
+			// Refreshing keeps the resource in the state while two-step login is enabled
+			{
+				Config:   testAccAccountTOTPConfig(email, password),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAccountTOTPConfig(email, password string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_account_register" "test" {
+    name     = "TOTP Test Account"
+    email    = %[5]q
+    password = %[6]q
+}
+
+resource "vaultwarden_account_totp" "test" {
+    email    = vaultwarden_account_register.test.email
+    password = vaultwarden_account_register.test.password
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, email, password)
+}
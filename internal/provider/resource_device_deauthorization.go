@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeviceDeauthorization{}
+var _ resource.ResourceWithConfigure = &DeviceDeauthorization{}
+
+func DeviceDeauthorizationResource() resource.Resource {
+	return &DeviceDeauthorization{}
+}
+
+// DeviceDeauthorization defines the resource implementation.
+type DeviceDeauthorization struct {
+	client *vaultwarden.Client
+}
+
+// DeviceDeauthorizationModel describes the resource data model.
+type DeviceDeauthorizationModel struct {
+	ID       types.String   `tfsdk:"id"`
+	DeviceID types.String   `tfsdk:"device_id"`
+	UserID   types.String   `tfsdk:"user_id"`
+	Triggers types.Map      `tfsdk:"triggers"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *DeviceDeauthorization) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_deauthorization"
+}
+
+func (r *DeviceDeauthorization) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource deauthorizes devices on the Vaultwarden server, so they have to log in again.\n\n" +
+			"Either a single device of the authenticated user is deactivated, or all devices of any user are deauthorized through the admin API. " +
+			"The devices are deauthorized when the resource is created, or replaced due to a change of `triggers`. Destroying the resource has no effect on the server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, which is the ID of the device or user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the device of the authenticated user to deactivate, as listed by the `vaultwarden_devices` data source",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("device_id"), path.MatchRoot("user_id")),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user to deauthorize all devices of. Requires the `admin_token` provider option",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values which deauthorize the devices again when changed",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *DeviceDeauthorization) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeviceDeauthorization) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DeviceDeauthorizationModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if !data.DeviceID.IsNull() {
+		deviceID := data.DeviceID.ValueString()
+		if err := r.client.DeactivateDevice(ctx, deviceID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deactivating device",
+				"Could not deactivate device with ID "+deviceID+": "+clientErrorDetail(err),
+			)
+			return
+		}
+
+		data.ID = data.DeviceID
+		tflog.Trace(ctx, fmt.Sprintf("deactivated device with ID: %s", deviceID))
+	} else {
+		userID := data.UserID.ValueString()
+		if err := r.client.DeauthorizeUser(ctx, userID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deauthorizing user",
+				"Could not deauthorize the devices of user with ID "+userID+": "+clientErrorDetail(err),
+			)
+			return
+		}
+
+		data.ID = data.UserID
+		tflog.Trace(ctx, fmt.Sprintf("deauthorized the devices of user with ID: %s", userID))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceDeauthorization) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The deauthorization happened once, there is nothing to refresh
+}
+
+func (r *DeviceDeauthorization) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DeviceDeauthorizationModel
+
+	// Only the timeouts can change without replacing the resource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceDeauthorization) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deauthorized devices cannot be authorized again, the resource is only removed from the state
+}
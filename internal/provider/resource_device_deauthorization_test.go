@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
+	"testing"
+)
+
+func TestAccDeviceDeauthorizationUser(t *testing.T) {
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: testAccDeviceDeauthorizationUserConfig(email, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vaultwarden_device_deauthorization.test", "id", "vaultwarden_user.test", "id"),
+					resource.TestCheckResourceAttr("vaultwarden_device_deauthorization.test", "triggers.rotation", "1"),
+				),
+			},
+			// Changing the triggers deauthorizes the devices again
+			{
+				Config: testAccDeviceDeauthorizationUserConfig(email, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_device_deauthorization.test", "triggers.rotation", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeviceDeauthorizationMissingTarget(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint    = %[1]q
+    admin_token = %[2]q
+}
+
+resource "vaultwarden_device_deauthorization" "test" {}
+`, test.TestBaseURL, test.TestAdminToken),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testAccDeviceDeauthorizationUserConfig(email, rotation string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint    = %[1]q
+    admin_token = %[2]q
+}
+
+resource "vaultwarden_user" "test" {
+    email = %[3]q
+}
+
+resource "vaultwarden_device_deauthorization" "test" {
+    user_id = vaultwarden_user.test.id
+
+    triggers = {
+        rotation = %[4]q
+    }
+}
+`, test.TestBaseURL, test.TestAdminToken, email, rotation)
+}
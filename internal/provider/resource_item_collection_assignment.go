@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ItemCollectionAssignment{}
+var _ resource.ResourceWithConfigure = &ItemCollectionAssignment{}
+var _ resource.ResourceWithImportState = &ItemCollectionAssignment{}
+
+func ItemCollectionAssignmentResource() resource.Resource {
+	return &ItemCollectionAssignment{}
+}
+
+// ItemCollectionAssignment defines the resource implementation.
+type ItemCollectionAssignment struct {
+	client *vaultwarden.Client
+}
+
+// ItemCollectionAssignmentModel describes the resource data model.
+type ItemCollectionAssignmentModel struct {
+	ID             types.String   `tfsdk:"id"`
+	ItemID         types.String   `tfsdk:"item_id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	CollectionIDs  types.Set      `tfsdk:"collection_ids"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// itemCollectionAssignmentFieldPaths maps the request fields of item collections to their attributes to report validation errors
+var itemCollectionAssignmentFieldPaths = map[string]path.Path{
+	"collectionids": path.Root("collection_ids"),
+}
+
+func (r *ItemCollectionAssignment) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item_collection_assignment"
+}
+
+func (r *ItemCollectionAssignment) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages the collections an existing organization item belongs to on the Vaultwarden server.\n\n" +
+			"The item itself is not managed, which allows items created in the Vaultwarden clients to be shared through Terraform. " +
+			"The assignment is authoritative: the item is removed from any collection not listed. " +
+			"Destroying the resource removes the item from all collections, the item itself is kept.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, which is the ID of the item",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"item_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization item to assign to the collections",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization the item belongs to",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"collection_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the collections the item belongs to",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *ItemCollectionAssignment) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// assign replaces the collections of the item with the collections of the model
+func (r *ItemCollectionAssignment) assign(ctx context.Context, data *ItemCollectionAssignmentModel, diags *diag.Diagnostics) {
+	var collectionIDs []string
+	diags.Append(data.CollectionIDs.ElementsAs(ctx, &collectionIDs, false)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	itemID := data.ItemID.ValueString()
+
+	// Only items owned by an organization can be assigned to collections
+	cipher, err := r.client.GetOrganizationCipher(ctx, itemID)
+	if err != nil {
+		diags.AddError(
+			"Error reading Vaultwarden item",
+			"Could not read item with ID "+itemID+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	if cipher.OrganizationID == "" {
+		diags.AddAttributeError(
+			path.Root("item_id"),
+			"Item is not owned by an organization",
+			"Item with ID "+itemID+" belongs to a personal vault and cannot be assigned to collections. Move it to an organization first.",
+		)
+		return
+	}
+
+	if err := r.client.UpdateCipherCollections(ctx, itemID, collectionIDs); err != nil {
+		addClientError(diags, "Error assigning item to collections", "Could not assign item with ID "+itemID+" to collections: ", err, itemCollectionAssignmentFieldPaths)
+		return
+	}
+
+	data.ID = data.ItemID
+	data.OrganizationID = types.StringValue(cipher.OrganizationID)
+}
+
+func (r *ItemCollectionAssignment) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ItemCollectionAssignmentModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.assign(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("assigned item with ID %s to collections", data.ItemID.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ItemCollectionAssignment) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ItemCollectionAssignmentModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get refreshed data from the client
+	cipher, err := r.client.GetOrganizationCipher(ctx, data.ItemID.ValueString())
+	if err != nil {
+		// Remove the assignment from the state if the item no longer exists
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("item with ID %s no longer exists, removing it from state", data.ItemID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading Vaultwarden item",
+			"Could not read item with ID "+data.ItemID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Overwrite the model with the refreshed data
+	data.ID = data.ItemID
+	data.OrganizationID = types.StringValue(cipher.OrganizationID)
+	data.CollectionIDs, diags = types.SetValueFrom(ctx, types.StringType, cipher.CollectionIDs)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ItemCollectionAssignment) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ItemCollectionAssignmentModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.assign(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ItemCollectionAssignment) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ItemCollectionAssignmentModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Remove the item from all collections, the item itself is not managed by this resource
+	if err := r.client.UpdateCipherCollections(ctx, data.ItemID.ValueString(), nil); err != nil {
+		// The item has already been deleted
+		if isNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error removing item from collections",
+			"Could not remove item with ID "+data.ItemID.ValueString()+" from its collections: "+clientErrorDetail(err),
+		)
+		return
+	}
+}
+
+func (r *ItemCollectionAssignment) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("item_id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
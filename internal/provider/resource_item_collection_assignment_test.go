@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAccItemCollectionAssignment(t *testing.T) {
+	// The item is created before the test case, which would otherwise only be skipped by it
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client, err := test.GetTestClient(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to get test client: %s", err)
+	}
+
+	// Create the item outside of Terraform, as if it had been created in the Vaultwarden clients
+	org, err := client.CreateOrganization(ctx, models.Organization{Name: gofakeit.Company(), CollectionName: "Default"})
+	if err != nil {
+		t.Fatalf("failed to create organization: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := client.DeleteOrganization(context.Background(), org.ID); err != nil {
+			t.Logf("failed to delete organization %s: %s", org.ID, err)
+		}
+	})
+
+	collection1, err := client.CreateOrganizationCollection(ctx, org.ID, models.Collection{Name: gofakeit.ProductName()})
+	if err != nil {
+		t.Fatalf("failed to create collection: %s", err)
+	}
+	collection2, err := client.CreateOrganizationCollection(ctx, org.ID, models.Collection{Name: gofakeit.ProductName()})
+	if err != nil {
+		t.Fatalf("failed to create collection: %s", err)
+	}
+
+	item, err := client.CreateOrganizationCipher(ctx, org.ID, models.Cipher{
+		Type:       models.CipherTypeSecureNote,
+		Name:       gofakeit.AppName(),
+		SecureNote: &models.SecureNote{},
+	}, []string{collection1.ID})
+	if err != nil {
+		t.Fatalf("failed to create item: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccItemCollectionAssignmentConfig(item.ID, collection1.ID, collection2.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_item_collection_assignment.test", "id", item.ID),
+					resource.TestCheckResourceAttr("vaultwarden_item_collection_assignment.test", "organization_id", org.ID),
+					resource.TestCheckResourceAttr("vaultwarden_item_collection_assignment.test", "collection_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("vaultwarden_item_collection_assignment.test", "collection_ids.*", collection2.ID),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "vaultwarden_item_collection_assignment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing removing the item from a collection
+			{
+				Config: testAccItemCollectionAssignmentConfig(item.ID, collection2.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_item_collection_assignment.test", "collection_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("vaultwarden_item_collection_assignment.test", "collection_ids.*", collection2.ID),
+				),
+			},
+		},
+	})
+}
+
+func testAccItemCollectionAssignmentConfig(itemID string, collectionIDs ...string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_item_collection_assignment" "test" {
+    item_id        = %[5]q
+    collection_ids = ["%[6]s"]
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, itemID, strings.Join(collectionIDs, `", "`))
+}
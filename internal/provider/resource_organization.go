@@ -3,22 +3,33 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/keybuilder"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Organization{}
 var _ resource.ResourceWithConfigure = &Organization{}
 var _ resource.ResourceWithImportState = &Organization{}
+var _ resource.ResourceWithIdentity = &Organization{}
 
 func OrganizationResource() resource.Resource {
 	return &Organization{}
@@ -31,10 +42,27 @@ type Organization struct {
 
 // OrganizationModel describes the resource data model.
 type OrganizationModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	BillingEmail   types.String `tfsdk:"billing_email"`
-	CollectionName types.String `tfsdk:"collection_name"`
+	ID                  types.String   `tfsdk:"id"`
+	Name                types.String   `tfsdk:"name"`
+	BillingEmail        types.String   `tfsdk:"billing_email"`
+	SSOIdentifier       types.String   `tfsdk:"sso_identifier"`
+	CollectionName      types.String   `tfsdk:"collection_name"`
+	DefaultCollectionID types.String   `tfsdk:"default_collection_id"`
+	KeySize             types.Int64    `tfsdk:"key_size"`
+	DeletionProtection  types.Bool     `tfsdk:"deletion_protection"`
+	UseGroups           types.Bool     `tfsdk:"use_groups"`
+	UseEvents           types.Bool     `tfsdk:"use_events"`
+	UseAPI              types.Bool     `tfsdk:"use_api"`
+	UseDirectory        types.Bool     `tfsdk:"use_directory"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationFieldPaths maps the request fields of an organization to their attributes to report validation errors
+var organizationFieldPaths = map[string]path.Path{
+	"name":           path.Root("name"),
+	"billingemail":   path.Root("billing_email"),
+	"identifier":     path.Root("sso_identifier"),
+	"collectionname": path.Root("collection_name"),
 }
 
 func (r *Organization) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,16 +88,101 @@ func (r *Organization) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "The billing email of the organization. If not specified, defaults to the authenticated user's email.",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					validEmail(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"sso_identifier": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the organization that members enter to log in with SSO",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 50),
+				},
+			},
 			"collection_name": schema.StringAttribute{
-				MarkdownDescription: "The name of the collection to create for the organization. Defaults to `Default`",
+				MarkdownDescription: "The name of the default collection created with the organization, which is renamed when changed. Defaults to `Default Collection`",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("Default Collection"),
 			},
+			"default_collection_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the default collection created with the organization. Imported organizations track their only collection as default collection, it is unknown for imported organizations with several collections",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_size": schema.Int64Attribute{
+				MarkdownDescription: "The size in bits of the RSA key pair generated for the organization, either `2048` or `4096`. Changing this forces a new resource to be created. Defaults to `2048`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(keybuilder.RSAKeySize2048, keybuilder.RSAKeySize4096),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from deleting the organization, which also deletes all of its collections and items. " +
+					"It must be set to `false` and applied before the organization can be destroyed. Defaults to `false`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"use_groups": schema.BoolAttribute{
+				MarkdownDescription: "Whether groups are enabled for the organization. Vaultwarden enables the features for all organizations by its settings, setting it fails the apply if the server doesn't match, e.g. to ensure the prerequisites of dependent resources",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"use_events": schema.BoolAttribute{
+				MarkdownDescription: "Whether event logs are enabled for the organization. Vaultwarden enables the features for all organizations by its settings, setting it fails the apply if the server doesn't match, e.g. to ensure the prerequisites of dependent resources",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"use_api": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization API key can be used. Vaultwarden enables the features for all organizations by its settings, setting it fails the apply if the server doesn't match, e.g. to ensure the prerequisites of dependent resources",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"use_directory": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization can be synced with Directory Connector. Vaultwarden enables the features for all organizations by its settings, setting it fails the apply if the server doesn't match, e.g. to ensure the prerequisites of dependent resources",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *Organization) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "ID of the organization",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -104,6 +217,19 @@ func (r *Organization) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Keep the planned features to check them against the created organization
+	planned := data
+
 	// Call the client method to create the organization
 	org := models.Organization{
 		Name:           data.Name.ValueString(),
@@ -111,19 +237,47 @@ func (r *Organization) Create(ctx context.Context, req resource.CreateRequest, r
 		CollectionName: data.CollectionName.ValueString(),
 	}
 
-	orgResp, err := r.client.CreateOrganization(ctx, org)
+	keySize := keybuilder.RSAKeySize2048
+	if !data.KeySize.IsNull() {
+		keySize = int(data.KeySize.ValueInt64())
+	}
+
+	orgResp, err := r.client.CreateOrganizationWithKeySize(ctx, org, keySize)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating Vaultwarden organization",
-			"Could not create organization, unexpected error: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Error creating Vaultwarden organization", "Could not create organization, unexpected error: ", err, organizationFieldPaths)
 		return
 	}
 
+	// The identifier can't be set when creating an organization, only by updating it
+	if !data.SSOIdentifier.IsNull() {
+		org = models.Organization{
+			Name:         orgResp.Name,
+			BillingEmail: orgResp.BillingEmail,
+			Identifier:   data.SSOIdentifier.ValueString(),
+		}
+		if orgResp, err = r.client.UpdateOrganization(ctx, orgResp.ID, org); err != nil {
+			addClientError(&resp.Diagnostics, "Error creating Vaultwarden organization", "Could not set SSO identifier of organization, unexpected error: ", err, organizationFieldPaths)
+			return
+		}
+	}
+
 	// Map response body to schema and populate Computed attribute values
 	data.ID = types.StringValue(orgResp.ID)
 	data.Name = types.StringValue(orgResp.Name)
 	data.BillingEmail = types.StringValue(orgResp.BillingEmail)
+	data.SSOIdentifier = organizationSSOIdentifier(orgResp)
+	setOrganizationFeatures(&data, orgResp)
+
+	// Track the default collection, which is the only collection of the new organization
+	data.DefaultCollectionID = types.StringNull()
+	if collection, err := r.client.FindOrganizationCollectionByName(ctx, orgResp.ID, data.CollectionName.ValueString()); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to find default collection",
+			"The default collection of organization "+orgResp.ID+" could not be found, changes of collection_name will not be applied: "+clientErrorDetail(err),
+		)
+	} else {
+		data.DefaultCollectionID = types.StringValue(collection.ID)
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -131,6 +285,10 @@ func (r *Organization) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationIdentityModel{ID: data.ID})...)
+
+	// The organization is kept in the state, so that it is replaced once the server settings are fixed
+	checkOrganizationFeatures(planned, orgResp, &resp.Diagnostics)
 }
 
 func (r *Organization) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -143,12 +301,29 @@ func (r *Organization) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed data from the client
 	orgResp, err := r.client.GetOrganization(ctx, data.ID.ValueString())
 	if err != nil {
+		// Remove the organization from the state if it no longer exists
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("organization with ID %s no longer exists, removing it from state", data.ID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading Vaultwarden organization",
-			"Could not read organization with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Could not read organization with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -156,9 +331,21 @@ func (r *Organization) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Overwrite the model with the refreshed data
 	data.Name = types.StringValue(orgResp.Name)
 	data.BillingEmail = types.StringValue(orgResp.BillingEmail)
+	data.SSOIdentifier = organizationSSOIdentifier(orgResp)
+	setOrganizationFeatures(&data, orgResp)
+
+	// Refresh the name of the default collection to detect renames
+	if !data.DefaultCollectionID.IsNull() {
+		r.readDefaultCollection(ctx, &data, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationIdentityModel{ID: data.ID})...)
 }
 
 func (r *Organization) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -171,22 +358,56 @@ func (r *Organization) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Update the organization if needed
 	org := models.Organization{
 		Name:         data.Name.ValueString(),
 		BillingEmail: data.BillingEmail.ValueString(),
+		Identifier:   data.SSOIdentifier.ValueString(),
 	}
 
-	if _, err := r.client.UpdateOrganization(ctx, data.ID.ValueString(), org); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating Vaultwarden organization",
-			"Could not update organization, unexpected error: "+err.Error(),
-		)
+	orgResp, err := r.client.UpdateOrganization(ctx, data.ID.ValueString(), org)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error updating Vaultwarden organization", "Could not update organization, unexpected error: ", err, organizationFieldPaths)
+		return
+	}
+
+	checkOrganizationFeatures(data, orgResp, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setOrganizationFeatures(&data, orgResp)
+
+	// Rename the default collection if needed
+	var state OrganizationModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if !data.CollectionName.Equal(state.CollectionName) {
+		r.renameDefaultCollection(ctx, data, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationIdentityModel{ID: data.ID})...)
 }
 
 func (r *Organization) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -199,16 +420,183 @@ func (r *Organization) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Refuse to delete protected organizations
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Vaultwarden organization is protected from deletion",
+			"Could not delete organization with ID "+data.ID.ValueString()+" because deletion_protection is enabled. "+
+				"Set deletion_protection to false and apply the configuration before destroying the organization.",
+		)
+		return
+	}
+
 	// Delete the organization
 	if err := r.client.DeleteOrganization(ctx, data.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Vaultwarden organization",
-			"Could not delete organization with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Could not delete organization with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
 }
 
+// readDefaultCollection refreshes the name of the default collection, forgetting the collection if it has been deleted
+func (r *Organization) readDefaultCollection(ctx context.Context, data *OrganizationModel, diags *diag.Diagnostics) {
+	collection, err := r.client.GetOrganizationCollection(ctx, data.ID.ValueString(), data.DefaultCollectionID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("default collection with ID %s no longer exists, no longer tracking it", data.DefaultCollectionID.ValueString()))
+			data.DefaultCollectionID = types.StringNull()
+			return
+		}
+
+		diags.AddError(
+			"Error reading default collection",
+			"Could not read default collection of organization "+data.ID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	name, err := r.client.DecryptOrganizationString(ctx, data.ID.ValueString(), collection.Name)
+	if err != nil {
+		if !r.client.IgnoreDecryptionErrors() {
+			diags.AddError(
+				"Error decrypting collection name",
+				"Could not read default collection of organization "+data.ID.ValueString()+", failed to decrypt collection name: "+err.Error(),
+			)
+			return
+		}
+
+		// Keep the previously known name instead of failing the refresh
+		diags.AddWarning(
+			"Unable to decrypt collection name",
+			"The name of the default collection of organization "+data.ID.ValueString()+" could not be decrypted, keeping the previously known value: "+err.Error(),
+		)
+		return
+	}
+
+	data.CollectionName = types.StringValue(name)
+}
+
+// renameDefaultCollection renames the default collection to the configured collection_name
+func (r *Organization) renameDefaultCollection(ctx context.Context, data OrganizationModel, diags *diag.Diagnostics) {
+	if data.DefaultCollectionID.IsNull() {
+		diags.AddAttributeWarning(
+			path.Root("collection_name"),
+			"Default collection is not tracked",
+			"The default collection of organization "+data.ID.ValueString()+" is unknown or has been deleted, so it cannot be renamed.",
+		)
+		return
+	}
+
+	existing, err := r.client.GetOrganizationCollection(ctx, data.ID.ValueString(), data.DefaultCollectionID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading default collection",
+			"Could not read default collection of organization "+data.ID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	collection := models.Collection{
+		Name:       data.CollectionName.ValueString(),
+		ExternalID: existing.ExternalID,
+	}
+
+	if _, err := r.client.UpdateOrganizationCollection(ctx, data.ID.ValueString(), data.DefaultCollectionID.ValueString(), collection); err != nil {
+		diags.AddError(
+			"Error renaming default collection",
+			"Could not rename default collection of organization "+data.ID.ValueString()+": "+clientErrorDetail(err),
+		)
+	}
+}
+
 func (r *Organization) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+
+	// Imported organizations are not protected unless configured otherwise
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+
+	var orgID types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &orgID)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Track the only collection of the organization as its default collection, so that the subsequent read refreshes
+	// collection_name. With several collections, the default collection can't be told apart from the others.
+	collections, err := r.client.GetOrganizationCollections(ctx, orgID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading organization collections",
+			"Could not read the collections of organization "+orgID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	if len(collections.Data) != 1 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("collection_name"),
+			"Default collection is not tracked",
+			fmt.Sprintf("Organization %s has %d collections, so its default collection can't be determined and changes of collection_name will not be applied.", orgID.ValueString(), len(collections.Data)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("default_collection_id"), collections.Data[0].ID)...)
+}
+
+// organizationSSOIdentifier returns the SSO identifier of the organization, which is null if not set
+func organizationSSOIdentifier(org *models.Organization) types.String {
+	if org.Identifier == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(org.Identifier)
+}
+
+// setOrganizationFeatures sets the features of the organization in the model
+func setOrganizationFeatures(data *OrganizationModel, org *models.Organization) {
+	data.UseGroups = types.BoolValue(org.UseGroups)
+	data.UseEvents = types.BoolValue(org.UseEvents)
+	data.UseAPI = types.BoolValue(org.UseAPI)
+	data.UseDirectory = types.BoolValue(org.UseDirectory)
+}
+
+// checkOrganizationFeatures reports the configured features that don't match the organization. Vaultwarden
+// enables the features for all organizations by its settings, so they can't be changed per organization.
+func checkOrganizationFeatures(plan OrganizationModel, org *models.Organization, diags *diag.Diagnostics) {
+	features := []struct {
+		attribute string
+		planned   types.Bool
+		enabled   bool
+		hint      string
+	}{
+		{"use_groups", plan.UseGroups, org.UseGroups, "Groups are enabled with the ORG_GROUPS_ENABLED setting of the server."},
+		{"use_events", plan.UseEvents, org.UseEvents, "Event logs are enabled with the ORG_EVENTS_ENABLED setting of the server."},
+		{"use_api", plan.UseAPI, org.UseAPI, "The server determines it for all organizations."},
+		{"use_directory", plan.UseDirectory, org.UseDirectory, "The server determines it for all organizations."},
+	}
+
+	for _, feature := range features {
+		if feature.planned.IsNull() || feature.planned.IsUnknown() || feature.planned.ValueBool() == feature.enabled {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root(feature.attribute),
+			"Organization feature can't be changed",
+			fmt.Sprintf("The %s feature of organization %s is %t on the server, but %t is configured. %s", feature.attribute, org.ID, feature.enabled, feature.planned.ValueBool(), feature.hint),
+		)
+	}
 }
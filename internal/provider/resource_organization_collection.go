@@ -3,24 +3,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/crypt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/encryptedstring"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
-	"strings"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OrganizationCollection{}
 var _ resource.ResourceWithConfigure = &OrganizationCollection{}
 var _ resource.ResourceWithImportState = &OrganizationCollection{}
+var _ resource.ResourceWithIdentity = &OrganizationCollection{}
 
 func OrganizationCollectionResource() resource.Resource {
 	return &OrganizationCollection{}
@@ -37,8 +38,43 @@ type OrganizationCollectionModel struct {
 	OrganizationID types.String `tfsdk:"organization_id"`
 	ExternalID     types.String `tfsdk:"external_id"`
 	Name           types.String `tfsdk:"name"`
+	CreateParents  types.Bool   `tfsdk:"create_parents"`
 	// TODO: Add groups
 	// TODO: Add users
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationCollectionFieldPaths maps the request fields of a collection to their attributes to report validation errors
+var organizationCollectionFieldPaths = map[string]path.Path{
+	"name":       path.Root("name"),
+	"externalid": path.Root("external_id"),
+}
+
+// refreshExternalID sets the external ID returned by the server. Servers without external IDs of collections don't
+// return them, so the configured value is kept there to avoid spurious diffs.
+// See: https://github.com/dani-garcia/vaultwarden/pull/3690
+func (r *OrganizationCollection) refreshExternalID(ctx context.Context, data *OrganizationCollectionModel, externalID string) {
+	if externalID == "" && !data.ExternalID.IsNull() && !r.client.SupportsFeature(ctx, vaultwarden.FeatureCollectionExternalID) {
+		return
+	}
+
+	if externalID == "" {
+		data.ExternalID = types.StringNull()
+	} else {
+		data.ExternalID = types.StringValue(externalID)
+	}
+}
+
+// warnUnsupportedExternalID adds a warning if external_id is set for a server that doesn't return external IDs of
+// collections, so changes outside of Terraform can't be detected
+func (r *OrganizationCollection) warnUnsupportedExternalID(ctx context.Context, diags *diag.Diagnostics) {
+	if err := r.client.RequireFeature(ctx, vaultwarden.FeatureCollectionExternalID); err != nil {
+		diags.AddAttributeWarning(
+			path.Root("external_id"),
+			"Unsupported external ID",
+			"The external ID is kept as configured, as the server doesn't return it: "+err.Error(),
+		)
+	}
 }
 
 func (r *OrganizationCollection) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -72,16 +108,35 @@ func (r *OrganizationCollection) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the organization collection",
+				MarkdownDescription: "The name of the organization collection. Vaultwarden nests collections with `/` in their names, e.g. `Engineering/Backend` is shown inside `Engineering`",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"create_parents": schema.BoolAttribute{
+				MarkdownDescription: "Whether to create the missing parent collections of a nested name, e.g. `Engineering` for `Engineering/Backend`. " +
+					"The parent collections are not managed by this resource and are kept when it is destroyed. Defaults to `false`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *OrganizationCollection) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationResourceIdentitySchema("ID of the collection")
+}
+
 func (r *OrganizationCollection) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -102,6 +157,26 @@ func (r *OrganizationCollection) Configure(ctx context.Context, req resource.Con
 	r.client = client
 }
 
+// createParents creates the missing parent collections of the collection name if create_parents is enabled
+func (r *OrganizationCollection) createParents(ctx context.Context, data OrganizationCollectionModel, diags *diag.Diagnostics) {
+	if !data.CreateParents.ValueBool() {
+		return
+	}
+
+	created, err := r.client.CreateOrganizationCollectionParents(ctx, data.OrganizationID.ValueString(), data.Name.ValueString())
+	for _, name := range created {
+		tflog.Info(ctx, fmt.Sprintf("created parent collection %s in organization %s", name, data.OrganizationID.ValueString()))
+	}
+
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("create_parents"),
+			"Error creating parent collections",
+			"Could not create the parent collections of "+data.Name.ValueString()+": "+clientErrorDetail(err),
+		)
+	}
+}
+
 func (r *OrganizationCollection) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data OrganizationCollectionModel
 
@@ -112,6 +187,23 @@ func (r *OrganizationCollection) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Create the missing parent collections first so that the collection is nested right away
+	r.createParents(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Call the client method to create the organization
 	collection := models.Collection{
 		Name: data.Name.ValueString(),
@@ -120,30 +212,19 @@ func (r *OrganizationCollection) Create(ctx context.Context, req resource.Create
 	// Set external_id if it's not null in the plan
 	if !data.ExternalID.IsNull() {
 		collection.ExternalID = data.ExternalID.ValueString()
+		r.warnUnsupportedExternalID(ctx, &resp.Diagnostics)
 	}
 
 	collResp, err := r.client.CreateOrganizationCollection(ctx, data.OrganizationID.ValueString(), collection)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating Vaultwarden organization collection",
-			"Could not create organization collection, unexpected error: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Error creating Vaultwarden organization collection", "Could not create organization collection, unexpected error: ", err, organizationCollectionFieldPaths)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	data.ID = types.StringValue(collResp.ID)
 
-	// If we're trying to set an external_id, but the API returns empty or null,
-	// keep our desired value from the configuration
-	// See: https://github.com/dani-garcia/vaultwarden/pull/3690
-	if collResp.ExternalID == "" && !data.ExternalID.IsNull() {
-		// Keep the existing external_id from our state
-	} else if collResp.ExternalID == "" {
-		data.ExternalID = types.StringNull()
-	} else {
-		data.ExternalID = types.StringValue(collResp.ExternalID)
-	}
+	r.refreshExternalID(ctx, &data, collResp.ExternalID)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -151,6 +232,7 @@ func (r *OrganizationCollection) Create(ctx context.Context, req resource.Create
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
 }
 
 func (r *OrganizationCollection) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -163,62 +245,59 @@ func (r *OrganizationCollection) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	// Get refreshed data from the client
-	collResp, err := r.client.GetOrganizationCollection(ctx, data.OrganizationID.ValueString(), data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading Vaultwarden organization collection",
-			"Could not read organization collection, unexpected error: "+err.Error(),
-		)
-		return
-	}
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 
-	// Get organization data from cache
-	orgSecret, exists := r.client.AuthState.Organizations[data.OrganizationID.ValueString()]
-	if !exists {
-		resp.Diagnostics.AddError(
-			"Error reading Vaultwarden organization collection",
-			"Could not read organization collection, organization not found or not authenticated",
-		)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Convert the collection name to an EncryptedString
-	encryptedName, err := encryptedstring.NewFromEncryptedValue(collResp.Name)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get refreshed data from the client
+	collResp, err := r.client.GetOrganizationCollection(ctx, data.OrganizationID.ValueString(), data.ID.ValueString())
 	if err != nil {
+		// Remove the collection from the state if it no longer exists
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("organization collection with ID %s no longer exists, removing it from state", data.ID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading Vaultwarden organization collection",
-			"Could not read organization collection, failed to parse encrypted collection name: "+err.Error(),
+			"Could not read organization collection, unexpected error: "+clientErrorDetail(err),
 		)
 		return
 	}
 
 	// Decrypt the collection name
-	decryptedBytes, err := crypt.Decrypt(encryptedName, &orgSecret.Key)
+	name, err := r.client.DecryptOrganizationString(ctx, data.OrganizationID.ValueString(), collResp.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error decrypting collection name",
-			err.Error(),
+		if !r.client.IgnoreDecryptionErrors() {
+			resp.Diagnostics.AddError(
+				"Error decrypting collection name",
+				"Could not read organization collection, failed to decrypt collection name: "+err.Error(),
+			)
+			return
+		}
+
+		// Keep the previously known name instead of failing the refresh
+		resp.Diagnostics.AddWarning(
+			"Unable to decrypt collection name",
+			"The name of organization collection "+data.ID.ValueString()+" could not be decrypted, keeping the previously known value: "+err.Error(),
 		)
-		return
-	}
-
-	// Overwrite the model with the refreshed data
-	data.Name = types.StringValue(string(decryptedBytes))
-
-	// If we're trying to set an external_id, but the API returns empty or null,
-	// keep our desired value from the configuration
-	// See: https://github.com/dani-garcia/vaultwarden/pull/3690
-	if collResp.ExternalID == "" && !data.ExternalID.IsNull() {
-		// Keep the existing external_id from our state
-	} else if collResp.ExternalID == "" {
-		data.ExternalID = types.StringNull()
 	} else {
-		data.ExternalID = types.StringValue(collResp.ExternalID)
+		// Overwrite the model with the refreshed data
+		data.Name = types.StringValue(name)
 	}
 
+	r.refreshExternalID(ctx, &data, collResp.ExternalID)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
 }
 
 func (r *OrganizationCollection) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -231,22 +310,40 @@ func (r *OrganizationCollection) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Create the missing parent collections of a changed name
+	r.createParents(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update the organization collection if needed
 	collection := models.Collection{
 		Name:       data.Name.ValueString(),
 		ExternalID: data.ExternalID.ValueString(),
 	}
+	if !data.ExternalID.IsNull() {
+		r.warnUnsupportedExternalID(ctx, &resp.Diagnostics)
+	}
 
 	if _, err := r.client.UpdateOrganizationCollection(ctx, data.OrganizationID.ValueString(), data.ID.ValueString(), collection); err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating Vaultwarden organization collection",
-			"Could not update organization collection, unexpected error: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Error updating Vaultwarden organization collection", "Could not update organization collection, unexpected error: ", err, organizationCollectionFieldPaths)
 		return
 	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
 }
 
 func (r *OrganizationCollection) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -259,75 +356,68 @@ func (r *OrganizationCollection) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the organization collection
 	if err := r.client.DeleteOrganizationCollection(ctx, data.OrganizationID.ValueString(), data.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Vaultwarden organization collection",
-			"Could not delete organization collection, unexpected error: "+err.Error(),
+			"Could not delete organization collection, unexpected error: "+clientErrorDetail(err),
 		)
 		return
 	}
 }
 
 func (r *OrganizationCollection) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, "/")
-	if len(idParts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid ID format",
-			"Expected import identifier with format: organization_id/collection_id",
-		)
+	organizationID, collectionID, ok := organizationResourceImportIDs(ctx, req, "organization_id/collection_id", &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
-	organizationID := idParts[0]
-	collectionID := idParts[1]
-
 	// Set the organization_id and id attributes
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), collectionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("create_parents"), false)...)
 
 	// After setting the IDs, fetch the current state of the resource
-	collection, err := r.client.GetOrganizationCollection(ctx, idParts[0], idParts[1])
+	collection, err := r.client.GetOrganizationCollection(ctx, organizationID, collectionID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error importing organization collection",
-			fmt.Sprintf("Cannot read organization collection %s: %v", req.ID, err),
+			fmt.Sprintf("Cannot read organization collection %s: %s", collectionID, clientErrorDetail(err)),
 		)
 		return
 	}
 
-	// Get organization data from cache
-	orgSecret, exists := r.client.AuthState.Organizations[idParts[0]]
-	if !exists {
-		resp.Diagnostics.AddError(
-			"Error importing organization collection",
-			"Could not read organization collection, organization not found or not authenticated",
-		)
-		return
-	}
-
-	// Convert and decrypt the name
-	encryptedName, err := encryptedstring.NewFromEncryptedValue(collection.Name)
+	// Decrypt the name
+	name, err := r.client.DecryptOrganizationString(ctx, organizationID, collection.Name)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error importing organization collection",
-			fmt.Sprintf("Failed to parse encrypted name: %v", err),
+		if !r.client.IgnoreDecryptionErrors() {
+			resp.Diagnostics.AddError(
+				"Error importing organization collection",
+				fmt.Sprintf("Failed to decrypt name: %v", err),
+			)
+			return
+		}
+
+		// Leave the name unset so that it is taken from the configuration
+		resp.Diagnostics.AddWarning(
+			"Unable to decrypt collection name",
+			fmt.Sprintf("The name of organization collection %s could not be decrypted and is left unset: %v", collectionID, err),
 		)
-		return
-	}
-
-	decryptedBytes, err := crypt.Decrypt(encryptedName, &orgSecret.Key)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error importing organization collection",
-			fmt.Sprintf("Failed to decrypt name: %v", err),
-		)
-		return
+	} else {
+		// Set the name
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
 	}
 
-	// Set the name
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), string(decryptedBytes))...)
-
 	// Set external_id if it exists
 	if collection.ExternalID != "" {
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("external_id"), collection.ExternalID)...)
@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/test"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"strings"
 	"testing"
 )
 
@@ -63,6 +68,115 @@ func TestAccOrganizationCollection(t *testing.T) {
 	})
 }
 
+func TestAccOrganizationCollectionIdentity(t *testing.T) {
+	orgName := gofakeit.Company()
+	collectionName := gofakeit.ProductName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			// Create testing with the identity matching the state
+			{
+				Config: testAccOrganizationCollectionConfig(orgName, collectionName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("vaultwarden_organization_collection.test", tfjsonpath.New("organization_id")),
+					statecheck.ExpectIdentityValueMatchesState("vaultwarden_organization_collection.test", tfjsonpath.New("id")),
+				},
+			},
+			// Import testing with an import block by identity
+			{
+				ResourceName:    "vaultwarden_organization_collection.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccOrganizationCollectionCreateParents(t *testing.T) {
+	orgName := gofakeit.Company()
+	parentName := gofakeit.ProductName()
+	collectionName := parentName + "/" + gofakeit.ProductName() + "/" + gofakeit.ProductName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing with the missing parent collections created alongside
+			{
+				Config: testAccOrganizationCollectionConfigCreateParents(orgName, collectionName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_collection.test", "name", collectionName),
+					resource.TestCheckResourceAttr("vaultwarden_organization_collection.test", "create_parents", "true"),
+					testAccCheckOrganizationCollectionExists(t, "vaultwarden_organization_collection.test", parentName),
+					testAccCheckOrganizationCollectionExists(t, "vaultwarden_organization_collection.test", collectionName[:strings.LastIndex(collectionName, "/")]),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckOrganizationCollectionExists checks that a collection with the name exists in the organization of the resource
+func testAccCheckOrganizationCollectionExists(t *testing.T, resourceName, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found in state: %s", resourceName)
+		}
+
+		ctx := context.Background()
+		client, err := test.GetTestClient(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to get test client: %w", err)
+		}
+
+		_, err = client.FindOrganizationCollectionByName(ctx, rs.Primary.Attributes["organization_id"], name)
+		return err
+	}
+}
+
+func TestAccOrganizationCollectionDisappears(t *testing.T) {
+	orgName := gofakeit.Company()
+	collectionName := gofakeit.ProductName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Delete the collection outside of Terraform and expect it to be recreated
+			{
+				Config: testAccOrganizationCollectionConfig(orgName, collectionName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckOrganizationCollectionDisappears(t, "vaultwarden_organization_collection.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckOrganizationCollectionDisappears deletes the collection outside of Terraform
+func testAccCheckOrganizationCollectionDisappears(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found in state: %s", resourceName)
+		}
+
+		ctx := context.Background()
+		client, err := test.GetTestClient(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to get test client: %w", err)
+		}
+
+		return client.DeleteOrganizationCollection(ctx, rs.Primary.Attributes["organization_id"], rs.Primary.ID)
+	}
+}
+
 // Base configuration
 func testAccOrganizationCollectionConfig(orgName, collectionName string) string {
 	return fmt.Sprintf(`
@@ -105,3 +219,25 @@ resource "vaultwarden_organization_collection" "test" {
 }
 `, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, collectionName, externalID)
 }
+
+// Configuration with a nested name and create_parents enabled
+func testAccOrganizationCollectionConfigCreateParents(orgName, collectionName string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_collection" "test" {
+    organization_id = vaultwarden_organization.test.id
+    name            = %[6]q
+    create_parents  = true
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, collectionName)
+}
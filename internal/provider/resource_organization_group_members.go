@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationGroupMembers{}
+var _ resource.ResourceWithConfigure = &OrganizationGroupMembers{}
+var _ resource.ResourceWithImportState = &OrganizationGroupMembers{}
+var _ resource.ResourceWithIdentity = &OrganizationGroupMembers{}
+
+func OrganizationGroupMembersResource() resource.Resource {
+	return &OrganizationGroupMembers{}
+}
+
+// OrganizationGroupMembers defines the resource implementation.
+type OrganizationGroupMembers struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationGroupMembersModel describes the resource data model.
+type OrganizationGroupMembersModel struct {
+	ID             types.String   `tfsdk:"id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	GroupID        types.String   `tfsdk:"group_id"`
+	MemberIDs      types.Set      `tfsdk:"member_ids"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationGroupMembersFieldPaths maps the request fields of group members to their attributes to report validation errors
+var organizationGroupMembersFieldPaths = map[string]path.Path{
+	"users": path.Root("member_ids"),
+}
+
+func (r *OrganizationGroupMembers) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_group_members"
+}
+
+func (r *OrganizationGroupMembers) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages the members of a group in an organization on the Vaultwarden server. Groups require the `ORG_GROUPS_ENABLED` setting of Vaultwarden.\n\n" +
+			"The member list is authoritative and replaced with a single request: users not listed are removed from the group. " +
+			"The group itself is not managed. Destroying the resource removes all members from the group.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, which is the ID of the group",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the users in the organization who are members of the group, e.g. the `id` of `vaultwarden_organization_user` resources",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *OrganizationGroupMembers) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationResourceIdentitySchema("ID of the group")
+}
+
+func (r *OrganizationGroupMembers) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// update replaces the members of the group with the members of the model
+func (r *OrganizationGroupMembers) update(ctx context.Context, data *OrganizationGroupMembersModel, diags *diag.Diagnostics) {
+	var memberIDs []string
+	diags.Append(data.MemberIDs.ElementsAs(ctx, &memberIDs, false)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	if err := r.client.RequireFeature(ctx, vaultwarden.FeatureOrganizationGroups); err != nil {
+		diags.AddError("Unsupported organization groups", "The members of the group can't be set: "+err.Error())
+		return
+	}
+
+	groupID := data.GroupID.ValueString()
+	if err := r.client.UpdateOrganizationGroupUsers(ctx, data.OrganizationID.ValueString(), groupID, memberIDs); err != nil {
+		addClientError(diags, "Error updating organization group members", "Could not update the members of organization group with ID "+groupID+": ", err, organizationGroupMembersFieldPaths)
+		return
+	}
+
+	data.ID = data.GroupID
+}
+
+func (r *OrganizationGroupMembers) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationGroupMembersModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("set the members of organization group with ID %s", data.GroupID.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
+}
+
+func (r *OrganizationGroupMembers) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationGroupMembersModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get refreshed data from the client
+	memberIDs, err := r.client.GetOrganizationGroupUsers(ctx, data.OrganizationID.ValueString(), data.GroupID.ValueString())
+	if err != nil {
+		// Remove the members from the state if the group no longer exists
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("organization group with ID %s no longer exists, removing it from state", data.GroupID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error fetching organization group members",
+			"Could not fetch organization group members, unexpected error: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Overwrite the model with the refreshed data
+	data.ID = data.GroupID
+	data.MemberIDs, diags = types.SetValueFrom(ctx, types.StringType, memberIDs)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
+}
+
+func (r *OrganizationGroupMembers) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrganizationGroupMembersModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
+}
+
+func (r *OrganizationGroupMembers) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationGroupMembersModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Remove all members from the group, the group itself is not managed by this resource
+	if err := r.client.UpdateOrganizationGroupUsers(ctx, data.OrganizationID.ValueString(), data.GroupID.ValueString(), nil); err != nil {
+		// The group no longer exists
+		if isNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error removing organization group members",
+			"Could not remove the members of organization group with ID "+data.GroupID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+}
+
+func (r *OrganizationGroupMembers) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organizationID, groupID, ok := organizationResourceImportIDs(ctx, req, "organization_id/group_id", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set the identifying attributes, the members are refreshed by the subsequent read
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"os"
+	"testing"
+)
+
+func TestAccOrganizationGroupMembers(t *testing.T) {
+	// The group is created before the test case, which would otherwise only be skipped by it
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client, err := test.GetTestClient(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to get test client: %s", err)
+	}
+
+	// Groups are not managed by the provider, so the group is created outside of Terraform
+	org, err := client.CreateOrganization(ctx, models.Organization{Name: gofakeit.Company(), CollectionName: "Default"})
+	if err != nil {
+		t.Fatalf("failed to create organization: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := client.DeleteOrganization(context.Background(), org.ID); err != nil {
+			t.Logf("failed to delete organization %s: %s", org.ID, err)
+		}
+	})
+
+	emails := []string{gofakeit.Email(), gofakeit.Email()}
+	group, err := client.CreateOrganizationGroup(ctx, org.ID, models.Group{Name: gofakeit.JobTitle()})
+	if err != nil {
+		t.Fatalf("failed to create group: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with both users
+			{
+				Config: testAccOrganizationGroupMembersConfig(org.ID, group.ID, emails, "vaultwarden_organization_user.first.id, vaultwarden_organization_user.second.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_group_members.test", "id", group.ID),
+					resource.TestCheckResourceAttr("vaultwarden_organization_group_members.test", "member_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("vaultwarden_organization_group_members.test", "member_ids.*", "vaultwarden_organization_user.first", "id"),
+					resource.TestCheckTypeSetElemAttrPair("vaultwarden_organization_group_members.test", "member_ids.*", "vaultwarden_organization_user.second", "id"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      "vaultwarden_organization_group_members.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccOrganizationGroupMembersImportStateIdFunc(),
+			},
+			// Update testing removing a user from the group
+			{
+				Config: testAccOrganizationGroupMembersConfig(org.ID, group.ID, emails, "vaultwarden_organization_user.second.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_group_members.test", "member_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("vaultwarden_organization_group_members.test", "member_ids.*", "vaultwarden_organization_user.second", "id"),
+				),
+			},
+			// Update testing removing all users from the group
+			{
+				Config: testAccOrganizationGroupMembersConfig(org.ID, group.ID, emails, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_group_members.test", "member_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationGroupMembersImportStateIdFunc() resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources["vaultwarden_organization_group_members.test"]
+		if !ok {
+			return "", fmt.Errorf("resource not found in state")
+		}
+
+		return fmt.Sprintf("%s/%s",
+			rs.Primary.Attributes["organization_id"],
+			rs.Primary.Attributes["group_id"]), nil
+	}
+}
+
+func testAccOrganizationGroupMembersConfig(orgID, groupID string, emails []string, memberIDs string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization_user" "first" {
+    organization_id = %[5]q
+    email           = %[7]q
+}
+
+resource "vaultwarden_organization_user" "second" {
+    organization_id = %[5]q
+    email           = %[8]q
+}
+
+resource "vaultwarden_organization_group_members" "test" {
+    organization_id = %[5]q
+    group_id        = %[6]q
+    member_ids      = [%[9]s]
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgID, groupID, emails[0], emails[1], memberIDs)
+}
@@ -0,0 +1,704 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationMembers{}
+var _ resource.ResourceWithConfigure = &OrganizationMembers{}
+var _ resource.ResourceWithImportState = &OrganizationMembers{}
+
+func OrganizationMembersResource() resource.Resource {
+	return &OrganizationMembers{}
+}
+
+// OrganizationMembers defines the resource implementation.
+type OrganizationMembers struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationMembersModel describes the resource data model.
+type OrganizationMembersModel struct {
+	ID             types.String   `tfsdk:"id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Emails         types.Set      `tfsdk:"emails"`
+	Type           types.String   `tfsdk:"type"`
+	Collections    types.Set      `tfsdk:"collections"`
+	UserIDs        types.Map      `tfsdk:"user_ids"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationMembersFieldPaths maps the request fields of organization users to their attributes to report validation errors
+var organizationMembersFieldPaths = map[string]path.Path{
+	"emails":      path.Root("emails"),
+	"email":       path.Root("emails"),
+	"type":        path.Root("type"),
+	"collections": path.Root("collections"),
+}
+
+// OrganizationMembersCollectionModel describes the access of the members to a collection.
+type OrganizationMembersCollectionModel struct {
+	ID            types.String `tfsdk:"id"`
+	ReadOnly      types.Bool   `tfsdk:"read_only"`
+	HidePasswords types.Bool   `tfsdk:"hide_passwords"`
+	Manage        types.Bool   `tfsdk:"manage"`
+}
+
+// organizationMembersCollectionAttrTypes contains the attribute types of the collection access objects
+var organizationMembersCollectionAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"read_only":      types.BoolType,
+	"hide_passwords": types.BoolType,
+	"manage":         types.BoolType,
+}
+
+// containsEmail reports whether the emails contain the given email, ignoring its case as Vaultwarden stores emails in lower case
+func containsEmail(emails []string, email string) bool {
+	return slices.ContainsFunc(emails, func(e string) bool { return strings.EqualFold(e, email) })
+}
+
+// organizationMembersID returns the ID of a set of members in the import format, i.e. the ID of the organization
+// followed by the sorted emails of the members in lower case
+func organizationMembersID(orgID string, emails []string) string {
+	normalized := make([]string, 0, len(emails))
+	for _, email := range emails {
+		normalized = append(normalized, strings.ToLower(email))
+	}
+	slices.Sort(normalized)
+
+	return orgID + "/" + strings.Join(slices.Compact(normalized), ",")
+}
+
+// expandOrganizationMembersCollections converts the collection access objects to the API model
+func expandOrganizationMembersCollections(ctx context.Context, collections types.Set) ([]models.CollectionAccess, diag.Diagnostics) {
+	access := []models.CollectionAccess{}
+	if collections.IsNull() || collections.IsUnknown() {
+		return access, nil
+	}
+
+	var data []OrganizationMembersCollectionModel
+	diags := collections.ElementsAs(ctx, &data, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, collection := range data {
+		access = append(access, models.CollectionAccess{
+			ID:            collection.ID.ValueString(),
+			ReadOnly:      collection.ReadOnly.ValueBool(),
+			HidePasswords: collection.HidePasswords.ValueBool(),
+			Manage:        collection.Manage.ValueBool(),
+		})
+	}
+
+	return access, diags
+}
+
+// requireCollectionManage reports an error if the manage permission is granted on a server without the collection
+// permission model, which would ignore it
+func requireCollectionManage(ctx context.Context, client *vaultwarden.Client, collections []models.CollectionAccess, diags *diag.Diagnostics) {
+	if !slices.ContainsFunc(collections, func(access models.CollectionAccess) bool { return access.Manage }) {
+		return
+	}
+
+	if err := client.RequireFeature(ctx, vaultwarden.FeatureCollectionPermissions); err != nil {
+		diags.AddAttributeError(
+			path.Root("collections"),
+			"Unsupported collection permission",
+			"The manage permission can't be granted: "+err.Error(),
+		)
+	}
+}
+
+func (r *OrganizationMembers) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_members"
+}
+
+func (r *OrganizationMembers) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource invites a set of users with the same role and collection access to an organization on the Vaultwarden server.\n\n" +
+			"Users are invited and removed in a single request per change, which reduces the number of requests for large batches compared to `vaultwarden_organization_user`. " +
+			"Do not manage the same users with both resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, which is the ID of the organization followed by the sorted emails of the users in lower case, e.g. `organization_id/bar@example.com,foo@example.com`",
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization to invite the users to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"emails": schema.SetAttribute{
+				MarkdownDescription: "The emails of the users to invite. Emails are compared case-insensitively, as Vaultwarden stores them in lower case",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(validEmail()),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The role type of the users (Owner, Admin, User, Manager). The Manager role is deprecated as of Vaultwarden 1.32.0. Defaults to `User`",
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString("User"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("Owner", "Admin", "User", "Manager"),
+				},
+			},
+			"collections": schema.SetNestedAttribute{
+				MarkdownDescription: "The collections the users have access to. If not set, the collections of the users are not managed and kept on updates",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the collection",
+							Required:            true,
+						},
+						"read_only": schema.BoolAttribute{
+							MarkdownDescription: "Whether the users can only read the items of the collection. Defaults to `false`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"hide_passwords": schema.BoolAttribute{
+							MarkdownDescription: "Whether the passwords of the items of the collection are hidden from the users. Defaults to `false`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"manage": schema.BoolAttribute{
+							MarkdownDescription: "Whether the users can manage the collection, e.g. its name and access, without being admins. Requires Vaultwarden 1.32.0 or newer. Defaults to `false`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"user_ids": schema.MapAttribute{
+				MarkdownDescription: "The IDs of the invited users in the organization, keyed by their email",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *OrganizationMembers) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// inviteRequest builds the request to invite users with the role and collection access of the model
+func (r *OrganizationMembers) inviteRequest(ctx context.Context, data OrganizationMembersModel) (vaultwarden.InviteOrganizationUserRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Parse the type string into a UserOrgType (value will always be present due to schema default)
+	var userType models.UserOrgType
+	if err := userType.FromString(data.Type.ValueString()); err != nil {
+		diags.AddAttributeError(path.Root("type"), "Error parsing user type", "Could not parse user type: "+err.Error())
+		return vaultwarden.InviteOrganizationUserRequest{}, diags
+	}
+	warnDeprecatedOrganizationUserType(ctx, r.client, userType, &diags)
+
+	collections, collectionDiags := expandOrganizationMembersCollections(ctx, data.Collections)
+	diags.Append(collectionDiags...)
+	requireCollectionManage(ctx, r.client, collections, &diags)
+
+	return vaultwarden.InviteOrganizationUserRequest{
+		Type:        userType,
+		Collections: collections,
+	}, diags
+}
+
+// refreshUserIDs looks up the IDs of the users in the emails of the model and sets the ID of the resource
+func (r *OrganizationMembers) refreshUserIDs(ctx context.Context, data *OrganizationMembersModel, diags *diag.Diagnostics) {
+	var emails []string
+	diags.Append(data.Emails.ElementsAs(ctx, &emails, false)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	users, err := r.client.GetOrganizationUsers(ctx, data.OrganizationID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error fetching organization users",
+			"Could not fetch organization users, unexpected error: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// The user IDs are keyed by the emails as configured
+	userIDs := make(map[string]string, len(emails))
+	for _, email := range emails {
+		index := slices.IndexFunc(users.Data, func(user models.OrganizationUserDetails) bool { return strings.EqualFold(user.Email, email) })
+		if index < 0 {
+			diags.AddError(
+				"Error fetching invited user",
+				"Could not find invited user "+email+" in organization "+data.OrganizationID.ValueString(),
+			)
+			continue
+		}
+		userIDs[email] = users.Data[index].ID
+	}
+
+	data.ID = types.StringValue(organizationMembersID(data.OrganizationID.ValueString(), emails))
+	userIDsValue, mapDiags := types.MapValueFrom(ctx, types.StringType, userIDs)
+	diags.Append(mapDiags...)
+	data.UserIDs = userIDsValue
+}
+
+// checkOwnersRemoval reports an error if removing or demoting the given owners would leave the organization without a
+// confirmed owner, so that Vaultwarden doesn't reject the change after some of the users have been changed already
+func (r *OrganizationMembers) checkOwnersRemoval(ctx context.Context, orgID string, userIDs []string, diags *diag.Diagnostics) {
+	err := r.client.CheckOrganizationOwnersRemoval(ctx, orgID, userIDs)
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, vaultwarden.ErrLastOrganizationOwner) {
+		diags.AddError(
+			"Last organization owner",
+			"The change would remove or demote all confirmed owners of organization with ID "+orgID+", which Vaultwarden doesn't allow. "+
+				"Make another member an owner first, e.g. with the transfer_ownership_to attribute of vaultwarden_organization_user.",
+		)
+		return
+	}
+
+	diags.AddError(
+		"Error fetching organization owners",
+		"Could not fetch the owners of organization with ID "+orgID+": "+clientErrorDetail(err),
+	)
+}
+
+func (r *OrganizationMembers) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationMembersModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	inviteReq, diags := r.inviteRequest(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	var emails []string
+	resp.Diagnostics.Append(data.Emails.ElementsAs(ctx, &emails, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Invite all users at once
+	if err := r.client.InviteOrganizationUsers(ctx, inviteReq, emails, data.OrganizationID.ValueString()); err != nil {
+		addClientError(&resp.Diagnostics, "Error inviting users", "Could not invite users, unexpected error: ", err, organizationMembersFieldPaths)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	r.refreshUserIDs(ctx, &data, &resp.Diagnostics)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("invited %d new organization users to organization with ID: %s", len(emails), data.OrganizationID))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationMembers) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationMembersModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var emails []string
+	resp.Diagnostics.Append(data.Emails.ElementsAs(ctx, &emails, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed data from the client
+	users, err := r.client.GetOrganizationUsers(ctx, data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error fetching organization users",
+			"Could not fetch organization users, unexpected error: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Keep the users which are still members of the organization, the others are invited again on the next apply.
+	// The emails are kept as configured, Vaultwarden stores them in lower case.
+	members := []string{}
+	userIDs := map[string]string{}
+	for _, email := range emails {
+		index := slices.IndexFunc(users.Data, func(user models.OrganizationUserDetails) bool { return strings.EqualFold(user.Email, email) })
+		if index < 0 {
+			continue
+		}
+		user := users.Data[index]
+
+		members = append(members, email)
+		userIDs[email] = user.ID
+
+		// Report a changed role of any member as a change of the shared role, so it is applied to every member again
+		if user.Type.String() != data.Type.ValueString() {
+			data.Type = types.StringValue(user.Type.String())
+		}
+
+		// Likewise report changed collections of any member, if the collections are managed
+		if data.Collections.IsNull() {
+			continue
+		}
+
+		details, err := r.client.GetOrganizationUser(ctx, user.ID, data.OrganizationID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error fetching organization user",
+				"Could not fetch organization user with ID "+user.ID+": "+clientErrorDetail(err),
+			)
+			return
+		}
+
+		collections, diags := flattenOrganizationUserCollections(ctx, details.Collections)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !collections.Equal(data.Collections) {
+			data.Collections = collections
+		}
+	}
+
+	if len(members) != len(emails) {
+		tflog.Warn(ctx, fmt.Sprintf("%d users are no longer members of organization %s", len(emails)-len(members), data.OrganizationID.ValueString()))
+	}
+
+	// Overwrite the model with the refreshed data
+	data.ID = types.StringValue(organizationMembersID(data.OrganizationID.ValueString(), emails))
+	data.Emails, diags = types.SetValueFrom(ctx, types.StringType, members)
+	resp.Diagnostics.Append(diags...)
+
+	data.UserIDs, diags = types.MapValueFrom(ctx, types.StringType, userIDs)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationMembers) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state OrganizationMembersModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	inviteReq, diags := r.inviteRequest(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	var emails, stateEmails []string
+	resp.Diagnostics.Append(data.Emails.ElementsAs(ctx, &emails, false)...)
+	resp.Diagnostics.Append(state.Emails.ElementsAs(ctx, &stateEmails, false)...)
+
+	var stateUserIDs map[string]string
+	resp.Diagnostics.Append(state.UserIDs.ElementsAs(ctx, &stateUserIDs, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Look up the users by their email in lower case, so that changing the case of an email doesn't invite the user again
+	userIDsByEmail := make(map[string]string, len(stateUserIDs))
+	for email, userID := range stateUserIDs {
+		userIDsByEmail[strings.ToLower(email)] = userID
+	}
+
+	orgID := data.OrganizationID.ValueString()
+
+	// Remove the users which are no longer in the set at once
+	var removedIDs []string
+	for _, email := range stateEmails {
+		if userID, ok := userIDsByEmail[strings.ToLower(email)]; ok && !containsEmail(emails, email) {
+			removedIDs = append(removedIDs, userID)
+		}
+	}
+
+	// Owners which are removed or demoted must not be the last ones
+	if state.Type.ValueString() == "Owner" {
+		demotedIDs := removedIDs
+		if !data.Type.Equal(state.Type) {
+			demotedIDs = slices.Collect(maps.Values(userIDsByEmail))
+		}
+
+		if len(demotedIDs) > 0 {
+			r.checkOwnersRemoval(ctx, orgID, demotedIDs, &resp.Diagnostics)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	if len(removedIDs) > 0 {
+		if err := r.client.DeleteOrganizationUsers(ctx, removedIDs, orgID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting organization users",
+				"Could not delete organization users, unexpected error: "+clientErrorDetail(err),
+			)
+			return
+		}
+	}
+
+	// Invite the users which have been added to the set at once
+	var addedEmails []string
+	for _, email := range emails {
+		if !containsEmail(stateEmails, email) {
+			addedEmails = append(addedEmails, email)
+		}
+	}
+
+	if len(addedEmails) > 0 {
+		if err := r.client.InviteOrganizationUsers(ctx, inviteReq, addedEmails, orgID); err != nil {
+			addClientError(&resp.Diagnostics, "Error inviting users", "Could not invite users, unexpected error: ", err, organizationMembersFieldPaths)
+			return
+		}
+	}
+
+	// Apply a changed role or collection access to the remaining users, which has to be done one by one
+	if !data.Type.Equal(state.Type) || !data.Collections.Equal(state.Collections) {
+		for _, email := range emails {
+			userID, ok := userIDsByEmail[strings.ToLower(email)]
+			if !ok || containsEmail(addedEmails, email) {
+				continue
+			}
+
+			user := models.OrganizationUserDetails{
+				Email:       email,
+				Type:        inviteReq.Type,
+				Collections: inviteReq.Collections,
+			}
+
+			// Keep the collections of the user if they are not managed, which Vaultwarden replaces on every update
+			if data.Collections.IsNull() {
+				current, err := r.client.GetOrganizationUser(ctx, userID, orgID)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error fetching organization user",
+						"Could not fetch organization user with ID "+userID+": "+clientErrorDetail(err),
+					)
+					return
+				}
+				user.Collections = current.Collections
+			}
+
+			if _, err := r.client.UpdateOrganizationUser(ctx, userID, orgID, user); err != nil {
+				addClientError(&resp.Diagnostics, "Error updating organization user", "Could not update organization user with ID "+userID+": ", err, organizationMembersFieldPaths)
+				return
+			}
+		}
+	}
+
+	r.refreshUserIDs(ctx, &data, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationMembers) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationMembersModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var userIDs map[string]string
+	resp.Diagnostics.Append(data.UserIDs.ElementsAs(ctx, &userIDs, false)...)
+
+	if resp.Diagnostics.HasError() || len(userIDs) == 0 {
+		return
+	}
+
+	// Delete all users at once
+	ids := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		ids = append(ids, userID)
+	}
+
+	if data.Type.ValueString() == "Owner" {
+		r.checkOwnersRemoval(ctx, data.OrganizationID.ValueString(), ids, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if err := r.client.DeleteOrganizationUsers(ctx, ids, data.OrganizationID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting organization users",
+			"Could not delete organization users of organization with ID "+data.OrganizationID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+}
+
+func (r *OrganizationMembers) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid ID format",
+			"Expected import identifier with format: organization_id/email[,email...]",
+		)
+		return
+	}
+
+	organizationID := idParts[0]
+	emails := strings.Split(idParts[1], ",")
+
+	users, err := r.client.GetOrganizationUsers(ctx, organizationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error fetching organization users",
+			"Could not fetch organization users, unexpected error: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Import the role of the first member, differing roles of other members are reported as a diff
+	userType := ""
+	for _, user := range users.Data {
+		if containsEmail(emails, user.Email) {
+			userType = user.Type.String()
+			break
+		}
+	}
+
+	if userType == "" {
+		resp.Diagnostics.AddError(
+			"Error fetching organization users",
+			"None of the users "+idParts[1]+" are members of organization "+organizationID,
+		)
+		return
+	}
+
+	emailsValue, diags := types.SetValueFrom(ctx, types.StringType, emails)
+	resp.Diagnostics.Append(diags...)
+
+	// Set the identifying attributes, the rest is refreshed by the subsequent read
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), organizationMembersID(organizationID, emails))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("emails"), emailsValue)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), userType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collections"), types.SetNull(types.ObjectType{AttrTypes: organizationMembersCollectionAttrTypes}))...)
+}
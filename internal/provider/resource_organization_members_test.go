@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAccOrganizationMembers(t *testing.T) {
+	orgName := gofakeit.Company()
+	email1 := gofakeit.Email()
+	email2 := gofakeit.Email()
+	email3 := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with default values
+			{
+				Config: testAccOrganizationMembersConfig(orgName, "User", email1, email2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "emails.#", "2"),
+					resource.TestCheckTypeSetElemAttr("vaultwarden_organization_members.test", "emails.*", email1),
+					resource.TestCheckTypeSetElemAttr("vaultwarden_organization_members.test", "emails.*", email2),
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "type", "User"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "user_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization_members.test", "user_ids."+email1),
+					resource.TestCheckResourceAttrPair("vaultwarden_organization_members.test", "organization_id", "vaultwarden_organization.test", "id"),
+					testAccCheckOrganizationMembersID("vaultwarden_organization_members.test", email1, email2),
+				),
+			},
+			// Update testing with a replaced user and a changed role
+			{
+				Config: testAccOrganizationMembersConfig(orgName, "Manager", email2, email3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "emails.#", "2"),
+					resource.TestCheckTypeSetElemAttr("vaultwarden_organization_members.test", "emails.*", email2),
+					resource.TestCheckTypeSetElemAttr("vaultwarden_organization_members.test", "emails.*", email3),
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "type", "Manager"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "user_ids.%", "2"),
+					resource.TestCheckNoResourceAttr("vaultwarden_organization_members.test", "user_ids."+email1),
+				),
+			},
+			// Import testing, the ID is in the import format
+			{
+				ResourceName:            "vaultwarden_organization_members.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"collections"},
+			},
+		},
+	})
+}
+
+func TestAccOrganizationMembersInvalidEmail(t *testing.T) {
+	orgName := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOrganizationMembersConfig(orgName, "User", "not-an-email"),
+				ExpectError: regexp.MustCompile(`Invalid email address`),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationMembersKeepsUnmanagedCollections(t *testing.T) {
+	orgName := gofakeit.Company()
+	collectionName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationMembersUserCollectionsConfig(orgName, collectionName, "User", email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.#", "1"),
+				),
+			},
+			// A changed role keeps the collections granted by another resource, a removed grant fails the plan check
+			{
+				Config: testAccOrganizationMembersUserCollectionsConfig(orgName, collectionName, "Manager", email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "type", "Manager"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationMembersMixedCaseEmail(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+	mixedCaseEmail := strings.ToUpper(email[:1]) + email[1:]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Vaultwarden stores the email in lower case, the configured email is kept without a diff
+			{
+				Config: testAccOrganizationMembersConfig(orgName, "User", mixedCaseEmail),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("vaultwarden_organization_members.test", "emails.*", mixedCaseEmail),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization_members.test", "user_ids."+mixedCaseEmail),
+					testAccCheckOrganizationMembersID("vaultwarden_organization_members.test", email),
+				),
+			},
+			// Changing the case of the email keeps the member
+			{
+				Config: testAccOrganizationMembersConfig(orgName, "User", email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("vaultwarden_organization_members.test", "emails.*", email),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization_members.test", "user_ids."+email),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationMembersCollectionsDrift(t *testing.T) {
+	orgName := gofakeit.Company()
+	collectionName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Revoke the collection access outside of Terraform and expect it to be granted again
+			{
+				Config: testAccOrganizationMembersCollectionsConfig(orgName, collectionName, email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_members.test", "collections.#", "1"),
+					testAccCheckOrganizationMembersCollectionsRevoked(t, "vaultwarden_organization_members.test", email),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckOrganizationMembersID checks that the ID of the resource consists of the organization ID and the sorted emails
+func testAccCheckOrganizationMembersID(resourceName string, emails ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found in state: %s", resourceName)
+		}
+
+		var sorted []string
+		for _, email := range emails {
+			sorted = append(sorted, strings.ToLower(email))
+		}
+		slices.Sort(sorted)
+		if expected := rs.Primary.Attributes["organization_id"] + "/" + strings.Join(sorted, ","); rs.Primary.ID != expected {
+			return fmt.Errorf("expected ID %s, got %s", expected, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckOrganizationMembersCollectionsRevoked revokes the collection access of a member outside of Terraform
+func testAccCheckOrganizationMembersCollectionsRevoked(t *testing.T, resourceName, email string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found in state: %s", resourceName)
+		}
+
+		ctx := context.Background()
+		client, err := test.GetTestClient(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to get test client: %w", err)
+		}
+
+		_, err = client.UpdateOrganizationUserCollections(ctx, rs.Primary.Attributes["user_ids."+email], rs.Primary.Attributes["organization_id"], []models.CollectionAccess{})
+		return err
+	}
+}
+
+// Configuration with a set of emails sharing a role
+func testAccOrganizationMembersConfig(orgName, userType string, emails ...string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_members" "test" {
+    organization_id = vaultwarden_organization.test.id
+    emails          = ["%[6]s"]
+    type            = %[7]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, strings.Join(emails, `", "`), userType)
+}
+
+// Configuration with a member whose collections are managed by another resource
+func testAccOrganizationMembersUserCollectionsConfig(orgName, collectionName, userType, email string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_collection" "test" {
+    organization_id = vaultwarden_organization.test.id
+    name            = %[6]q
+}
+
+resource "vaultwarden_organization_members" "test" {
+    organization_id = vaultwarden_organization.test.id
+    emails          = [%[7]q]
+    type            = %[8]q
+}
+
+resource "vaultwarden_organization_user_collections" "test" {
+    organization_id = vaultwarden_organization.test.id
+    user_id         = vaultwarden_organization_members.test.user_ids[%[7]q]
+
+    collections = [{
+        id = vaultwarden_organization_collection.test.id
+    }]
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, collectionName, email, userType)
+}
+
+// Configuration with a member granted access to a collection
+func testAccOrganizationMembersCollectionsConfig(orgName, collectionName, email string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_collection" "test" {
+    organization_id = vaultwarden_organization.test.id
+    name            = %[6]q
+}
+
+resource "vaultwarden_organization_members" "test" {
+    organization_id = vaultwarden_organization.test.id
+    emails          = [%[7]q]
+
+    collections = [{
+        id = vaultwarden_organization_collection.test.id
+    }]
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, collectionName, email)
+}
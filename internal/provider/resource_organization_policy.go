@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"strconv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationTogglePolicy{}
+var _ resource.ResourceWithConfigure = &OrganizationTogglePolicy{}
+var _ resource.ResourceWithImportState = &OrganizationTogglePolicy{}
+
+func OrganizationPolicyPersonalOwnershipResource() resource.Resource {
+	return &OrganizationTogglePolicy{
+		policyType: models.PolicyTypePersonalOwnership,
+		typeName:   "_organization_policy_personal_ownership",
+		description: "This resource manages the personal ownership policy of an organization, which disables the personal vault of its members. " +
+			"Members have to save new items to the organization instead.",
+	}
+}
+
+func OrganizationPolicyRequireSSOResource() resource.Resource {
+	return &OrganizationTogglePolicy{
+		policyType: models.PolicyTypeRequireSso,
+		typeName:   "_organization_policy_require_sso",
+		description: "This resource manages the require single sign-on authentication policy of an organization, which requires its members to log in with SSO. " +
+			"Owners and admins are exempt from the policy. It only takes effect if SSO is enabled on the Vaultwarden server.",
+	}
+}
+
+// OrganizationTogglePolicy defines the implementation of the resources for policies which can only be enabled or
+// disabled, each policy type is a resource of its own.
+type OrganizationTogglePolicy struct {
+	client      *vaultwarden.Client
+	policyType  models.PolicyType
+	typeName    string
+	description string
+}
+
+// OrganizationTogglePolicyModel describes the resource data model.
+type OrganizationTogglePolicyModel struct {
+	ID             types.String   `tfsdk:"id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// updateOrganizationPolicy updates a policy of an organization, returning nil if it fails
+func updateOrganizationPolicy(ctx context.Context, client *vaultwarden.Client, orgID string, policy models.Policy, diags *diag.Diagnostics) *models.Policy {
+	policyResp, err := client.UpdateOrganizationPolicy(ctx, orgID, policy)
+	if err != nil {
+		diags.AddError(
+			"Error updating organization policy",
+			"Could not update policy "+strconv.FormatInt(int64(policy.Type), 10)+" of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		return nil
+	}
+
+	return policyResp
+}
+
+func (r *OrganizationTogglePolicy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeName
+}
+
+func (r *OrganizationTogglePolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: r.description + "\n\nDestroying the resource disables the policy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the policy",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the policy is enabled. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *OrganizationTogglePolicy) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// update applies the enabled flag of the model to the policy
+func (r *OrganizationTogglePolicy) update(ctx context.Context, data *OrganizationTogglePolicyModel, diags *diag.Diagnostics) {
+	policy := updateOrganizationPolicy(ctx, r.client, data.OrganizationID.ValueString(), models.Policy{
+		Type:    r.policyType,
+		Enabled: data.Enabled.ValueBool(),
+	}, diags)
+	if policy == nil {
+		return
+	}
+
+	data.ID = types.StringValue(policy.ID)
+	data.Enabled = types.BoolValue(policy.Enabled)
+}
+
+func (r *OrganizationTogglePolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationTogglePolicyModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("configured policy %d of organization with ID: %s", r.policyType, data.OrganizationID))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationTogglePolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationTogglePolicyModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	policy, err := r.client.GetOrganizationPolicy(ctx, data.OrganizationID.ValueString(), r.policyType)
+	if err != nil {
+		// Remove the policy from the state if the organization no longer exists
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("organization with ID %s no longer exists, removing its policy from state", data.OrganizationID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading organization policy",
+			"Could not read policy of organization with ID "+data.OrganizationID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Overwrite the model with the refreshed data
+	data.ID = types.StringValue(policy.ID)
+	data.Enabled = types.BoolValue(policy.Enabled)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationTogglePolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrganizationTogglePolicyModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationTogglePolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationTogglePolicyModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Policies can't be deleted, only disabled
+	data.Enabled = types.BoolValue(false)
+	r.update(ctx, &data, &resp.Diagnostics)
+}
+
+func (r *OrganizationTogglePolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The policy is identified by the organization, as each organization has one policy of each type
+	resource.ImportStatePassthroughID(ctx, path.Root("organization_id"), req, resp)
+}
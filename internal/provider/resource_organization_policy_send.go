@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationPolicySend{}
+var _ resource.ResourceWithConfigure = &OrganizationPolicySend{}
+var _ resource.ResourceWithImportState = &OrganizationPolicySend{}
+
+func OrganizationPolicySendResource() resource.Resource {
+	return &OrganizationPolicySend{}
+}
+
+// OrganizationPolicySend defines the resource implementation.
+type OrganizationPolicySend struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationPolicySendModel describes the resource data model.
+type OrganizationPolicySendModel struct {
+	ID               types.String   `tfsdk:"id"`
+	OrganizationID   types.String   `tfsdk:"organization_id"`
+	DisableSend      types.Bool     `tfsdk:"disable_send"`
+	DisableHideEmail types.Bool     `tfsdk:"disable_hide_email"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// sendOptionsDisableHideEmail is the option of the Send options policy which prevents hiding the email of the sender
+const sendOptionsDisableHideEmail = "disableHideEmail"
+
+func (r *OrganizationPolicySend) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_policy_send"
+}
+
+func (r *OrganizationPolicySend) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages the Send policies of an organization, the disable Send policy and the Send options policy. " +
+			"Owners and admins are exempt from the policies.\n\n" +
+			"Destroying the resource disables both policies.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, which is the ID of the organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"disable_send": schema.BoolAttribute{
+				MarkdownDescription: "Whether the members can't create or edit Sends, they can only delete existing ones. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"disable_hide_email": schema.BoolAttribute{
+				MarkdownDescription: "Whether the members can't hide their email from the recipients of their Sends. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *OrganizationPolicySend) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// update applies the model to the disable Send and Send options policies
+func (r *OrganizationPolicySend) update(ctx context.Context, data *OrganizationPolicySendModel, diags *diag.Diagnostics) {
+	orgID := data.OrganizationID.ValueString()
+
+	disableSend := updateOrganizationPolicy(ctx, r.client, orgID, models.Policy{
+		Type:    models.PolicyTypeDisableSend,
+		Enabled: data.DisableSend.ValueBool(),
+	}, diags)
+	if disableSend == nil {
+		return
+	}
+
+	// The Send options policy only has the option to disable hiding the email, so it is enabled together with it
+	sendOptions := updateOrganizationPolicy(ctx, r.client, orgID, models.Policy{
+		Type:    models.PolicyTypeSendOptions,
+		Enabled: data.DisableHideEmail.ValueBool(),
+		Data: map[string]interface{}{
+			sendOptionsDisableHideEmail: data.DisableHideEmail.ValueBool(),
+		},
+	}, diags)
+	if sendOptions == nil {
+		return
+	}
+
+	data.ID = data.OrganizationID
+	data.DisableSend = types.BoolValue(disableSend.Enabled)
+	data.DisableHideEmail = types.BoolValue(sendOptionsHideEmailDisabled(sendOptions))
+}
+
+// sendOptionsHideEmailDisabled returns whether the Send options policy prevents hiding the email of the sender
+func sendOptionsHideEmailDisabled(policy *models.Policy) bool {
+	disableHideEmail, _ := policy.Data[sendOptionsDisableHideEmail].(bool)
+	return policy.Enabled && disableHideEmail
+}
+
+func (r *OrganizationPolicySend) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationPolicySendModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("configured Send policies of organization with ID: %s", data.OrganizationID))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationPolicySend) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationPolicySendModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := data.OrganizationID.ValueString()
+	disableSend, err := r.client.GetOrganizationPolicy(ctx, orgID, models.PolicyTypeDisableSend)
+	if err != nil {
+		// Remove the policies from the state if the organization no longer exists
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("organization with ID %s no longer exists, removing its Send policies from state", orgID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading organization policy",
+			"Could not read the disable Send policy of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	sendOptions, err := r.client.GetOrganizationPolicy(ctx, orgID, models.PolicyTypeSendOptions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading organization policy",
+			"Could not read the Send options policy of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Overwrite the model with the refreshed data
+	data.ID = data.OrganizationID
+	data.DisableSend = types.BoolValue(disableSend.Enabled)
+	data.DisableHideEmail = types.BoolValue(sendOptionsHideEmailDisabled(sendOptions))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationPolicySend) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrganizationPolicySendModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationPolicySend) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationPolicySendModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Policies can't be deleted, only disabled
+	data.DisableSend = types.BoolValue(false)
+	data.DisableHideEmail = types.BoolValue(false)
+	r.update(ctx, &data, &resp.Diagnostics)
+}
+
+func (r *OrganizationPolicySend) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The policies are identified by the organization, as each organization has one policy of each type
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), req.ID)...)
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccOrganizationPolicyPersonalOwnership(t *testing.T) {
+	testAccOrganizationTogglePolicy(t, "vaultwarden_organization_policy_personal_ownership")
+}
+
+func TestAccOrganizationPolicyRequireSSO(t *testing.T) {
+	testAccOrganizationTogglePolicy(t, "vaultwarden_organization_policy_require_sso")
+}
+
+// testAccOrganizationTogglePolicy tests a resource of a policy which can only be enabled or disabled
+func testAccOrganizationTogglePolicy(t *testing.T, resourceType string) {
+	orgName := gofakeit.Company()
+	resourceName := resourceType + ".test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with the policy enabled by default
+			{
+				Config: testAccOrganizationPolicyConfig(resourceType, orgName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "organization_id", "vaultwarden_organization.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("resource not found in state")
+					}
+					return rs.Primary.Attributes["organization_id"], nil
+				},
+			},
+			// Update testing disabling the policy
+			{
+				Config: testAccOrganizationPolicyConfig(resourceType, orgName, "enabled = false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationPolicyConfig(resourceType, orgName, attributes string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[4]q
+}
+
+resource %[5]q "test" {
+    organization_id = vaultwarden_organization.test.id
+    %[6]s
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, orgName, resourceType, attributes)
+}
+
+func TestAccOrganizationPolicySend(t *testing.T) {
+	orgName := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with only the Send options policy
+			{
+				Config: testAccOrganizationPolicyConfig("vaultwarden_organization_policy_send", orgName, "disable_hide_email = true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vaultwarden_organization_policy_send.test", "id", "vaultwarden_organization.test", "id"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_policy_send.test", "disable_send", "false"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_policy_send.test", "disable_hide_email", "true"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      "vaultwarden_organization_policy_send.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing disabling Send altogether
+			{
+				Config: testAccOrganizationPolicyConfig("vaultwarden_organization_policy_send", orgName, "disable_send = true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_policy_send.test", "disable_send", "true"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_policy_send.test", "disable_hide_email", "false"),
+				),
+			},
+		},
+	})
+}
@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/test"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"regexp"
 	"testing"
 )
 
@@ -24,6 +27,7 @@ func TestAccOrganization(t *testing.T) {
 					resource.TestCheckResourceAttr("vaultwarden_organization.test", "name", name),
 					resource.TestCheckResourceAttr("vaultwarden_organization.test", "billing_email", test.TestEmail),
 					resource.TestCheckResourceAttr("vaultwarden_organization.test", "collection_name", "Default Collection"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "default_collection_id"),
 					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "id"),
 				),
 			},
@@ -44,8 +48,7 @@ func TestAccOrganization(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"collection_name", // Not returned by API
-					"last_updated",    // Computed field
+					"last_updated", // Computed field
 				},
 			},
 			// Delete testing automatically occurs in TestCase
@@ -53,6 +56,175 @@ func TestAccOrganization(t *testing.T) {
 	})
 }
 
+func TestAccOrganizationKeySize(t *testing.T) {
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create an organization with a 4096-bit key pair
+			{
+				Config: testAccOrganizationConfigKeySize(name, 4096),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "name", name),
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "key_size", "4096"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationDefaultCollection(t *testing.T) {
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the organization with a custom default collection name
+			{
+				Config: testAccOrganizationConfigCollectionName(name, "Initial Collection"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "collection_name", "Initial Collection"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "default_collection_id"),
+				),
+			},
+			// Rename the default collection
+			{
+				Config: testAccOrganizationConfigCollectionName(name, "Renamed Collection"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "collection_name", "Renamed Collection"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "default_collection_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationDeletionProtection(t *testing.T) {
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a protected organization
+			{
+				Config: testAccOrganizationConfigDeletionProtection(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "deletion_protection", "true"),
+				),
+			},
+			// Destroying the protected organization must fail
+			{
+				Config:      testAccOrganizationConfigDeletionProtection(name, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("protected from deletion"),
+			},
+			// Disable the protection to allow the organization to be destroyed
+			{
+				Config: testAccOrganizationConfigDeletionProtection(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationSSOIdentifier(t *testing.T) {
+	name := gofakeit.Company()
+	identifier := gofakeit.Username()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the organization with an SSO identifier
+			{
+				Config: testAccOrganizationConfigSSOIdentifier(name, fmt.Sprintf("%q", identifier)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "sso_identifier", identifier),
+					resource.TestCheckResourceAttr("data.vaultwarden_organization.test", "sso_identifier", identifier),
+				),
+			},
+			// Remove the SSO identifier
+			{
+				Config: testAccOrganizationConfigSSOIdentifier(name, "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("vaultwarden_organization.test", "sso_identifier"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationFeatures(t *testing.T) {
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Require a feature that Vaultwarden enables for all organizations
+			{
+				Config: testAccOrganizationConfigFeature(name, "use_api", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization.test", "use_api", "true"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "use_groups"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "use_events"),
+					resource.TestCheckResourceAttrSet("vaultwarden_organization.test", "use_directory"),
+					resource.TestCheckResourceAttrPair("data.vaultwarden_organization.test", "use_api", "vaultwarden_organization.test", "use_api"),
+				),
+			},
+			// Requiring a feature the server doesn't enable fails
+			{
+				Config:      testAccOrganizationConfigFeature(name, "use_directory", true),
+				ExpectError: regexp.MustCompile("Organization feature can't be changed"),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationDisappears(t *testing.T) {
+	name := gofakeit.Company()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Delete the organization outside of Terraform and expect it to be recreated
+			{
+				Config: testAccOrganizationConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckOrganizationDisappears(t, "vaultwarden_organization.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckOrganizationDisappears deletes the organization outside of Terraform
+func testAccCheckOrganizationDisappears(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found in state: %s", resourceName)
+		}
+
+		ctx := context.Background()
+		client, err := test.GetTestClient(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to get test client: %w", err)
+		}
+
+		return client.DeleteOrganization(ctx, rs.Primary.ID)
+	}
+}
+
 // Base configuration
 func testAccOrganizationConfig(name string) string {
 	return fmt.Sprintf(`
@@ -85,3 +257,94 @@ resource "vaultwarden_organization" "test" {
 }
 `, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name)
 }
+
+// Configuration with a custom key size
+func testAccOrganizationConfigKeySize(name string, keySize int) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+  key_size = %[6]d
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name, keySize)
+}
+
+// Configuration with deletion protection
+func testAccOrganizationConfigDeletionProtection(name string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+  deletion_protection = %[6]t
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name, deletionProtection)
+}
+
+// Configuration with a custom default collection name
+func testAccOrganizationConfigCollectionName(name, collectionName string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+  collection_name = %[6]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name, collectionName)
+}
+
+func testAccOrganizationConfigSSOIdentifier(name, ssoIdentifier string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+  sso_identifier = %[6]s
+}
+
+data "vaultwarden_organization" "test" {
+  id = vaultwarden_organization.test.id
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name, ssoIdentifier)
+}
+
+func testAccOrganizationConfigFeature(name, feature string, enabled bool) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+  endpoint = %[1]q
+  email = %[2]q
+  master_password = %[3]q
+  admin_token = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+  name = %[5]q
+  %[6]s = %[7]t
+}
+
+data "vaultwarden_organization" "test" {
+  id = vaultwarden_organization.test.id
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, name, feature, enabled)
+}
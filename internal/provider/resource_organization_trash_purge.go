@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"time"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationTrashPurge{}
+var _ resource.ResourceWithConfigure = &OrganizationTrashPurge{}
+
+func OrganizationTrashPurgeResource() resource.Resource {
+	return &OrganizationTrashPurge{}
+}
+
+// OrganizationTrashPurge defines the resource implementation.
+type OrganizationTrashPurge struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationTrashPurgeModel describes the resource data model.
+type OrganizationTrashPurgeModel struct {
+	ID             types.String   `tfsdk:"id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	OlderThanDays  types.Int64    `tfsdk:"older_than_days"`
+	Triggers       types.Map      `tfsdk:"triggers"`
+	PurgedItemIDs  types.List     `tfsdk:"purged_item_ids"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *OrganizationTrashPurge) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_trash_purge"
+}
+
+func (r *OrganizationTrashPurge) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource permanently deletes the items in the trash of an organization, e.g. to purge them sooner than the `TRASH_AUTO_DELETE_DAYS` setting of the Vaultwarden server does.\n\n" +
+			"The trash is purged when the resource is created, or replaced due to a change of `triggers`. Destroying the resource has no effect on the server.\n\n" +
+			"The admin API of Vaultwarden doesn't allow to run its scheduled jobs on demand, so the other maintenance jobs can't be triggered from Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, which is the ID of the organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization to purge the trash of",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"older_than_days": schema.Int64Attribute{
+				MarkdownDescription: "Only purge the items which have been in the trash for at least this many days. All items are purged if not set",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values which purge the trash again when changed",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"purged_item_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the items which have been purged",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *OrganizationTrashPurge) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationTrashPurge) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationTrashPurgeModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var deletedBefore time.Time
+	if !data.OlderThanDays.IsNull() {
+		deletedBefore = time.Now().AddDate(0, 0, -int(data.OlderThanDays.ValueInt64()))
+	}
+
+	orgID := data.OrganizationID.ValueString()
+	purgedIDs, err := r.client.PurgeOrganizationTrash(ctx, orgID, deletedBefore)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error purging organization trash",
+			"Could not purge the trash of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	data.ID = data.OrganizationID
+	data.PurgedItemIDs, diags = types.ListValueFrom(ctx, types.StringType, purgedIDs)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, fmt.Sprintf("purged %d items from the trash of organization with ID: %s", len(purgedIDs), orgID))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationTrashPurge) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The trash has been purged once, there is nothing to refresh
+}
+
+func (r *OrganizationTrashPurge) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrganizationTrashPurgeModel
+
+	// Only the timeouts can change without replacing the resource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationTrashPurge) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Purged items cannot be restored, the resource is only removed from the state
+}
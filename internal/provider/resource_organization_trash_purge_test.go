@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"os"
+	"testing"
+)
+
+func TestAccOrganizationTrashPurge(t *testing.T) {
+	// The items are created before the test case, which would otherwise only be skipped by it
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client, err := test.GetTestClient(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to get test client: %s", err)
+	}
+
+	// Create the items outside of Terraform, as if they had been created and deleted in the Vaultwarden clients
+	org, err := client.CreateOrganization(ctx, models.Organization{Name: gofakeit.Company(), CollectionName: "Default"})
+	if err != nil {
+		t.Fatalf("failed to create organization: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := client.DeleteOrganization(context.Background(), org.ID); err != nil {
+			t.Logf("failed to delete organization %s: %s", org.ID, err)
+		}
+	})
+
+	var itemIDs []string
+	for range 2 {
+		item, err := client.CreateOrganizationCipher(ctx, org.ID, models.Cipher{
+			Type:       models.CipherTypeSecureNote,
+			Name:       gofakeit.AppName(),
+			SecureNote: &models.SecureNote{},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create item: %s", err)
+		}
+		itemIDs = append(itemIDs, item.ID)
+	}
+	if err := client.TrashOrganizationCipher(ctx, itemIDs[0]); err != nil {
+		t.Fatalf("failed to move item to the trash: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The item has just been deleted, so it is kept when only older items are purged
+			{
+				Config: testAccOrganizationTrashPurgeConfig(org.ID, "older_than_days = 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_trash_purge.test", "id", org.ID),
+					resource.TestCheckResourceAttr("vaultwarden_organization_trash_purge.test", "purged_item_ids.#", "0"),
+				),
+			},
+			// Replacing the resource purges the trash again
+			{
+				Config: testAccOrganizationTrashPurgeConfig(org.ID, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_trash_purge.test", "purged_item_ids.#", "1"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_trash_purge.test", "purged_item_ids.0", itemIDs[0]),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationTrashPurgeConfig(orgID, attributes string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+}
+
+resource "vaultwarden_organization_trash_purge" "test" {
+    organization_id = %[4]q
+    %[5]s
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, orgID, attributes)
+}
@@ -2,8 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -13,16 +17,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
-	"strings"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OrganizationUser{}
 var _ resource.ResourceWithConfigure = &OrganizationUser{}
 var _ resource.ResourceWithImportState = &OrganizationUser{}
+var _ resource.ResourceWithIdentity = &OrganizationUser{}
+var _ resource.ResourceWithValidateConfig = &OrganizationUser{}
 
 func OrganizationUserResource() resource.Resource {
 	return &OrganizationUser{}
@@ -35,12 +42,316 @@ type OrganizationUser struct {
 
 // OrganizationUserModel describes the resource data model.
 type OrganizationUserModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	Email          types.String `tfsdk:"email"`
-	Type           types.String `tfsdk:"type"`
-	AccessAll      types.Bool   `tfsdk:"access_all"`
-	Status         types.String `tfsdk:"status"`
+	ID                  types.String   `tfsdk:"id"`
+	OrganizationID      types.String   `tfsdk:"organization_id"`
+	Email               types.String   `tfsdk:"email"`
+	Type                types.String   `tfsdk:"type"`
+	AccessAll           types.Bool     `tfsdk:"access_all"`
+	Status              types.String   `tfsdk:"status"`
+	Permissions         types.Object   `tfsdk:"permissions"`
+	WaitForStatus       types.String   `tfsdk:"wait_for_status"`
+	TransferOwnershipTo types.String   `tfsdk:"transfer_ownership_to"`
+	RevokeOnDestroy     types.Bool     `tfsdk:"revoke_on_destroy"`
+	RegistrationURL     types.String   `tfsdk:"registration_url"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationUserFieldPaths maps the request fields of an organization user to their attributes to report validation errors
+var organizationUserFieldPaths = map[string]path.Path{
+	"emails":      path.Root("email"),
+	"email":       path.Root("email"),
+	"type":        path.Root("type"),
+	"accessall":   path.Root("access_all"),
+	"permissions": path.Root("permissions"),
+}
+
+// organizationUserStatusPollInterval is the interval to poll the status of a user with wait_for_status
+const organizationUserStatusPollInterval = 5 * time.Second
+
+// OrganizationUserPermissionsModel describes the permissions of a user with the Custom type.
+type OrganizationUserPermissionsModel struct {
+	AccessEventLogs      types.Bool `tfsdk:"access_event_logs"`
+	AccessImportExport   types.Bool `tfsdk:"access_import_export"`
+	AccessReports        types.Bool `tfsdk:"access_reports"`
+	CreateNewCollections types.Bool `tfsdk:"create_new_collections"`
+	EditAnyCollection    types.Bool `tfsdk:"edit_any_collection"`
+	DeleteAnyCollection  types.Bool `tfsdk:"delete_any_collection"`
+	ManageGroups         types.Bool `tfsdk:"manage_groups"`
+	ManagePolicies       types.Bool `tfsdk:"manage_policies"`
+	ManageSso            types.Bool `tfsdk:"manage_sso"`
+	ManageUsers          types.Bool `tfsdk:"manage_users"`
+	ManageResetPassword  types.Bool `tfsdk:"manage_reset_password"`
+	ManageScim           types.Bool `tfsdk:"manage_scim"`
+}
+
+// organizationUserPermissionDescriptions contains the descriptions of the permissions of a user with the Custom type
+var organizationUserPermissionDescriptions = map[string]string{
+	"access_event_logs":      "Whether the user can access the event logs",
+	"access_import_export":   "Whether the user can import and export the organization vault",
+	"access_reports":         "Whether the user can access the reports",
+	"create_new_collections": "Whether the user can create new collections",
+	"edit_any_collection":    "Whether the user can edit any collection",
+	"delete_any_collection":  "Whether the user can delete any collection",
+	"manage_groups":          "Whether the user can manage groups",
+	"manage_policies":        "Whether the user can manage policies",
+	"manage_sso":             "Whether the user can manage single sign-on",
+	"manage_users":           "Whether the user can manage users",
+	"manage_reset_password":  "Whether the user can manage the password reset of users",
+	"manage_scim":            "Whether the user can manage SCIM",
+}
+
+// organizationUserPermissionsAttrTypes returns the attribute types of the permissions object
+func organizationUserPermissionsAttrTypes() map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(organizationUserPermissionDescriptions))
+	for name := range organizationUserPermissionDescriptions {
+		attrTypes[name] = types.BoolType
+	}
+	return attrTypes
+}
+
+// organizationUserPermissionsSchema returns the schema attributes of the permissions object
+func organizationUserPermissionsSchema() map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(organizationUserPermissionDescriptions))
+	for name, description := range organizationUserPermissionDescriptions {
+		attributes[name] = schema.BoolAttribute{
+			MarkdownDescription: description + ". Defaults to `false`",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		}
+	}
+	return attributes
+}
+
+// expandOrganizationUserPermissions converts the permissions object to the API model, returning nil if it is not set
+func expandOrganizationUserPermissions(ctx context.Context, permissions types.Object) (*models.OrganizationUserPermissions, diag.Diagnostics) {
+	if permissions.IsNull() || permissions.IsUnknown() {
+		return nil, nil
+	}
+
+	var data OrganizationUserPermissionsModel
+	diags := permissions.As(ctx, &data, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &models.OrganizationUserPermissions{
+		AccessEventLogs:      data.AccessEventLogs.ValueBool(),
+		AccessImportExport:   data.AccessImportExport.ValueBool(),
+		AccessReports:        data.AccessReports.ValueBool(),
+		CreateNewCollections: data.CreateNewCollections.ValueBool(),
+		EditAnyCollection:    data.EditAnyCollection.ValueBool(),
+		DeleteAnyCollection:  data.DeleteAnyCollection.ValueBool(),
+		ManageGroups:         data.ManageGroups.ValueBool(),
+		ManagePolicies:       data.ManagePolicies.ValueBool(),
+		ManageSso:            data.ManageSso.ValueBool(),
+		ManageUsers:          data.ManageUsers.ValueBool(),
+		ManageResetPassword:  data.ManageResetPassword.ValueBool(),
+		ManageScim:           data.ManageScim.ValueBool(),
+	}, diags
+}
+
+// flattenOrganizationUserPermissions converts the API model to the permissions object, which is null if no permissions are given
+func flattenOrganizationUserPermissions(ctx context.Context, permissions *models.OrganizationUserPermissions) (types.Object, diag.Diagnostics) {
+	if permissions == nil {
+		return types.ObjectNull(organizationUserPermissionsAttrTypes()), nil
+	}
+
+	return types.ObjectValueFrom(ctx, organizationUserPermissionsAttrTypes(), OrganizationUserPermissionsModel{
+		AccessEventLogs:      types.BoolValue(permissions.AccessEventLogs),
+		AccessImportExport:   types.BoolValue(permissions.AccessImportExport),
+		AccessReports:        types.BoolValue(permissions.AccessReports),
+		CreateNewCollections: types.BoolValue(permissions.CreateNewCollections),
+		EditAnyCollection:    types.BoolValue(permissions.EditAnyCollection),
+		DeleteAnyCollection:  types.BoolValue(permissions.DeleteAnyCollection),
+		ManageGroups:         types.BoolValue(permissions.ManageGroups),
+		ManagePolicies:       types.BoolValue(permissions.ManagePolicies),
+		ManageSso:            types.BoolValue(permissions.ManageSso),
+		ManageUsers:          types.BoolValue(permissions.ManageUsers),
+		ManageResetPassword:  types.BoolValue(permissions.ManageResetPassword),
+		ManageScim:           types.BoolValue(permissions.ManageScim),
+	})
+}
+
+// warnDeprecatedOrganizationUserType adds a warning if the role type is deprecated by the server, like the Manager
+// role which the collection permission model replaces
+func warnDeprecatedOrganizationUserType(ctx context.Context, client *vaultwarden.Client, userType models.UserOrgType, diags *diag.Diagnostics) {
+	if userType != models.UserOrgTypeManager {
+		return
+	}
+
+	if err := client.CheckDeprecatedFeature(ctx, vaultwarden.FeatureManagerRole); err != nil {
+		diags.AddAttributeWarning(
+			path.Root("type"),
+			"Deprecated role type",
+			err.Error()+". Use the Custom role with the manage permission of collections instead.",
+		)
+	}
+}
+
+// refreshAccessAll returns whether access_all should be refreshed from the server. Servers with the collection
+// permission model ignore the flag, so the configured value is kept to avoid spurious diffs.
+func (r *OrganizationUser) refreshAccessAll(ctx context.Context) bool {
+	return !r.client.SupportsFeature(ctx, vaultwarden.FeatureCollectionPermissions)
+}
+
+// warnIgnoredAccessAll adds a warning if access_all is enabled for a server with the collection permission model
+func (r *OrganizationUser) warnIgnoredAccessAll(ctx context.Context, data OrganizationUserModel, diags *diag.Diagnostics) {
+	if data.AccessAll.ValueBool() && !r.refreshAccessAll(ctx) {
+		diags.AddAttributeWarning(
+			path.Root("access_all"),
+			"Setting ignored by the Vaultwarden server",
+			"The Vaultwarden server uses the collection permission model, which ignores access_all. "+
+				"Owners and admins can access all collections, other users need to be granted access to collections explicitly, e.g. with vaultwarden_organization_user_collections.",
+		)
+	}
+}
+
+// organizationUserRegistrationURL returns the link to register the account of a user with the given status, if registering
+// it accepts the invitation. That's only the case for invited users without an account on servers without mail delivery,
+// which takes the admin token to tell. Otherwise the invitation is accepted with the token of the invitation email.
+func organizationUserRegistrationURL(ctx context.Context, client *vaultwarden.Client, status, email string) types.String {
+	if status != "Invited" || client.Credentials.AdminToken == "" {
+		return types.StringNull()
+	}
+
+	mailEnabled, err := client.MailEnabled(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Could not tell whether the server delivers mails", map[string]interface{}{"error": err.Error()})
+		return types.StringNull()
+	}
+	if mailEnabled {
+		return types.StringNull()
+	}
+
+	user, err := client.GetUserByEmail(ctx, email)
+	if err != nil {
+		tflog.Debug(ctx, "Could not tell whether the invited user has an account", map[string]interface{}{"error": err.Error()})
+		return types.StringNull()
+	}
+	if user.Key != "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(client.RegistrationURL(email))
+}
+
+// waitForStatus waits until the user has reached at least the status configured in wait_for_status, if any
+func (r *OrganizationUser) waitForStatus(ctx context.Context, data *OrganizationUserModel, diags *diag.Diagnostics) {
+	if data.WaitForStatus.IsNull() {
+		return
+	}
+
+	var status models.UserOrgStatus
+	if err := status.FromString(data.WaitForStatus.ValueString()); err != nil {
+		diags.AddAttributeError(path.Root("wait_for_status"), "Error parsing status", "Could not parse status: "+err.Error())
+		return
+	}
+
+	userResp, err := r.client.WaitForOrganizationUserStatus(ctx, data.ID.ValueString(), data.OrganizationID.ValueString(), status, organizationUserStatusPollInterval)
+	if err != nil {
+		diags.AddError(
+			"Error waiting for organization user status",
+			"Organization user with ID "+data.ID.ValueString()+" did not reach status "+data.WaitForStatus.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	data.Status = types.StringValue(userResp.Status.String())
+}
+
+// releaseOwnership makes sure that the organization keeps a confirmed owner when the user is removed or demoted. If the
+// user is the last owner, the member configured in transfer_ownership_to is made an owner first, as Vaultwarden would
+// otherwise reject the change halfway through a destroy.
+func (r *OrganizationUser) releaseOwnership(ctx context.Context, data OrganizationUserModel, diags *diag.Diagnostics) {
+	orgID := data.OrganizationID.ValueString()
+
+	err := r.client.CheckOrganizationOwnersRemoval(ctx, orgID, []string{data.ID.ValueString()})
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, vaultwarden.ErrLastOrganizationOwner) {
+		diags.AddError(
+			"Error fetching organization owners",
+			"Could not fetch the owners of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	if data.TransferOwnershipTo.IsNull() {
+		diags.AddAttributeError(
+			path.Root("transfer_ownership_to"),
+			"Last organization owner",
+			"User "+data.Email.ValueString()+" is the last confirmed owner of organization with ID "+orgID+", which can't be removed or demoted. "+
+				"Set transfer_ownership_to to the email of a confirmed member to make them an owner first, or add another owner.",
+		)
+		return
+	}
+
+	successor, err := r.client.GetOrganizationUserByEmail(ctx, data.TransferOwnershipTo.ValueString(), orgID)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("transfer_ownership_to"),
+			"Error fetching new organization owner",
+			"Could not fetch organization user "+data.TransferOwnershipTo.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	if err := r.client.TransferOrganizationOwnership(ctx, orgID, data.ID.ValueString(), successor.ID); err != nil {
+		diags.AddAttributeError(
+			path.Root("transfer_ownership_to"),
+			"Error transferring organization ownership",
+			"Could not make "+data.TransferOwnershipTo.ValueString()+" an owner of organization with ID "+orgID+": "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("transferred ownership of organization with ID %s to %s", orgID, data.TransferOwnershipTo.ValueString()))
+}
+
+// restoreRevokedUser restores the access of the user if it is a revoked member of the organization, updating it to the
+// configured role. It returns whether the user has been restored, otherwise it has to be invited.
+func (r *OrganizationUser) restoreRevokedUser(ctx context.Context, data OrganizationUserModel, userType models.UserOrgType, permissions *models.OrganizationUserPermissions, diags *diag.Diagnostics) bool {
+	orgID := data.OrganizationID.ValueString()
+
+	current, err := r.client.GetOrganizationUserByEmail(ctx, data.Email.ValueString(), orgID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			diags.AddError(
+				"Error fetching organization user",
+				"Could not fetch organization user "+data.Email.ValueString()+": "+clientErrorDetail(err),
+			)
+		}
+		return false
+	}
+	if current.Status != models.UserOrgStatusRevoked {
+		return false
+	}
+
+	if err := r.client.RestoreOrganizationUser(ctx, current.ID, orgID); err != nil {
+		diags.AddError(
+			"Error restoring organization user",
+			"Could not restore revoked organization user with ID "+current.ID+": "+clientErrorDetail(err),
+		)
+		return false
+	}
+
+	// Keep the collections of the user, which Vaultwarden replaces on every update
+	user := models.OrganizationUserDetails{
+		Email:       data.Email.ValueString(),
+		Type:        userType,
+		AccessAll:   data.AccessAll.ValueBool(),
+		Permissions: permissions,
+		Collections: current.Collections,
+	}
+	if _, err := r.client.UpdateOrganizationUser(ctx, current.ID, orgID, user); err != nil {
+		addClientError(diags, "Error updating organization user", "Could not update restored organization user with ID "+current.ID+": ", err, organizationUserFieldPaths)
+		return false
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("restored revoked organization user with ID %s", current.ID))
+	return true
 }
 
 func (r *OrganizationUser) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,7 +360,8 @@ func (r *OrganizationUser) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *OrganizationUser) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "This resource invites a user to an organization on the Vaultwarden server.",
+		MarkdownDescription: "This resource invites a user to an organization on the Vaultwarden server. " +
+			"Manage the collections the user has access to with `vaultwarden_organization_user_collections`.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -69,27 +381,61 @@ func (r *OrganizationUser) Schema(ctx context.Context, req resource.SchemaReques
 			"email": schema.StringAttribute{
 				MarkdownDescription: "The email of the user to invite",
 				Required:            true,
+				Validators: []validator.String{
+					validEmail(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "The role type of the user (Owner, Admin, User, Manager). Defaults to `User`",
+				MarkdownDescription: "The role type of the user (Owner, Admin, User, Manager, Custom). The Manager role is deprecated as of Vaultwarden 1.32.0, use the Custom role with the manage permission of collections instead. Defaults to `User`",
 				Computed:            true,
 				Optional:            true,
 				Default:             stringdefault.StaticString("User"),
 				Validators: []validator.String{
-					stringvalidator.OneOf("Owner", "Admin", "User", "Manager"),
+					stringvalidator.OneOf("Owner", "Admin", "User", "Manager", "Custom"),
 				},
 			},
 			"access_all": schema.BoolAttribute{
-				MarkdownDescription: "Whether the user has access to all collections in the organization. Defaults to `false`",
-				Computed:            true,
+				MarkdownDescription: "Whether the user has access to all collections in the organization. " +
+					"Vaultwarden 1.32.0 and newer ignore this setting, owners and admins can access all collections there while other users need explicit collection permissions. Defaults to `false`",
+				Computed:           true,
+				Optional:           true,
+				Default:            booldefault.StaticBool(false),
+				DeprecationMessage: "Vaultwarden 1.32.0 and newer replace access_all with explicit collection permissions. Grant access to collections explicitly instead.",
+			},
+			"permissions": schema.SingleNestedAttribute{
+				MarkdownDescription: "The granular permissions of the user, which can only be set when `type` is `Custom`",
 				Optional:            true,
-				Default:             booldefault.StaticBool(false),
+				Attributes:          organizationUserPermissionsSchema(),
+			},
+			"wait_for_status": schema.StringAttribute{
+				MarkdownDescription: "The status (Accepted, Confirmed) the user must reach before the resource is considered created, for example when invitations are accepted and confirmed by automation. " +
+					"The waiting time is limited by the create and update timeouts",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Accepted", "Confirmed"),
+				},
+			},
+			"transfer_ownership_to": schema.StringAttribute{
+				MarkdownDescription: "The email of a confirmed member to make an owner of the organization before this user is removed or demoted, if this user is its last confirmed owner. " +
+					"Vaultwarden doesn't allow to remove the last owner of an organization, so removing or demoting it fails without this setting",
+				Optional: true,
+				Validators: []validator.String{
+					validEmail(),
+				},
+			},
+			"revoke_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to revoke the access of the user to the organization on destroy instead of removing the user from it. " +
+					"A revoked user stays a member with its role and collections and can be restored, which is safer for the accounts of people. " +
+					"A revoked user with the same email is restored when the resource is created again. Defaults to `false`",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"status": schema.StringAttribute{
-				MarkdownDescription: "The status of the user",
+				MarkdownDescription: "The status of the user, which is refreshed when the user accepts the invitation or is confirmed outside of Terraform",
 				Computed:            true,
 				Validators: []validator.String{
 					stringvalidator.OneOf("Revoked", "Invited", "Accepted", "Confirmed"),
@@ -98,10 +444,30 @@ func (r *OrganizationUser) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"registration_url": schema.StringAttribute{
+				MarkdownDescription: "The link of the web vault to register the account of the invited user, if registering it accepts the invitation. " +
+					"That's only the case on servers without mail delivery, which send no invitation email, for users without an account. " +
+					"It carries no invitation token and is only set with the `admin_token` of the provider, which is needed to tell. " +
+					"Anyone with the link can register the account of the invited email",
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *OrganizationUser) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationResourceIdentitySchema("ID of the user in the organization")
+}
+
 func (r *OrganizationUser) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -122,6 +488,25 @@ func (r *OrganizationUser) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+func (r *OrganizationUser) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data OrganizationUserModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Permissions only apply to users with the Custom type, which is not the default
+	if !data.Permissions.IsNull() && !data.Type.IsUnknown() && data.Type.ValueString() != "Custom" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("permissions"),
+			"Invalid permissions configuration",
+			"The permissions can only be set for users with the Custom type.",
+		)
+	}
+}
+
 func (r *OrganizationUser) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data OrganizationUserModel
 
@@ -132,6 +517,16 @@ func (r *OrganizationUser) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Parse the type string into a UserOrgType (value will always be present due to schema default)
 	var userType models.UserOrgType
 	if err := userType.FromString(data.Type.ValueString()); err != nil {
@@ -141,27 +536,45 @@ func (r *OrganizationUser) Create(ctx context.Context, req resource.CreateReques
 		)
 		return
 	}
+	warnDeprecatedOrganizationUserType(ctx, r.client, userType, &resp.Diagnostics)
 
-	// Call the client method to invite the user
-	inviteReq := vaultwarden.InviteOrganizationUserRequest{
-		Type:      userType,
-		AccessAll: data.AccessAll.ValueBool(),
-	}
+	permissions, diags := expandOrganizationUserPermissions(ctx, data.Permissions)
+	resp.Diagnostics.Append(diags...)
 
-	if err := r.client.InviteOrganizationUser(ctx, inviteReq, data.Email.ValueString(), data.OrganizationID.ValueString()); err != nil {
-		resp.Diagnostics.AddError(
-			"Error inviting user",
-			"Could not invite user, unexpected error: "+err.Error(),
-		)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// A user revoked by a previous destroy is still a member, so its access is restored instead of inviting it again
+	restored := false
+	if data.RevokeOnDestroy.ValueBool() {
+		restored = r.restoreRevokedUser(ctx, data, userType, permissions, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !restored {
+		// Call the client method to invite the user
+		inviteReq := vaultwarden.InviteOrganizationUserRequest{
+			Type:        userType,
+			AccessAll:   data.AccessAll.ValueBool(),
+			Permissions: permissions,
+		}
+
+		if err := r.client.InviteOrganizationUser(ctx, inviteReq, data.Email.ValueString(), data.OrganizationID.ValueString()); err != nil {
+			addClientError(&resp.Diagnostics, "Error inviting user", "Could not invite user, unexpected error: ", err, organizationUserFieldPaths)
+			return
+		}
+	}
+
 	// Fetch the invited user by email
 	userResp, err := r.client.GetOrganizationUserByEmail(ctx, data.Email.ValueString(), data.OrganizationID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error fetching registered user",
-			"Could not fetch registered user, unexpected error: "+err.Error(),
+			"Could not fetch registered user, unexpected error: "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -169,15 +582,24 @@ func (r *OrganizationUser) Create(ctx context.Context, req resource.CreateReques
 	// Map response body to schema and populate Computed attribute values
 	data.ID = types.StringValue(userResp.ID)
 	data.Status = types.StringValue(userResp.Status.String())
-	data.AccessAll = types.BoolValue(userResp.AccessAll)
 	data.Type = types.StringValue(userResp.Type.String())
+	if r.refreshAccessAll(ctx) {
+		data.AccessAll = types.BoolValue(userResp.AccessAll)
+	}
+	r.warnIgnoredAccessAll(ctx, data, &resp.Diagnostics)
+
+	// Wait for the user to accept the invitation or be confirmed if requested, the user is saved in any case to be
+	// tainted instead of orphaned if it fails
+	r.waitForStatus(ctx, &data, &resp.Diagnostics)
+	data.RegistrationURL = organizationUserRegistrationURL(ctx, r.client, data.Status.ValueString(), data.Email.ValueString())
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, fmt.Sprintf("created a new user_invite with ID: %s", data.ID))
+	tflog.Trace(ctx, fmt.Sprintf("invited a new organization user with ID: %s", data.ID))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
 }
 
 func (r *OrganizationUser) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -190,12 +612,29 @@ func (r *OrganizationUser) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed data from the client
 	userResp, err := r.client.GetOrganizationUser(ctx, data.ID.ValueString(), data.OrganizationID.ValueString())
 	if err != nil {
+		// Remove the user from the state if it is no longer a member of the organization
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("organization user with ID %s no longer exists, removing it from state", data.ID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error fetching organization user",
-			"Could not fetch organization user, unexpected error: "+err.Error(),
+			"Could not fetch organization user, unexpected error: "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -203,11 +642,25 @@ func (r *OrganizationUser) Read(ctx context.Context, req resource.ReadRequest, r
 	// Overwrite the model with the refreshed data
 	data.Email = types.StringValue(userResp.Email)
 	data.Status = types.StringValue(userResp.Status.String())
-	data.AccessAll = types.BoolValue(userResp.AccessAll)
 	data.Type = types.StringValue(userResp.Type.String())
+	data.RegistrationURL = organizationUserRegistrationURL(ctx, r.client, userResp.Status.String(), userResp.Email)
+	if r.refreshAccessAll(ctx) {
+		data.AccessAll = types.BoolValue(userResp.AccessAll)
+	}
+
+	// Only refresh the permissions when they are managed, the server returns them for every user
+	if !data.Permissions.IsNull() {
+		data.Permissions, diags = flattenOrganizationUserPermissions(ctx, userResp.Permissions)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
 }
 
 func (r *OrganizationUser) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -220,6 +673,16 @@ func (r *OrganizationUser) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Parse the type string into a UserOrgType (value will always be present due to schema default)
 	var userType models.UserOrgType
 	if err := userType.FromString(data.Type.ValueString()); err != nil {
@@ -229,24 +692,56 @@ func (r *OrganizationUser) Update(ctx context.Context, req resource.UpdateReques
 		)
 		return
 	}
+	warnDeprecatedOrganizationUserType(ctx, r.client, userType, &resp.Diagnostics)
+
+	permissions, diags := expandOrganizationUserPermissions(ctx, data.Permissions)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update the user if needed
 	user := models.OrganizationUserDetails{
-		Email:     data.Email.ValueString(),
-		Type:      userType,
-		AccessAll: data.AccessAll.ValueBool(),
+		Email:       data.Email.ValueString(),
+		Type:        userType,
+		AccessAll:   data.AccessAll.ValueBool(),
+		Permissions: permissions,
 	}
 
-	if _, err := r.client.UpdateOrganizationUser(ctx, data.ID.ValueString(), data.OrganizationID.ValueString(), user); err != nil {
+	// Keep the collections of the user, which Vaultwarden replaces on every update
+	current, err := r.client.GetOrganizationUser(ctx, data.ID.ValueString(), data.OrganizationID.ValueString())
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error updating organization user",
-			"Could not update organization user with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Error fetching organization user",
+			"Could not fetch organization user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
+	user.Collections = current.Collections
+
+	// Hand over the organization before its last owner is demoted
+	if current.Type == models.UserOrgTypeOwner && userType != models.UserOrgTypeOwner {
+		r.releaseOwnership(ctx, data, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if _, err := r.client.UpdateOrganizationUser(ctx, data.ID.ValueString(), data.OrganizationID.ValueString(), user); err != nil {
+		addClientError(&resp.Diagnostics, "Error updating organization user", "Could not update organization user with ID "+data.ID.ValueString()+": ", err, organizationUserFieldPaths)
+		return
+	}
+	r.warnIgnoredAccessAll(ctx, data, &resp.Diagnostics)
+
+	// Wait for the user to reach the requested status, which might have been raised
+	r.waitForStatus(ctx, &data, &resp.Diagnostics)
+	data.RegistrationURL = organizationUserRegistrationURL(ctx, r.client, data.Status.ValueString(), data.Email.ValueString())
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
 }
 
 func (r *OrganizationUser) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -259,29 +754,57 @@ func (r *OrganizationUser) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Hand over the organization before its last owner is removed or revoked
+	if data.Type.ValueString() == "Owner" {
+		r.releaseOwnership(ctx, data, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Revoke the user instead of removing it if requested, keeping it a member of the organization
+	if data.RevokeOnDestroy.ValueBool() {
+		if err := r.client.RevokeOrganizationUser(ctx, data.ID.ValueString(), data.OrganizationID.ValueString()); err != nil && !isNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Error revoking organization user",
+				"Could not revoke organization user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
+			)
+		}
+		return
+	}
+
 	// Delete the user
 	if err := r.client.DeleteOrganizationUser(ctx, data.ID.ValueString(), data.OrganizationID.ValueString()); err != nil {
+		// The user has already been removed, e.g. together with its account
+		if isNotFoundError(err) {
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error deleting organization user",
-			"Could not delete organization user with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Could not delete organization user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
 }
 
 func (r *OrganizationUser) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, "/")
-	if len(idParts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid ID format",
-			"Expected import identifier with format: organization_id/user_id",
-		)
+	organizationID, userID, ok := organizationResourceImportIDs(ctx, req, "organization_id/user_id", &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
-	organizationID := idParts[0]
-	userID := idParts[1]
-
 	// Set the organization_id and id attributes
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userID)...)
@@ -291,7 +814,7 @@ func (r *OrganizationUser) ImportState(ctx context.Context, req resource.ImportS
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error fetching organization user",
-			"Could not fetch organization user, unexpected error: "+err.Error(),
+			"Could not fetch organization user, unexpected error: "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -301,4 +824,13 @@ func (r *OrganizationUser) ImportState(ctx context.Context, req resource.ImportS
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), userResp.Type.String())...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("access_all"), userResp.AccessAll)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status"), userResp.Status.String())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("revoke_on_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("registration_url"), organizationUserRegistrationURL(ctx, r.client, userResp.Status.String(), userResp.Email))...)
+
+	// Import the permissions of users with the Custom type
+	if userResp.Type == models.UserOrgTypeCustom {
+		permissions, diags := flattenOrganizationUserPermissions(ctx, userResp.Permissions)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("permissions"), permissions)...)
+	}
 }
@@ -0,0 +1,343 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationUserCollections{}
+var _ resource.ResourceWithConfigure = &OrganizationUserCollections{}
+var _ resource.ResourceWithImportState = &OrganizationUserCollections{}
+var _ resource.ResourceWithIdentity = &OrganizationUserCollections{}
+
+func OrganizationUserCollectionsResource() resource.Resource {
+	return &OrganizationUserCollections{}
+}
+
+// OrganizationUserCollections defines the resource implementation.
+type OrganizationUserCollections struct {
+	client *vaultwarden.Client
+}
+
+// OrganizationUserCollectionsModel describes the resource data model.
+type OrganizationUserCollectionsModel struct {
+	ID             types.String   `tfsdk:"id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	UserID         types.String   `tfsdk:"user_id"`
+	Collections    types.Set      `tfsdk:"collections"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationUserCollectionsFieldPaths maps the request fields of organization users to their attributes to report validation errors
+var organizationUserCollectionsFieldPaths = map[string]path.Path{
+	"collections": path.Root("collections"),
+}
+
+// flattenOrganizationUserCollections converts the collection access of a user to collection access objects
+func flattenOrganizationUserCollections(ctx context.Context, collections []models.CollectionAccess) (types.Set, diag.Diagnostics) {
+	data := make([]OrganizationMembersCollectionModel, 0, len(collections))
+	for _, collection := range collections {
+		data = append(data, OrganizationMembersCollectionModel{
+			ID:            types.StringValue(collection.ID),
+			ReadOnly:      types.BoolValue(collection.ReadOnly),
+			HidePasswords: types.BoolValue(collection.HidePasswords),
+			Manage:        types.BoolValue(collection.Manage),
+		})
+	}
+
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: organizationMembersCollectionAttrTypes}, data)
+}
+
+func (r *OrganizationUserCollections) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_user_collections"
+}
+
+func (r *OrganizationUserCollections) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages the collections a user in an organization has access to on the Vaultwarden server.\n\n" +
+			"The collection access is authoritative: access to any collection not listed is revoked. " +
+			"The membership of the user is not managed, invite the user with `vaultwarden_organization_user` instead. " +
+			"Destroying the resource revokes the access to all collections.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, which is the ID of the user in the organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user in the organization",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"collections": schema.SetNestedAttribute{
+				MarkdownDescription: "The collections the user has access to",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the collection",
+							Required:            true,
+						},
+						"read_only": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user can only read the items of the collection. Defaults to `false`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"hide_passwords": schema.BoolAttribute{
+							MarkdownDescription: "Whether the passwords of the items of the collection are hidden from the user. Defaults to `false`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"manage": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user can manage the collection, e.g. its name and access, without being an admin. Requires Vaultwarden 1.32.0 or newer. Defaults to `false`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *OrganizationUserCollections) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = organizationResourceIdentitySchema("ID of the user in the organization")
+}
+
+func (r *OrganizationUserCollections) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vaultwarden.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vaultwarden.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// update replaces the collection access of the user with the collections of the model
+func (r *OrganizationUserCollections) update(ctx context.Context, data *OrganizationUserCollectionsModel, diags *diag.Diagnostics) {
+	collections, collectionDiags := expandOrganizationMembersCollections(ctx, data.Collections)
+	diags.Append(collectionDiags...)
+	requireCollectionManage(ctx, r.client, collections, diags)
+
+	if diags.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	if _, err := r.client.UpdateOrganizationUserCollections(ctx, userID, data.OrganizationID.ValueString(), collections); err != nil {
+		addClientError(diags, "Error updating organization user collections", "Could not update the collections of organization user with ID "+userID+": ", err, organizationUserCollectionsFieldPaths)
+		return
+	}
+
+	data.ID = data.UserID
+}
+
+func (r *OrganizationUserCollections) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationUserCollectionsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, fmt.Sprintf("granted organization user with ID %s access to collections", data.UserID.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
+}
+
+func (r *OrganizationUserCollections) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationUserCollectionsModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get refreshed data from the client
+	userResp, err := r.client.GetOrganizationUser(ctx, data.UserID.ValueString(), data.OrganizationID.ValueString())
+	if err != nil {
+		// Remove the collection access from the state if the user is no longer a member of the organization
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("organization user with ID %s no longer exists, removing it from state", data.UserID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error fetching organization user",
+			"Could not fetch organization user, unexpected error: "+clientErrorDetail(err),
+		)
+		return
+	}
+
+	// Overwrite the model with the refreshed data
+	data.ID = data.UserID
+	data.Collections, diags = flattenOrganizationUserCollections(ctx, userResp.Collections)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
+}
+
+func (r *OrganizationUserCollections) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrganizationUserCollectionsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.update(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, OrganizationResourceIdentityModel{OrganizationID: data.OrganizationID, ID: data.ID})...)
+}
+
+func (r *OrganizationUserCollections) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationUserCollectionsModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Revoke the access to all collections, the membership itself is not managed by this resource
+	if _, err := r.client.UpdateOrganizationUserCollections(ctx, data.UserID.ValueString(), data.OrganizationID.ValueString(), nil); err != nil {
+		// The user is no longer a member of the organization
+		if isNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error revoking organization user collections",
+			"Could not revoke the collections of organization user with ID "+data.UserID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+}
+
+func (r *OrganizationUserCollections) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organizationID, userID, ok := organizationResourceImportIDs(ctx, req, "organization_id/user_id", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set the identifying attributes, the collections are refreshed by the subsequent read
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), organizationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+}
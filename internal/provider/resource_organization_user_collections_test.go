@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"testing"
+)
+
+func TestAccOrganizationUserCollections(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with access to both collections
+			{
+				Config: testAccOrganizationUserCollectionsConfig(orgName, email, "User", `
+    collections = [
+        {
+            id = vaultwarden_organization_collection.first.id
+        },
+        {
+            id        = vaultwarden_organization_collection.second.id
+            read_only = true
+        },
+    ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vaultwarden_organization_user_collections.test", "id", "vaultwarden_organization_user.test", "id"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("vaultwarden_organization_user_collections.test", "collections.*", map[string]string{
+						"read_only":      "true",
+						"hide_passwords": "false",
+					}),
+				),
+			},
+			// Changing the role of the user keeps the collection access
+			{
+				Config: testAccOrganizationUserCollectionsConfig(orgName, email, "Manager", `
+    collections = [
+        {
+            id = vaultwarden_organization_collection.first.id
+        },
+        {
+            id        = vaultwarden_organization_collection.second.id
+            read_only = true
+        },
+    ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "type", "Manager"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.#", "2"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      "vaultwarden_organization_user_collections.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccOrganizationUserCollectionsImportStateIdFunc(),
+			},
+			// Update testing revoking the access to a collection
+			{
+				Config: testAccOrganizationUserCollectionsConfig(orgName, email, "Manager", `
+    collections = [
+        {
+            id             = vaultwarden_organization_collection.first.id
+            hide_passwords = true
+        },
+    ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.#", "1"),
+					resource.TestCheckResourceAttrPair("vaultwarden_organization_user_collections.test", "collections.0.id", "vaultwarden_organization_collection.first", "id"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.0.hide_passwords", "true"),
+				),
+			},
+			// Update testing granting the manage permission
+			{
+				Config: testAccOrganizationUserCollectionsConfig(orgName, email, "User", `
+    collections = [
+        {
+            id     = vaultwarden_organization_collection.first.id
+            manage = true
+        },
+    ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.#", "1"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.0.manage", "true"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user_collections.test", "collections.0.read_only", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationUserCollectionsImportStateIdFunc() resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources["vaultwarden_organization_user_collections.test"]
+		if !ok {
+			return "", fmt.Errorf("resource not found in state")
+		}
+
+		return fmt.Sprintf("%s/%s",
+			rs.Primary.Attributes["organization_id"],
+			rs.Primary.Attributes["user_id"]), nil
+	}
+}
+
+func testAccOrganizationUserCollectionsConfig(orgName, email, userType, collections string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_collection" "first" {
+    organization_id = vaultwarden_organization.test.id
+    name            = "First"
+}
+
+resource "vaultwarden_organization_collection" "second" {
+    organization_id = vaultwarden_organization.test.id
+    name            = "Second"
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id = vaultwarden_organization.test.id
+    email           = %[6]q
+    type            = %[7]q
+}
+
+resource "vaultwarden_organization_user_collections" "test" {
+    organization_id = vaultwarden_organization.test.id
+    user_id         = vaultwarden_organization_user.test.id
+%[8]s
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email, userType, collections)
+}
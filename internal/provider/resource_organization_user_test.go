@@ -1,11 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/test"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/url"
+	"os"
 	"regexp"
 	"testing"
 )
@@ -29,6 +36,7 @@ func TestAccOrganizationUser(t *testing.T) {
 					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "type", "User"),
 					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "access_all", "false"),
 					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "status", "Invited"),
+					resource.TestMatchResourceAttr("vaultwarden_organization_user.test", "registration_url", regexp.MustCompile(`/#/register\?email=`+regexp.QuoteMeta(url.QueryEscape(email))+`$`)),
 					resource.TestCheckResourceAttrSet("vaultwarden_organization_user.test", "id"),
 					resource.TestCheckResourceAttrSet("vaultwarden_organization_user.test", "organization_id"),
 				),
@@ -78,6 +86,269 @@ func TestAccOrganizationUserInvalidType(t *testing.T) {
 	})
 }
 
+func TestAccOrganizationUserPermissions(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a user with the Custom type and granular permissions
+			{
+				Config: testAccOrganizationUserConfigPermissions(orgName, email, "Custom"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "type", "Custom"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "permissions.manage_users", "true"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "permissions.access_reports", "true"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "permissions.manage_policies", "false"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      "vaultwarden_organization_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccOrganizationUserImportStateIdFunc(),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationUserPermissionsInvalidType(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOrganizationUserConfigPermissions(orgName, email, "User"),
+				ExpectError: regexp.MustCompile(`Invalid permissions configuration`),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationUserOwner(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOrganizationUserConfigOwner(orgName, email, "Owner", "not-an-email"),
+				ExpectError: regexp.MustCompile(`Invalid email address`),
+			},
+			// The creator of the organization remains its owner, so further owners can be demoted and removed
+			{
+				Config: testAccOrganizationUserConfigOwner(orgName, email, "Owner", test.TestEmail),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "type", "Owner"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "transfer_ownership_to", test.TestEmail),
+				),
+			},
+			{
+				Config: testAccOrganizationUserConfigOwner(orgName, email, "User", test.TestEmail),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "type", "User"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationUserWaitForStatusTimeout(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Nobody accepts the invitation, so waiting for it must time out
+			{
+				Config:      testAccOrganizationUserConfigWaitForStatus(orgName, email, "Accepted"),
+				ExpectError: regexp.MustCompile(`did not reach status Accepted`),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationUserIdentity(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			// Create testing with the identity matching the state
+			{
+				Config: testAccOrganizationUserConfigBasic(orgName, email),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("vaultwarden_organization_user.test", tfjsonpath.New("organization_id")),
+					statecheck.ExpectIdentityValueMatchesState("vaultwarden_organization_user.test", tfjsonpath.New("id")),
+				},
+			},
+			// Import testing with an import block by identity
+			{
+				ResourceName:    "vaultwarden_organization_user.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccOrganizationUserDisappears(t *testing.T) {
+	orgName := gofakeit.Company()
+	email := gofakeit.Email()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Remove the user from the organization outside of Terraform and expect it to be invited again
+			{
+				Config: testAccOrganizationUserConfigBasic(orgName, email),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckOrganizationUserDisappears(t, "vaultwarden_organization_user.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccOrganizationUserRevokeOnDestroy(t *testing.T) {
+	// The organization is created before the test case to outlive the user, which would otherwise only be skipped by it
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client, err := test.GetTestClient(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to get test client: %s", err)
+	}
+
+	org, err := client.CreateOrganization(ctx, models.Organization{Name: gofakeit.Company(), CollectionName: "Default"})
+	if err != nil {
+		t.Fatalf("failed to create organization: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := client.DeleteOrganization(context.Background(), org.ID); err != nil {
+			t.Logf("failed to delete organization %s: %s", org.ID, err)
+		}
+	})
+
+	email := gofakeit.Email()
+	var userID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the user to be revoked on destroy
+			{
+				Config: testAccOrganizationUserConfigRevokeOnDestroy(org.ID, email, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "revoke_on_destroy", "true"),
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "status", "Invited"),
+					func(s *terraform.State) error {
+						userID = s.RootModule().Resources["vaultwarden_organization_user.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			// Destroy the user, which stays a revoked member of the organization
+			{
+				Config: testAccOrganizationUserConfigRevokeOnDestroy(org.ID, email, false),
+				Check: func(s *terraform.State) error {
+					user, err := client.GetOrganizationUser(ctx, userID, org.ID)
+					if err != nil {
+						return fmt.Errorf("failed to get revoked organization user: %w", err)
+					}
+					if user.Status != models.UserOrgStatusRevoked {
+						return fmt.Errorf("expected status Revoked, got %s", user.Status.String())
+					}
+					return nil
+				},
+			},
+			// Create the user again, which restores the revoked member
+			{
+				Config: testAccOrganizationUserConfigRevokeOnDestroy(org.ID, email, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "status", "Invited"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["vaultwarden_organization_user.test"].Primary.ID; id != userID {
+							return fmt.Errorf("expected the revoked user %s to be restored, got %s", userID, id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganizationUserRemovedWithAccount(t *testing.T) {
+	orgName := gofakeit.Company()
+	name := gofakeit.Name()
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, false, 12) // min 12 chars
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the user and the account registered for it
+			{
+				Config: testAccOrganizationUserConfigRemovedWithAccount(orgName, name, email, password, false, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vaultwarden_organization_user.test", "id"),
+					resource.TestCheckResourceAttrSet("vaultwarden_account_register.test", "id"),
+				),
+			},
+			// Destroy the account first, which removes the user before it is destroyed
+			{
+				Config: testAccOrganizationUserConfigRemovedWithAccount(orgName, name, email, password, false, false),
+			},
+			// Create them again, the user is revoked on the destroy of the test case after the account has removed it
+			{
+				Config: testAccOrganizationUserConfigRemovedWithAccount(orgName, name, email, password, true, true),
+				Check:  resource.TestCheckResourceAttr("vaultwarden_organization_user.test", "revoke_on_destroy", "true"),
+			},
+		},
+	})
+}
+
+// testAccCheckOrganizationUserDisappears removes the user from the organization outside of Terraform
+func testAccCheckOrganizationUserDisappears(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found in state: %s", resourceName)
+		}
+
+		ctx := context.Background()
+		client, err := test.GetTestClient(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to get test client: %w", err)
+		}
+
+		return client.DeleteOrganizationUser(ctx, rs.Primary.ID, rs.Primary.Attributes["organization_id"])
+	}
+}
+
 // Basic configuration with default values
 func testAccOrganizationUserConfigBasic(orgName, email string) string {
 	return fmt.Sprintf(`
@@ -122,6 +393,141 @@ resource "vaultwarden_organization_user" "test" {
 `, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email, userType, accessAll)
 }
 
+func testAccOrganizationUserConfigOwner(orgName, email, userType, transferOwnershipTo string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id       = vaultwarden_organization.test.id
+    email                 = %[6]q
+    type                  = %[7]q
+    transfer_ownership_to = %[8]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email, userType, transferOwnershipTo)
+}
+
+// Configuration with granular permissions
+func testAccOrganizationUserConfigPermissions(orgName, email, userType string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id = vaultwarden_organization.test.id
+    email          = %[6]q
+    type           = %[7]q
+
+    permissions = {
+        manage_users   = true
+        access_reports = true
+    }
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email, userType)
+}
+
+// Configuration waiting for a status with a short timeout
+func testAccOrganizationUserConfigWaitForStatus(orgName, email, status string) string {
+	return fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+
+resource "vaultwarden_organization_user" "test" {
+    organization_id = vaultwarden_organization.test.id
+    email           = %[6]q
+    wait_for_status = %[7]q
+
+    timeouts {
+        create = "10s"
+    }
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName, email, status)
+}
+
+// Configuration of a user revoked on destroy in an existing organization, the user is left out to destroy it
+func testAccOrganizationUserConfigRevokeOnDestroy(orgID, email string, withUser bool) string {
+	config := fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken)
+
+	if !withUser {
+		return config
+	}
+
+	return config + fmt.Sprintf(`
+resource "vaultwarden_organization_user" "test" {
+    organization_id   = %[1]q
+    email             = %[2]q
+    revoke_on_destroy = true
+}
+`, orgID, email)
+}
+
+// Configuration of a user whose account is destroyed before it, the user and the account are left out to destroy them
+func testAccOrganizationUserConfigRemovedWithAccount(orgName, name, email, password string, revokeOnDestroy, withUser bool) string {
+	config := fmt.Sprintf(`
+provider "vaultwarden" {
+    endpoint        = %[1]q
+    email           = %[2]q
+    master_password = %[3]q
+    admin_token     = %[4]q
+}
+
+resource "vaultwarden_organization" "test" {
+    name = %[5]q
+}
+`, test.TestBaseURL, test.TestEmail, test.TestPassword, test.TestAdminToken, orgName)
+
+	if !withUser {
+		return config
+	}
+
+	return config + fmt.Sprintf(`
+resource "vaultwarden_organization_user" "test" {
+    organization_id   = vaultwarden_organization.test.id
+    email             = %[2]q
+    revoke_on_destroy = %[4]t
+}
+
+resource "vaultwarden_account_register" "test" {
+    name     = %[1]q
+    email    = %[2]q
+    password = %[3]q
+
+    depends_on = [vaultwarden_organization_user.test]
+}
+`, name, email, password, revokeOnDestroy)
+}
+
 // Import state function
 func testAccOrganizationUserImportStateIdFunc() resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
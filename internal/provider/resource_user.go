@@ -3,15 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -30,8 +32,25 @@ type User struct {
 
 // UserModel describes the resource data model.
 type UserModel struct {
-	Email types.String `tfsdk:"email"`
-	ID    types.String `tfsdk:"id"`
+	Email           types.String   `tfsdk:"email"`
+	ID              types.String   `tfsdk:"id"`
+	Name            types.String   `tfsdk:"name"`
+	RegistrationURL types.String   `tfsdk:"registration_url"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// registrationURL returns the link to register the account of the user, or null once the user has registered it
+func (r *User) registrationURL(user *models.User) types.String {
+	if user.Key != "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(r.client.RegistrationURL(user.Email))
+}
+
+// userFieldPaths maps the request fields of a user to their attributes to report validation errors
+var userFieldPaths = map[string]path.Path{
+	"email": path.Root("email"),
 }
 
 func (r *User) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -40,7 +59,7 @@ func (r *User) Metadata(ctx context.Context, req resource.MetadataRequest, resp
 
 func (r *User) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "This resource invites a user to the Vaultwarden server.\n\nRequires `admin_token` to be set in the provider configuration.",
+		MarkdownDescription: "This resource invites a user to the Vaultwarden server through the admin API. The invited user registers the account and chooses the master password on their own.\n\nUse `vaultwarden_account_register` instead to create an account with a known master password.\n\nRequires `admin_token` to be set in the provider configuration.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -50,9 +69,35 @@ func (r *User) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				},
 			},
 			"email": schema.StringAttribute{
-				MarkdownDescription: "The email of the user to invite",
+				MarkdownDescription: "The email of the user to invite. Changing this forces a new resource to be created",
 				Required:            true,
+				Validators: []validator.String{
+					validEmail(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the user, which the user chooses when registering the account. The admin API of Vaultwarden doesn't allow to set it",
+				Computed:            true,
 			},
+			"registration_url": schema.StringAttribute{
+				MarkdownDescription: "The link of the web vault to register the account, as long as the user hasn't registered it. " +
+					"It is not the link of the invitation email and carries no invitation token, but Vaultwarden lets invited emails register without it. " +
+					"Without mail delivery configured on the server, no invitation email is sent and the link can be shared with the user out of band instead. " +
+					"Anyone with the link can register the account of the invited email",
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -87,6 +132,16 @@ func (r *User) Create(ctx context.Context, req resource.CreateRequest, resp *res
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Call the client method to invite the user
 	user := models.User{
 		Email: data.Email.ValueString(),
@@ -94,19 +149,18 @@ func (r *User) Create(ctx context.Context, req resource.CreateRequest, resp *res
 
 	userResp, err := r.client.InviteUser(ctx, user)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error inviting user",
-			"Could not invite user, unexpected error: "+err.Error(),
-		)
+		addClientError(&resp.Diagnostics, "Error inviting user", "Could not invite user, unexpected error: ", err, userFieldPaths)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	data.ID = types.StringValue(userResp.ID)
+	data.Name = types.StringValue(userResp.Name)
+	data.RegistrationURL = r.registrationURL(userResp)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, fmt.Sprintf("created a new user_invite with ID: %s", data.ID))
+	tflog.Trace(ctx, fmt.Sprintf("created a new user with ID: %s", data.ID))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -122,18 +176,30 @@ func (r *User) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed data from the client
 	userResp, err := r.client.GetUser(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Vaultwarden user",
-			"Could not read user with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Could not read user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
 
 	// Overwrite the model with the refreshed data
 	data.Email = types.StringValue(userResp.Email)
+	data.Name = types.StringValue(userResp.Name)
+	data.RegistrationURL = r.registrationURL(userResp)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -149,6 +215,28 @@ func (r *User) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Changing the email replaces the user and the name can't be set, so only the computed attributes are refreshed
+	userResp, err := r.client.GetUser(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Vaultwarden user",
+			"Could not read user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
+		)
+		return
+	}
+	data.Name = types.StringValue(userResp.Name)
+	data.RegistrationURL = r.registrationURL(userResp)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -163,11 +251,21 @@ func (r *User) Delete(ctx context.Context, req resource.DeleteRequest, resp *res
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the user
 	if err := r.client.DeleteUser(ctx, data.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Vaultwarden user",
-			"Could not delete user with ID "+data.ID.ValueString()+": "+err.Error(),
+			"Could not delete user with ID "+data.ID.ValueString()+": "+clientErrorDetail(err),
 		)
 		return
 	}
@@ -2,7 +2,9 @@ package provider
 
 import (
 	"fmt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/test"
+	"github.com/ottramst/terraform-provider-vaultwarden/internal/test"
+	"net/url"
+	"regexp"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -22,6 +24,8 @@ func TestAccUser(t *testing.T) {
 				Config: testAccExampleResourceConfig(email),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("vaultwarden_user.test", "email", email),
+					resource.TestCheckResourceAttrSet("vaultwarden_user.test", "name"),
+					resource.TestMatchResourceAttr("vaultwarden_user.test", "registration_url", regexp.MustCompile(`/#/register\?email=`+regexp.QuoteMeta(url.QueryEscape(email))+`$`)),
 				),
 			},
 			// ImportState testing
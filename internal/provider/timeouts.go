@@ -0,0 +1,6 @@
+package provider
+
+import "time"
+
+// defaultTimeout is the duration of resource operations that have no timeout configured in the timeouts block
+const defaultTimeout = 20 * time.Minute
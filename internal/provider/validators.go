@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"net/mail"
+)
+
+var _ validator.String = emailValidator{}
+
+// emailValidator validates that a string attribute is a bare email address
+type emailValidator struct{}
+
+func (v emailValidator) Description(_ context.Context) string {
+	return "value must be a valid email address"
+}
+
+func (v emailValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emailValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	// Reject addresses with display names, such as "John <john@example.com>", which the server doesn't accept
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid email address",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// validEmail returns a validator which ensures that a string attribute is a valid email address
+func validEmail() validator.String {
+	return emailValidator{}
+}
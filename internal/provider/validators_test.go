@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"testing"
+)
+
+func TestEmailValidator(t *testing.T) {
+	tests := []struct {
+		value   types.String
+		wantErr bool
+	}{
+		{value: types.StringValue("user@example.com")},
+		{value: types.StringValue("first.last+tag@sub.example.com")},
+		{value: types.StringNull()},
+		{value: types.StringUnknown()},
+		{value: types.StringValue(""), wantErr: true},
+		{value: types.StringValue("user"), wantErr: true},
+		{value: types.StringValue("user@"), wantErr: true},
+		{value: types.StringValue("John <user@example.com>"), wantErr: true},
+		{value: types.StringValue(" user@example.com"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("email"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			validEmail().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("unexpected diagnostics for %s: %v", tt.value, resp.Diagnostics)
+			}
+		})
+	}
+}
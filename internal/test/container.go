@@ -0,0 +1,99 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/recorder"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	// DefaultImage is the Vaultwarden image started for acceptance tests unless VW_TEST_IMAGE is set
+	DefaultImage = "vaultwarden/server:1.32.4-alpine"
+
+	// containerPort is the port Vaultwarden listens on inside the container
+	containerPort = "80/tcp"
+
+	// containerStartupTimeout is how long to wait for the container to become healthy, including
+	// pulling the image
+	containerStartupTimeout = 5 * time.Minute
+)
+
+// Main runs the tests of a package. Acceptance tests are run against a Vaultwarden container started
+// for the test run, unless VW_TEST_URL points to a running server or the tests are replayed from
+// recorded cassettes. The container requires a Docker compatible runtime.
+func Main(m *testing.M) int {
+	if os.Getenv("TF_ACC") == "" || TestBaseURL != "" {
+		return m.Run()
+	}
+	if mode, _ := recorder.ModeFromEnv(); mode == recorder.ModeReplay {
+		return m.Run()
+	}
+
+	ctx := context.Background()
+	url, terminate, err := StartContainer(ctx, GetTestImage())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start Vaultwarden container: %v\n", err)
+		return 1
+	}
+	defer terminate()
+
+	TestBaseURL = url
+	return m.Run()
+}
+
+// GetTestImage returns the Vaultwarden image to start for acceptance tests
+// It can be configured via the VW_TEST_IMAGE environment variable
+func GetTestImage() string {
+	if image := os.Getenv("VW_TEST_IMAGE"); image != "" {
+		return image
+	}
+	return DefaultImage
+}
+
+// StartContainer starts a Vaultwarden container with the test admin token and returns its URL and a
+// function that removes the container again
+func StartContainer(ctx context.Context, image string) (url string, terminate func(), err error) {
+	// testcontainers panics if no container runtime can be found
+	defer func() {
+		if r := recover(); r != nil {
+			url, terminate, err = "", nil, fmt.Errorf("no container runtime available: %v", r)
+		}
+	}()
+
+	container, err := testcontainers.Run(ctx, image,
+		testcontainers.WithExposedPorts(containerPort),
+		testcontainers.WithEnv(map[string]string{
+			"ADMIN_TOKEN":               TestAdminToken,
+			"ADMIN_RATELIMIT_MAX_BURST": "100",
+			"ADMIN_RATELIMIT_SECONDS":   "10",
+			"LOGIN_RATELIMIT_MAX_BURST": "100",
+			"LOGIN_RATELIMIT_SECONDS":   "10",
+			"ORG_GROUPS_ENABLED":        "true",
+		}),
+		testcontainers.WithWaitStrategy(
+			wait.ForHTTP("/alive").WithPort(containerPort).WithStartupTimeout(containerStartupTimeout),
+		),
+	)
+	terminate = func() {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to remove Vaultwarden container: %v\n", err)
+		}
+	}
+	if err != nil {
+		terminate()
+		return "", nil, fmt.Errorf("failed to start container from image %s: %w", image, err)
+	}
+
+	url, err = container.PortEndpoint(ctx, containerPort, "http")
+	if err != nil {
+		terminate()
+		return "", nil, fmt.Errorf("failed to get container endpoint: %w", err)
+	}
+
+	return url, terminate, nil
+}
@@ -3,10 +3,10 @@ package test
 import (
 	"context"
 	"fmt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/crypt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/keybuilder"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/keybuilder"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
 	"os"
 	"strings"
 	"sync"
@@ -28,13 +28,9 @@ var (
 )
 
 // GetTestBaseURL returns the base URL for the Vaultwarden instance
-// It can be configured via the VW_TEST_URL environment variable
+// It can be configured via the VW_TEST_URL environment variable, otherwise Main starts a container
 func GetTestBaseURL() string {
-	if url := os.Getenv("VW_TEST_URL"); url != "" {
-		return url
-	}
-	// Default to docker service name when running in container
-	return "http://tf-vaultwarden:8000"
+	return os.Getenv("VW_TEST_URL")
 }
 
 // GetTestClient returns a singleton test client, creating it if necessary
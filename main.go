@@ -4,8 +4,12 @@ import (
 	"context"
 	"flag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/ottramst/terraform-provider-vaultwarden/internal/provider"
 	"log"
+	"os"
+	"slices"
+	"strings"
 )
 
 var (
@@ -31,9 +35,46 @@ func main() {
 		Debug:   debug,
 	}
 
+	// Terraform and OpenTofu releases that don't support protocol version 6 get a downgraded server
+	if !supportsProtocol6() {
+		serveProtocol5(opts)
+		return
+	}
+
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// supportsProtocol6 reports whether the plugin client supports protocol version 6. Terraform and
+// OpenTofu announce the plugin protocol versions they support in the PLUGIN_PROTOCOL_VERSIONS
+// environment variable, which is not set when the provider is started for debugging.
+func supportsProtocol6() bool {
+	versions := os.Getenv("PLUGIN_PROTOCOL_VERSIONS")
+	if versions == "" {
+		return true
+	}
+
+	return slices.Contains(strings.Split(versions, ","), "6")
+}
+
+// serveProtocol5 serves the provider with protocol version 5
+func serveProtocol5(opts providerserver.ServeOpts) {
+	ctx := context.Background()
+
+	server, err := provider.NewProtocol5Server(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if opts.Debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	if err := tf5server.Serve(opts.Address, server, serveOpts...); err != nil {
+		log.Fatal(err.Error())
+	}
+}
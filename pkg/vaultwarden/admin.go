@@ -0,0 +1,92 @@
+package vaultwarden
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// GetAdminConfig retrieves the effective configuration of the server from the diagnostics of the admin
+// panel. Secrets, e.g. the SMTP password, are redacted by the server.
+func (c *Client) GetAdminConfig(ctx context.Context) (map[string]json.RawMessage, error) {
+	var config map[string]json.RawMessage
+	if _, err := c.doRequest(ctx, http.MethodGet, "/admin/diagnostics/config", nil, &config); err != nil {
+		return nil, fmt.Errorf("failed to get admin config: %w", err)
+	}
+
+	return config, nil
+}
+
+// MailEnabled reports whether the server delivers mails. Like Vaultwarden, it requires SMTP to be enabled and an SMTP
+// host or sendmail to be configured. The result is cached, failed requests are not.
+func (c *Client) MailEnabled(ctx context.Context) (bool, error) {
+	c.mailMu.Lock()
+	defer c.mailMu.Unlock()
+
+	if c.mailEnabled != nil {
+		return *c.mailEnabled, nil
+	}
+
+	config, err := c.GetAdminConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// Unset settings are null and keep their defaults
+	var enableSMTP, useSendmail *bool
+	var smtpHost *string
+	for name, target := range map[string]interface{}{
+		"_enable_smtp": &enableSMTP,
+		"smtp_host":    &smtpHost,
+		"use_sendmail": &useSendmail,
+	} {
+		if raw, ok := config[name]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				return false, fmt.Errorf("failed to decode admin config setting %s: %w", name, err)
+			}
+		}
+	}
+
+	enabled := (enableSMTP == nil || *enableSMTP) && ((smtpHost != nil && *smtpHost != "") || (useSendmail != nil && *useSendmail))
+	c.mailEnabled = &enabled
+
+	return enabled, nil
+}
+
+// GetAllOrganizations retrieves all organizations of the server, not only those the user is a member of. Vaultwarden
+// only lists the organizations in the HTML overview of the admin panel, so they are collected from the users of the
+// server instead. Organizations without confirmed members are therefore not included, and the user count only counts
+// the confirmed members. The organizations are sorted by name and ID.
+func (c *Client) GetAllOrganizations(ctx context.Context) ([]models.OrganizationOverview, error) {
+	users, err := c.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overviews := map[string]*models.OrganizationOverview{}
+	for _, user := range users {
+		for _, org := range user.Organizations {
+			overview, exists := overviews[org.ID]
+			if !exists {
+				overview = &models.OrganizationOverview{ID: org.ID, Name: org.Name, Enabled: org.Enabled}
+				overviews[org.ID] = overview
+			}
+			overview.UserCount++
+		}
+	}
+
+	organizations := make([]models.OrganizationOverview, 0, len(overviews))
+	for _, overview := range overviews {
+		organizations = append(organizations, *overview)
+	}
+	slices.SortFunc(organizations, func(a, b models.OrganizationOverview) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+	})
+
+	return organizations, nil
+}
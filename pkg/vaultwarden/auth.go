@@ -0,0 +1,242 @@
+package vaultwarden
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AuthMethod represents the type of authentication being used
+type AuthMethod int
+
+const (
+	AuthMethodNone AuthMethod = iota
+	AuthMethodAdmin
+	AuthMethodUserPassword
+	AuthMethodOAuth2
+)
+
+// String returns the string representation of the auth method
+func (m AuthMethod) String() string {
+	switch m {
+	case AuthMethodAdmin:
+		return "admin"
+	case AuthMethodUserPassword:
+		return "password"
+	case AuthMethodOAuth2:
+		return "api_key"
+	default:
+		return "none"
+	}
+}
+
+// AuthContext describes the user the client is authenticated as and how
+type AuthContext struct {
+	UserID              string
+	Email               string
+	Method              AuthMethod
+	TokenExpiresAt      time.Time
+	AdminAuthConfigured bool
+}
+
+type OrganizationSecret struct {
+	Key              symmetrickey.Key
+	OrganizationUUID string
+	Name             string
+}
+
+// AuthState holds the current authentication state
+type AuthState struct {
+	// Admin authentication
+	AdminCookie *http.Cookie
+
+	// User authentication
+	AccessToken    string    // JWT token
+	TokenExpiresAt time.Time // JWT expiration time
+	PrivateKey     *rsa.PrivateKey
+	KdfConfig      *models.KdfConfiguration
+
+	// Organizations data
+	Organizations map[string]OrganizationSecret
+}
+
+// accessToken returns the current user access token
+func (c *Client) accessToken() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.AuthState.AccessToken
+}
+
+// privateKey returns the decrypted private key of the authenticated user
+func (c *Client) privateKey() *rsa.PrivateKey {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.AuthState.PrivateKey
+}
+
+// adminCookie returns the current admin session cookie
+func (c *Client) adminCookie() *http.Cookie {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.AuthState.AdminCookie
+}
+
+// cachedOrganizationSecret returns the cached secret of an organization
+func (c *Client) cachedOrganizationSecret(orgID string) (OrganizationSecret, bool) {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	orgSecret, exists := c.AuthState.Organizations[orgID]
+	return orgSecret, exists
+}
+
+// cacheOrganizationSecrets adds the given organization secrets to the cache
+func (c *Client) cacheOrganizationSecrets(secrets map[string]OrganizationSecret) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.AuthState.Organizations == nil {
+		c.AuthState.Organizations = make(map[string]OrganizationSecret)
+	}
+	for orgID, orgSecret := range secrets {
+		c.AuthState.Organizations[orgID] = orgSecret
+	}
+}
+
+// invalidateAuth clears the credentials used by the given request from the auth state,
+// unless they have already been replaced by a newer login
+func (c *Client) invalidateAuth(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if cookie, err := req.Cookie(adminCookieName); err == nil && c.AuthState.AdminCookie != nil && c.AuthState.AdminCookie.Value == cookie.Value {
+		c.AuthState.AdminCookie = nil
+	}
+
+	if c.AuthState.AccessToken != "" && req.Header.Get("Authorization") == "Bearer "+c.AuthState.AccessToken {
+		c.AuthState.AccessToken = ""
+		c.AuthState.TokenExpiresAt = time.Time{}
+	}
+}
+
+// validateCredentials ensures that the provided credentials meet the requirements
+func (c *Client) validateCredentials() error {
+	if c.Credentials == nil {
+		return fmt.Errorf("no credentials provided")
+	}
+
+	// Check if at least one auth method is defined
+	hasAdminAuth := c.Credentials.AdminToken != ""
+	hasUserAuth := c.Credentials.Email != "" && c.Credentials.MasterPassword != ""
+	hasOAuth2Auth := c.Credentials.ClientID != "" && c.Credentials.ClientSecret != ""
+
+	if !hasAdminAuth && !hasUserAuth && !hasOAuth2Auth {
+		return fmt.Errorf("at least one authentication method must be provided")
+	}
+
+	// Validate user credentials if OAuth2 is used
+	if hasOAuth2Auth {
+		if c.Credentials.Email == "" || c.Credentials.MasterPassword == "" {
+			return fmt.Errorf("email and master password are required when using OAuth2")
+		}
+		c.userAuthMethod = AuthMethodOAuth2
+	} else if hasUserAuth {
+		c.userAuthMethod = AuthMethodUserPassword
+	}
+
+	return nil
+}
+
+// getAuthMethod determines which authentication method to use based on the request path
+// relative to the endpoint
+func (c *Client) getAuthMethod(path string) (AuthMethod, error) {
+	// Use admin token for /admin endpoints
+	if path == "/admin" || strings.HasPrefix(path, "/admin/") {
+		if c.Credentials.AdminToken != "" {
+			return AuthMethodAdmin, nil
+		}
+		return AuthMethodNone, fmt.Errorf("admin token is required for admin endpoints but was not provided")
+	}
+
+	// For other endpoints, prefer OAuth2 if available
+	if c.Credentials.ClientID != "" && c.Credentials.ClientSecret != "" {
+		return AuthMethodOAuth2, nil
+	}
+
+	// Fall back to user/password if available
+	if c.Credentials.Email != "" && c.Credentials.MasterPassword != "" {
+		return AuthMethodUserPassword, nil
+	}
+
+	return AuthMethodNone, fmt.Errorf("no valid authentication method available for path: %s", path)
+}
+
+// authenticateRequest adds authentication headers/data to the request based on the auth method
+func (c *Client) authenticateRequest(req *http.Request) error {
+	authMethod, err := c.getAuthMethod(c.apiPath(req.URL))
+	if err != nil {
+		return err
+	}
+
+	switch authMethod {
+	case AuthMethodAdmin:
+		// Ensure we have valid admin authentication
+		if err := c.ensureAdminAuth(req.Context()); err != nil {
+			return fmt.Errorf("admin authentication failed: %w", err)
+		}
+
+		// Add admin cookie to request
+		if cookie := c.adminCookie(); cookie != nil {
+			req.AddCookie(cookie)
+		}
+	case AuthMethodOAuth2, AuthMethodUserPassword:
+		// Both OAuth2 and user/password methods use JWT tokens
+		if err := c.ensureUserAuth(req.Context()); err != nil {
+			return fmt.Errorf("user authentication failed: %w", err)
+		}
+
+		// Add the JWT token as a Bearer token
+		if token := c.accessToken(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	case AuthMethodNone:
+		return fmt.Errorf("no valid authentication method available for path: %s", req.URL.Path)
+	}
+
+	return nil
+}
+
+// Re
+
+// AuthContext returns the authentication context of the client, logging in the user if user
+// credentials are configured. Without user credentials only the admin authentication is reported.
+func (c *Client) AuthContext(ctx context.Context) (*AuthContext, error) {
+	authContext := &AuthContext{
+		Method:              c.userAuthMethod,
+		AdminAuthConfigured: c.Credentials.AdminToken != "",
+	}
+
+	if c.userAuthMethod == AuthMethodNone {
+		return authContext, nil
+	}
+
+	profile, err := c.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authContext.UserID = profile.ID
+	authContext.Email = profile.Email
+
+	c.authMu.RLock()
+	authContext.TokenExpiresAt = c.AuthState.TokenExpiresAt
+	c.authMu.RUnlock()
+
+	return authContext, nil
+}
@@ -3,23 +3,50 @@ package vaultwarden
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"net/http"
 	"net/url"
 	"time"
 )
 
+const (
+	adminCookieName = "VW_ADMIN"
+)
+
 // ensureAdminAuth ensures that admin authentication is valid
 func (c *Client) ensureAdminAuth(ctx context.Context) error {
 	// Check if we have a valid admin session
-	if c.AuthState != nil && c.AuthState.AdminCookie != nil {
-		// Check if cookie is not expired
-		if !c.AuthState.AdminCookie.Expires.IsZero() && time.Now().Before(c.AuthState.AdminCookie.Expires) {
-			return nil
-		}
+	if c.hasValidAdminAuth() {
+		return nil
+	}
+
+	// Only perform one login at a time
+	c.adminLoginMu.Lock()
+	defer c.adminLoginMu.Unlock()
+
+	// Another request might have logged in while we were waiting
+	if c.hasValidAdminAuth() {
+		return nil
 	}
 
 	// Perform admin login
-	return c.adminLogin(ctx)
+	ctx = WithLogSubsystems(ctx)
+	tflog.SubsystemDebug(ctx, LogSubsystemAuth, "Logging in to the Vaultwarden admin panel")
+	if err := c.observeLogin(ctx, loginKindAdmin, c.adminLogin); err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystemAuth, "Vaultwarden admin login failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystemAuth, "Logged in to the Vaultwarden admin panel")
+	return nil
+}
+
+// hasValidAdminAuth checks whether the admin session cookie is present and not expired
+func (c *Client) hasValidAdminAuth() bool {
+	cookie := c.adminCookie()
+	return cookie != nil && !cookie.Expires.IsZero() && time.Now().Before(cookie.Expires)
 }
 
 // adminLogin performs the admin authentication
@@ -30,19 +57,16 @@ func (c *Client) adminLogin(ctx context.Context) error {
 
 	// Configure client to not follow redirects. This happens for some versions of Vaultwarden
 	// See: https://github.com/dani-garcia/vaultwarden/issues/2444
-	originalClient := c.httpClient
-	c.httpClient = &http.Client{
-		Timeout: originalClient.Timeout,
+	noRedirectClient := &http.Client{
+		Transport: c.httpClient.Transport,
+		Timeout:   c.httpClient.Timeout,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
 	}
-	defer func() {
-		c.httpClient = originalClient
-	}()
 
 	// Make login request
-	resp, err := c.doUnauthenticatedRequest(ctx, http.MethodPost, "/admin", form, nil)
+	resp, err := c.sendRequest(ctx, noRedirectClient, false, http.MethodPost, "/admin", form, nil)
 	if err != nil {
 		// Check if this is a redirect error (which we expect)
 		if resp != nil && resp.StatusCode == http.StatusSeeOther {
@@ -60,11 +84,10 @@ func (c *Client) adminLogin(ctx context.Context) error {
 
 	// Look for admin cookie
 	for _, cookie := range resp.Cookies() {
-		if cookie.Name == "VW_ADMIN" {
-			if c.AuthState == nil {
-				c.AuthState = &AuthState{}
-			}
+		if cookie.Name == adminCookieName {
+			c.authMu.Lock()
 			c.AuthState.AdminCookie = cookie
+			c.authMu.Unlock()
 			return nil
 		}
 	}
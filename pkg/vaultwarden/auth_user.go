@@ -0,0 +1,413 @@
+package vaultwarden
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/keybuilder"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/totp"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TokenResponse represents the response from the login endpoint
+type TokenResponse struct {
+	Kdf                 models.KdfType `json:"Kdf"`
+	KdfIterations       int            `json:"KdfIterations"`
+	KdfMemory           int            `json:"kdfMemory"`
+	KdfParallelism      int            `json:"kdfParallelism"`
+	Key                 string         `json:"Key"`
+	PrivateKey          string         `json:"PrivateKey"`
+	ResetMasterPassword bool           `json:"ResetMasterPassword"`
+	AccessToken         string         `json:"access_token"`
+	ExpireIn            int            `json:"expires_in"`
+	RefreshToken        string         `json:"refresh_token"`
+	Scope               string         `json:"scope"`
+	TokenType           string         `json:"token_type"`
+	UnofficialServer    bool           `json:"unofficialServer"`
+}
+
+// ensureUserAuth ensures that user authentication is valid
+func (c *Client) ensureUserAuth(ctx context.Context) error {
+	// Check if we have a valid user session
+	if c.hasValidUserAuth() {
+		return nil
+	}
+
+	// Only perform one login at a time
+	c.userLoginMu.Lock()
+	defer c.userLoginMu.Unlock()
+
+	// Another request might have logged in while we were waiting
+	if c.hasValidUserAuth() {
+		return nil
+	}
+
+	// Perform user login
+	ctx = WithLogSubsystems(ctx)
+	tflog.SubsystemDebug(ctx, LogSubsystemAuth, "Logging in to Vaultwarden as user", map[string]interface{}{
+		"auth_method": c.userAuthMethod.String(),
+	})
+	if err := c.observeLogin(ctx, loginKindUser, c.userLogin); err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystemAuth, "Vaultwarden user login failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystemAuth, "Logged in to Vaultwarden as user")
+	return nil
+}
+
+// hasValidUserAuth checks whether the access token and private key are present and the token is not expired
+func (c *Client) hasValidUserAuth() bool {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+
+	if c.AuthState.AccessToken == "" || c.AuthState.PrivateKey == nil {
+		return false
+	}
+
+	// Check if token is not expired (with some buffer time)
+	return !c.AuthState.TokenExpiresAt.IsZero() && time.Now().Add(time.Minute).Before(c.AuthState.TokenExpiresAt)
+}
+
+// userLogin performs the user authentication
+func (c *Client) userLogin(ctx context.Context) error {
+	// 1. Get KDF configuration if not already present
+	c.authMu.RLock()
+	kdfConfig := c.AuthState.KdfConfig
+	c.authMu.RUnlock()
+
+	if kdfConfig == nil {
+		preloginResp, err := c.PreLogin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get prelogin info: %w", err)
+		}
+
+		// Build the KDF configuration
+		kdfConfig = &models.KdfConfiguration{
+			KdfType:        preloginResp.Kdf,
+			KdfIterations:  preloginResp.KdfIterations,
+			KdfMemory:      preloginResp.KdfMemory,
+			KdfParallelism: preloginResp.KdfParallelism,
+		}
+
+		c.authMu.Lock()
+		c.AuthState.KdfConfig = kdfConfig
+		c.authMu.Unlock()
+	}
+
+	// 2. Build a prelogin key
+	preloginKey, err := keybuilder.BuildPreloginKey(c.Credentials.MasterPassword, c.Credentials.Email, kdfConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build prelogin key: %w", err)
+	}
+	defer preloginKey.Zero()
+
+	// 3. Hash the password
+	hashedPassword := crypt.HashPassword(c.Credentials.MasterPassword, *preloginKey, false)
+	c.cachePasswordHash(kdfConfig, hashedPassword)
+
+	// 4. Perform the login request
+	var tokenResp *TokenResponse
+	switch c.userAuthMethod {
+	case AuthMethodOAuth2:
+		tokenResp, err = c.loginWithAPIKey(ctx)
+		if err != nil {
+			return fmt.Errorf("API key authentication failed: %w", err)
+		}
+	case AuthMethodUserPassword:
+		tokenResp, err = c.LoginWithUserCredentials(ctx, hashedPassword)
+		if err != nil {
+			return fmt.Errorf("user credential authentication failed: %w", err)
+		}
+	default:
+		return fmt.Errorf("no valid user authentication method available")
+	}
+
+	// 5. Decrypt the encryption key
+	encryptionKey, err := crypt.DecryptEncryptionKey(tokenResp.Key, *preloginKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt encryption key: %w", err)
+	}
+	defer encryptionKey.Zero()
+
+	// 6. Decrypt the private key
+	privateKey, err := crypt.DecryptPrivateKey(tokenResp.PrivateKey, *encryptionKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+
+	// Parse token expiration
+	expirationTime, err := helpers.ParseJWTExpiration(tokenResp.AccessToken)
+	if err != nil {
+		return fmt.Errorf("failed to parse token expiration: %w", err)
+	}
+
+	// Update auth state
+	c.authMu.Lock()
+	c.AuthState.AccessToken = tokenResp.AccessToken
+	c.AuthState.PrivateKey = privateKey
+	c.AuthState.TokenExpiresAt = expirationTime
+	c.authMu.Unlock()
+
+	// Fetch the user profile. The request is not shared, as the login might have been triggered by the
+	// reauthentication of a shared profile request, which would otherwise wait for itself.
+	var user models.User
+	if _, err := c.sendRequest(ctx, c.httpClient, true, http.MethodGet, "/api/accounts/profile", nil, &user); err != nil {
+		return fmt.Errorf("failed to get user profile: %w", err)
+	}
+
+	// Decrypt the organization keys
+	secrets, err := decryptOrganizationSecrets(user.Organizations, privateKey)
+	if err != nil {
+		return err
+	}
+
+	// Replace the org keys in the auth state
+	c.authMu.Lock()
+	c.AuthState.Organizations = secrets
+	c.authMu.Unlock()
+
+	return nil
+}
+
+// userKey derives the user key from the master password and the encrypted key of the profile. The user
+// key is not kept in memory after logging in, callers have to zero it once it is no longer needed.
+func (c *Client) userKey(ctx context.Context) (*symmetrickey.Key, error) {
+	profile, err := c.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The KDF configuration is loaded by the login preceding the profile request
+	c.authMu.RLock()
+	kdfConfig := c.AuthState.KdfConfig
+	c.authMu.RUnlock()
+
+	if kdfConfig == nil {
+		return nil, fmt.Errorf("KDF configuration is not available")
+	}
+
+	preloginKey, err := keybuilder.BuildPreloginKey(c.Credentials.MasterPassword, c.Credentials.Email, kdfConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prelogin key: %w", err)
+	}
+	defer preloginKey.Zero()
+
+	userKey, err := crypt.DecryptEncryptionKey(profile.Key, *preloginKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt user key: %w", err)
+	}
+
+	return userKey, nil
+}
+
+// masterPasswordHash hashes the master password of the configured user, as required by the endpoints
+// which ask for the master password to be confirmed
+func (c *Client) masterPasswordHash(ctx context.Context) (string, error) {
+	// Do a prelogin to fetch KDF parameters
+	preloginResp, err := c.PreLogin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("prelogin failed: %w", err)
+	}
+
+	// Create KDF configuration
+	kdfConfig := &models.KdfConfiguration{
+		KdfType:        preloginResp.Kdf,
+		KdfIterations:  preloginResp.KdfIterations,
+		KdfMemory:      preloginResp.KdfMemory,
+		KdfParallelism: preloginResp.KdfParallelism,
+	}
+
+	if hashedPassword, ok := c.cachedPasswordHash(kdfConfig); ok {
+		return hashedPassword, nil
+	}
+
+	preloginKey, err := keybuilder.BuildPreloginKey(c.Credentials.MasterPassword, c.Credentials.Email, kdfConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to build prelogin key: %w", err)
+	}
+	defer preloginKey.Zero()
+
+	hashedPassword := crypt.HashPassword(c.Credentials.MasterPassword, *preloginKey, false)
+	c.cachePasswordHash(kdfConfig, hashedPassword)
+
+	return hashedPassword, nil
+}
+
+// cachedPasswordHash returns the master password hash of the configured user if it has been derived with the KDF
+// configuration before. The key derivation is by design the most expensive operation of the client, so the hash of
+// the login is reused by the endpoints asking for the master password. Unlike the master key, the hash can't decrypt
+// the user key, so only the hash is kept in memory.
+func (c *Client) cachedPasswordHash(kdfConfig *models.KdfConfiguration) (string, bool) {
+	c.passwordHashMu.Lock()
+	defer c.passwordHashMu.Unlock()
+
+	if c.passwordHash == "" || c.passwordHashKdf != *kdfConfig {
+		return "", false
+	}
+
+	return c.passwordHash, true
+}
+
+// cachePasswordHash remembers the master password hash derived with the KDF configuration
+func (c *Client) cachePasswordHash(kdfConfig *models.KdfConfiguration, hashedPassword string) {
+	c.passwordHashMu.Lock()
+	defer c.passwordHashMu.Unlock()
+
+	c.passwordHash = hashedPassword
+	c.passwordHashKdf = *kdfConfig
+}
+
+// decryptOrganizationSecrets decrypts the keys of the given organizations using the user's private key
+func decryptOrganizationSecrets(organizations []models.Organization, privateKey *rsa.PrivateKey) (map[string]OrganizationSecret, error) {
+	secrets := make(map[string]OrganizationSecret)
+	for _, org := range organizations {
+		if !org.Enabled || org.Key == "" {
+			continue
+		}
+
+		// Decrypt the organization key
+		decryptedKeyBytes, err := keybuilder.RSADecrypt(org.Key, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt organization key for org %s: %w", org.ID, err)
+		}
+
+		// Convert decrypted key to symmetrickey.Key
+		decryptedKey, err := symmetrickey.NewFromRawBytes(decryptedKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct symmetric key for org %s: %w", org.ID, err)
+		}
+
+		// Store the decrypted key and org info
+		secrets[org.ID] = OrganizationSecret{
+			Key:              *decryptedKey,
+			OrganizationUUID: org.ID,
+			Name:             org.Name,
+		}
+	}
+
+	return secrets, nil
+}
+
+// preloginRequest represents the request body for the prelogin endpoint
+type preloginRequest struct {
+	Email string `json:"email"`
+}
+
+// PreloginResponse represents the response from the prelogin endpoint
+type PreloginResponse struct {
+	Kdf            models.KdfType `json:"kdf"`
+	KdfIterations  int            `json:"kdfIterations"`
+	KdfMemory      int            `json:"kdfMemory"`
+	KdfParallelism int            `json:"kdfParallelism"`
+}
+
+// PreLogin retrieves the KDF configuration of the account of the client credentials
+func (c *Client) PreLogin(ctx context.Context) (*PreloginResponse, error) {
+	return c.PreLoginForEmail(ctx, c.Credentials.Email)
+}
+
+// PreLoginForEmail retrieves the KDF configuration of the account with the given email. The server returns
+// its default configuration for emails without an account, so that accounts cannot be enumerated.
+func (c *Client) PreLoginForEmail(ctx context.Context, email string) (*PreloginResponse, error) {
+	// Prepare request body
+	reqBody := preloginRequest{
+		Email: email,
+	}
+
+	// Make request
+	var preloginResp PreloginResponse
+	resp, err := c.doUnauthenticatedRequest(ctx, http.MethodPost, "/identity/accounts/prelogin", reqBody, &preloginResp)
+	if err != nil {
+		return nil, fmt.Errorf("prelogin request failed: %w", err)
+	}
+
+	// Check response status
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("prelogin failed with status code: %d", resp.StatusCode)
+	}
+
+	return &preloginResp, nil
+}
+
+func (c *Client) LoginWithUserCredentials(ctx context.Context, hashedPassword string) (*TokenResponse, error) {
+	// Prepare request body
+	form := url.Values{}
+	form.Add("scope", "api offline_access")
+	form.Add("client_id", "cli")
+	form.Add("grant_type", "password")
+	form.Add("username", c.Credentials.Email)
+	form.Add("password", hashedPassword)
+	form.Add("deviceType", c.DeviceInfo.DeviceType)
+	form.Add("deviceIdentifier", c.DeviceInfo.DeviceIdentifier)
+	form.Add("deviceName", c.DeviceInfo.DeviceName)
+
+	if c.Credentials.TOTPSecret == "" {
+		return c.requestUserToken(ctx, form)
+	}
+
+	// Vaultwarden rejects codes of time steps that were used before, e.g. by the login of the previous
+	// operation. As it accepts the code of the next time step to allow for clock drift, that one is tried
+	// if the current one is rejected.
+	form.Add("twoFactorProvider", strconv.Itoa(int(models.TwoFactorTypeAuthenticator)))
+	form.Add("twoFactorRemember", "0")
+
+	now := time.Now()
+	var err error
+	for _, t := range []time.Time{now, now.Add(totp.Period)} {
+		code, codeErr := totp.Code(c.Credentials.TOTPSecret, t)
+		if codeErr != nil {
+			return nil, fmt.Errorf("failed to generate TOTP code: %w", codeErr)
+		}
+		form.Set("twoFactorToken", code)
+
+		var tokenResp *TokenResponse
+		tokenResp, err = c.requestUserToken(ctx, form)
+		var vwErr *models.VaultwardenError
+		if err == nil || !errors.As(err, &vwErr) || !vwErr.IsInvalidTwoFactorCode() {
+			return tokenResp, err
+		}
+	}
+
+	return nil, err
+}
+
+// requestUserToken requests an access token with the master password login form
+func (c *Client) requestUserToken(ctx context.Context, form url.Values) (*TokenResponse, error) {
+	var tokenResp TokenResponse
+	if _, err := c.doUnauthenticatedRequest(ctx, http.MethodPost, "/identity/connect/token", form, &tokenResp); err != nil {
+		return nil, fmt.Errorf("user credential authentication failed: %w", err)
+	}
+
+	return &tokenResp, nil
+}
+
+func (c *Client) loginWithAPIKey(ctx context.Context) (*TokenResponse, error) {
+	// Prepare request body
+	form := url.Values{}
+	form.Add("scope", "api")
+	form.Add("client_id", c.Credentials.ClientID)
+	form.Add("client_secret", c.Credentials.ClientSecret)
+	form.Add("grant_type", "client_credentials")
+	form.Add("deviceType", c.DeviceInfo.DeviceType)
+	form.Add("deviceIdentifier", c.DeviceInfo.DeviceIdentifier)
+	form.Add("deviceName", c.DeviceInfo.DeviceName)
+
+	var tokenResp TokenResponse
+	if _, err := c.doUnauthenticatedRequest(ctx, http.MethodPost, "/identity/connect/token", form, &tokenResp); err != nil {
+		return nil, fmt.Errorf("API key authentication failed: %w", err)
+	}
+
+	return &tokenResp, nil
+}
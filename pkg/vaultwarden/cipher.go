@@ -0,0 +1,210 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// CreateOrganizationCipherRequest represents the request body for creating an organization item
+type CreateOrganizationCipherRequest struct {
+	Cipher        models.Cipher `json:"cipher"`
+	CollectionIDs []string      `json:"collectionIds"`
+}
+
+// DeleteCiphersAdminRequest represents the request body for permanently deleting items of an organization
+type DeleteCiphersAdminRequest struct {
+	IDs            []string `json:"ids"`
+	OrganizationID string   `json:"organizationId"`
+}
+
+// CipherCollectionsRequest represents the request body for updating the collections of an item
+type CipherCollectionsRequest struct {
+	CollectionIDs []string `json:"collectionIds"`
+}
+
+// CreateOrganizationCipher creates a new item in an organization, assigned to the given collections.
+// Only the name of the item is encrypted, the item data is expected to be encrypted already.
+func (c *Client) CreateOrganizationCipher(ctx context.Context, orgID string, cipher models.Cipher, collectionIDs []string) (*models.Cipher, error) {
+	// Get the organization key, loading it if necessary
+	orgSecret, err := c.GetOrganizationSecret(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt the item name using the organization key
+	cipherName, err := c.encryptString([]byte(cipher.Name), orgSecret.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt item name: %w", err)
+	}
+	cipher.Name = cipherName
+	cipher.OrganizationID = orgID
+
+	req := CreateOrganizationCipherRequest{
+		Cipher:        cipher,
+		CollectionIDs: collectionIDs,
+	}
+
+	var cipherResp models.Cipher
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/ciphers/create", req, &cipherResp); err != nil {
+		return nil, fmt.Errorf("failed to create organization item: %w", err)
+	}
+
+	return &cipherResp, nil
+}
+
+// GetOrganizationCipher retrieves an organization item by its ID, including the collections it is assigned to
+func (c *Client) GetOrganizationCipher(ctx context.Context, cipherID string) (*models.Cipher, error) {
+	if cipherID == "" {
+		return nil, fmt.Errorf("item ID is required")
+	}
+
+	var cipher models.Cipher
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/ciphers/%s/admin", cipherID), nil, &cipher); err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+
+	return &cipher, nil
+}
+
+// GetOrganizationCiphers retrieves all items of an organization, including the collections they are assigned to
+func (c *Client) GetOrganizationCiphers(ctx context.Context, orgID string) ([]models.Cipher, error) {
+	ciphers, err := getAllPages[models.Cipher](ctx, c, "/api/ciphers/organization-details?organizationId="+url.QueryEscape(orgID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization items: %w", err)
+	}
+
+	return ciphers, nil
+}
+
+// DecryptOrganizationCipherString decrypts a value of an organization item. Items with an individual key
+// are encrypted using that key, which is encrypted using the organization key itself.
+func (c *Client) DecryptOrganizationCipherString(ctx context.Context, cipher models.Cipher, value string) (string, error) {
+	if cipher.Key == "" {
+		return c.DecryptOrganizationString(ctx, cipher.OrganizationID, value)
+	}
+
+	orgSecret, err := c.GetOrganizationSecret(ctx, cipher.OrganizationID)
+	if err != nil {
+		return "", err
+	}
+
+	encKey, err := encryptedstring.NewFromEncryptedValue(cipher.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse item key: %w", err)
+	}
+
+	keyBytes, err := crypt.Decrypt(encKey, &orgSecret.Key)
+	if err != nil {
+		tflog.SubsystemDebug(WithLogSubsystems(ctx), LogSubsystemCrypto, "Failed to decrypt item key", map[string]interface{}{
+			LogFieldOrganizationID: cipher.OrganizationID,
+			LogFieldResource:       "cipher",
+			"cipher_id":            cipher.ID,
+			"error":                err.Error(),
+		})
+		return "", fmt.Errorf("failed to decrypt item key: %w", err)
+	}
+	defer clear(keyBytes)
+
+	cipherKey, err := symmetrickey.NewFromRawBytes(keyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to construct item key: %w", err)
+	}
+	defer cipherKey.Zero()
+
+	encString, err := encryptedstring.NewFromEncryptedValue(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse encrypted value: %w", err)
+	}
+
+	decryptedBytes, err := crypt.Decrypt(encString, cipherKey)
+	if err != nil {
+		tflog.SubsystemDebug(WithLogSubsystems(ctx), LogSubsystemCrypto, "Failed to decrypt item value", map[string]interface{}{
+			LogFieldOrganizationID: cipher.OrganizationID,
+			LogFieldResource:       "cipher",
+			"cipher_id":            cipher.ID,
+			"error":                err.Error(),
+		})
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return string(decryptedBytes), nil
+}
+
+// UpdateCipherCollections replaces the collections an organization item is assigned to
+func (c *Client) UpdateCipherCollections(ctx context.Context, cipherID string, collectionIDs []string) error {
+	if cipherID == "" {
+		return fmt.Errorf("item ID is required")
+	}
+
+	// Send an empty list instead of null to remove the item from all collections
+	if collectionIDs == nil {
+		collectionIDs = []string{}
+	}
+
+	req := CipherCollectionsRequest{CollectionIDs: collectionIDs}
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/ciphers/%s/collections-admin", cipherID), req, nil); err != nil {
+		return fmt.Errorf("failed to update item collections: %w", err)
+	}
+
+	return nil
+}
+
+// TrashOrganizationCipher moves an organization item to the trash, from which it can be restored
+func (c *Client) TrashOrganizationCipher(ctx context.Context, cipherID string) error {
+	if cipherID == "" {
+		return fmt.Errorf("item ID is required")
+	}
+
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/ciphers/%s/delete-admin", cipherID), nil, nil); err != nil {
+		return fmt.Errorf("failed to move item to the trash: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeOrganizationTrash permanently deletes the items in the trash of an organization which have been deleted
+// before the given time, or all of them if it is zero. The IDs of the purged items are returned.
+func (c *Client) PurgeOrganizationTrash(ctx context.Context, orgID string, deletedBefore time.Time) ([]string, error) {
+	ciphers, err := c.GetOrganizationCiphers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, cipher := range ciphers {
+		if cipher.DeletedDate == nil {
+			continue
+		}
+
+		if !deletedBefore.IsZero() {
+			deletedDate, err := time.Parse(time.RFC3339Nano, *cipher.DeletedDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse deletion date of item %s: %w", cipher.ID, err)
+			}
+			if !deletedDate.Before(deletedBefore) {
+				continue
+			}
+		}
+
+		ids = append(ids, cipher.ID)
+	}
+
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	req := DeleteCiphersAdminRequest{IDs: ids, OrganizationID: orgID}
+	if _, err := c.doRequest(ctx, http.MethodDelete, "/api/ciphers/admin", req, nil); err != nil {
+		return nil, fmt.Errorf("failed to purge organization trash: %w", err)
+	}
+
+	return ids, nil
+}
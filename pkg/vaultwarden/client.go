@@ -0,0 +1,598 @@
+package vaultwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultDeviceType = "21"
+	DefaultDeviceName = "Vaultwarden_Terraform_Provider"
+
+	// maxErrorBodyLength limits how much of a non-JSON error response is included in errors
+	maxErrorBodyLength = 512
+
+	// maxErrorResponseSize limits how much of an error response is read, error responses are small
+	maxErrorResponseSize = 1 << 20
+
+	// DefaultMaxResponseSize limits the size of the decoded responses, which is well above the sync
+	// payload of large vaults
+	DefaultMaxResponseSize = 256 << 20
+
+	// requestIDHeader carries the correlation ID of a request, so that it can be matched
+	// with the logs of the Vaultwarden server or a reverse proxy in front of it
+	requestIDHeader = "X-Request-Id"
+)
+
+// DeviceInfo holds information about the client device
+type DeviceInfo struct {
+	DeviceType       string
+	DeviceIdentifier string
+	DeviceName       string
+}
+
+// Client represents a Vaultwarden API client
+type Client struct {
+	endpoint    *url.URL
+	endpoints   []*url.URL
+	httpClient  *http.Client
+	middlewares []Middleware
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// Index of the endpoint requests are sent to, guarded by endpointMu. Failovers are serialized by failoverMu.
+	activeEndpoint int
+	endpointMu     sync.RWMutex
+	failoverMu     sync.Mutex
+
+	// HTTP client passed in by the caller, before the middlewares were added
+	baseHTTPClient *http.Client
+
+	// Auth credentials
+	Credentials    *models.Credentials
+	userAuthMethod AuthMethod
+
+	// Authenticated state
+	AuthState *AuthState
+
+	// Guards the authenticated state
+	authMu sync.RWMutex
+
+	// Ensure that only one login and organization key refresh is in flight at a time
+	userLoginMu  sync.Mutex
+	adminLoginMu sync.Mutex
+	orgKeysMu    sync.Mutex
+
+	// Index of the sync data, guarded by indexMu
+	index   *syncIndex
+	indexMu sync.Mutex
+
+	// Deduplicates identical concurrent GET requests. The write epoch is part of the key, so that
+	// requests issued after a write never share the response of a request issued before it.
+	inflight   singleflight.Group
+	writeEpoch atomic.Uint64
+
+	// Cached listings of organization users and collections
+	orgUsersCache       listingCache[models.OrganizationUserDetails]
+	orgCollectionsCache listingCache[models.Collection]
+
+	// Device info
+	DeviceInfo *DeviceInfo
+
+	// Detected version of the server, guarded by versionMu
+	serverVersion    *ServerVersion
+	serverVersionErr error
+	versionDetected  bool
+	versionMu        sync.Mutex
+
+	// Whether the server delivers mails, guarded by mailMu
+	mailEnabled *bool
+	mailMu      sync.Mutex
+
+	// Whether undecryptable values should be reported as warnings instead of errors
+	ignoreDecryptionErrors bool
+
+	// Whether encrypted values are verified by decrypting them again
+	cryptoSafeMode bool
+
+	// Maximum size of the decompressed body of responses that are decoded
+	maxResponseSize int64
+
+	// Receives measurements of the requests and logins, if configured
+	metrics Metrics
+
+	// Traces the requests, the global tracer provider is used unless one is configured
+	tracerProvider trace.TracerProvider
+	tracer         trace.Tracer
+
+	// Master password hash and the KDF configuration it was derived with, guarded by passwordHashMu
+	passwordHash    string
+	passwordHashKdf models.KdfConfiguration
+	passwordHashMu  sync.Mutex
+}
+
+// New creates a new Vaultwarden client with the given endpoint and options
+func New(endpoint string, opts ...ClientOption) (*Client, error) {
+	parsedURL, err := parseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate device identifier
+	deviceID := uuid.New().String()
+
+	client := &Client{
+		endpoint:  parsedURL,
+		endpoints: []*url.URL{parsedURL},
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		DeviceInfo: &DeviceInfo{
+			DeviceType:       DefaultDeviceType,
+			DeviceIdentifier: deviceID,
+			DeviceName:       DefaultDeviceName,
+		},
+		Credentials:     &models.Credentials{},
+		maxResponseSize: DefaultMaxResponseSize,
+		cryptoSafeMode:  true,
+		AuthState: &AuthState{
+			Organizations: make(map[string]OrganizationSecret),
+		},
+	}
+
+	// Apply any provided options
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, fmt.Errorf("failed to apply client option: %w", err)
+		}
+	}
+
+	// Trace the requests with the global tracer provider unless one is configured
+	if client.tracerProvider == nil {
+		client.tracerProvider = otel.GetTracerProvider()
+	}
+	client.tracer = client.tracerProvider.Tracer(tracerName)
+
+	// Compose the transport middlewares
+	if err := client.buildTransport(); err != nil {
+		return nil, err
+	}
+
+	// Validate credentials
+	if err := client.validateCredentials(); err != nil {
+		return nil, fmt.Errorf("failed to validate credentials: %w", err)
+	}
+
+	return client, nil
+}
+
+// ForUser returns a client acting as another user, e.g. an account registered by the provider. The
+// client shares the endpoint, HTTP client, middlewares, device settings and metrics of this client, but
+// none of its credentials or authenticated state.
+func (c *Client) ForUser(email, masterPassword string, opts ...ClientOption) (*Client, error) {
+	shared := []ClientOption{
+		WithHTTPClient(c.baseHTTPClient),
+		WithDeviceType(c.DeviceInfo.DeviceType),
+		WithDeviceName(c.DeviceInfo.DeviceName),
+		WithIgnoreDecryptionErrors(c.ignoreDecryptionErrors),
+		WithCryptoSafeMode(c.cryptoSafeMode),
+		WithUserCredentials(email, masterPassword),
+		WithTracerProvider(c.tracerProvider),
+		WithMaxResponseSize(c.maxResponseSize),
+	}
+	if c.dialContext != nil {
+		shared = append(shared, WithDialContext(c.dialContext))
+	}
+	if c.metrics != nil {
+		shared = append(shared, WithMetrics(c.metrics))
+	}
+	if len(c.middlewares) > 0 {
+		shared = append(shared, WithMiddleware(c.middlewares...))
+	}
+	if len(c.endpoints) > 1 {
+		failoverEndpoints := make([]string, 0, len(c.endpoints)-1)
+		for _, endpoint := range c.endpoints[1:] {
+			failoverEndpoints = append(failoverEndpoints, endpoint.String())
+		}
+		shared = append(shared, WithFailoverEndpoints(failoverEndpoints...))
+	}
+
+	return New(c.endpoint.String(), append(shared, opts...)...)
+}
+
+// MultipartFile represents a file part of a multipart request body
+type MultipartFile struct {
+	FieldName string
+	FileName  string
+	Content   io.Reader
+}
+
+// MultipartBody represents a multipart/form-data request body. The parts are streamed
+// to the server as the request is sent instead of being buffered in memory.
+type MultipartBody struct {
+	Fields map[string]string
+	Files  []MultipartFile
+}
+
+// reader returns a reader streaming the encoded body along with its content type
+func (b *MultipartBody) reader() (io.Reader, string) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(b.write(writer))
+	}()
+
+	return pr, writer.FormDataContentType()
+}
+
+// write encodes the fields and files of the body using the given writer
+func (b *MultipartBody) write(writer *multipart.Writer) error {
+	for name, value := range b.Fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return fmt.Errorf("failed to write multipart field %s: %w", name, err)
+		}
+	}
+
+	for _, file := range b.Files {
+		part, err := writer.CreateFormFile(file.FieldName, file.FileName)
+		if err != nil {
+			return fmt.Errorf("failed to create multipart file %s: %w", file.FileName, err)
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return fmt.Errorf("failed to write multipart file %s: %w", file.FileName, err)
+		}
+	}
+
+	return writer.Close()
+}
+
+// prepareRequestBody prepares the request body and returns the appropriate content type
+func prepareRequestBody(reqBody interface{}) (io.Reader, string, error) {
+	if reqBody == nil {
+		return nil, "", nil
+	}
+
+	var bodyReader io.Reader
+	var contentType string
+
+	switch v := reqBody.(type) {
+	case url.Values:
+		// Handle form-encoded data
+		bodyReader = strings.NewReader(v.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	case string:
+		// Handle raw string data
+		bodyReader = strings.NewReader(v)
+	case []byte:
+		// Handle raw byte data
+		bodyReader = bytes.NewReader(v)
+	case *MultipartBody:
+		// Handle streamed multipart data, e.g. file uploads
+		bodyReader, contentType = v.reader()
+	case io.Reader:
+		// Handle streamed raw data, e.g. large imports
+		bodyReader = v
+		contentType = "application/octet-stream"
+	default:
+		// Handle JSON data
+		jsonData, err := json.Marshal(reqBody)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonData)
+		contentType = "application/json"
+	}
+
+	return bodyReader, contentType, nil
+}
+
+// parseErrorResponse converts an unsuccessful response into a VaultwardenError
+func parseErrorResponse(resp *http.Response, body []byte) *models.VaultwardenError {
+	var vwErr models.VaultwardenError
+	if err := json.Unmarshal(body, &vwErr); err != nil || (vwErr.Message == "" && vwErr.ErrorCode == "") {
+		// Not a Vaultwarden error body (e.g. a proxy error page), use the raw response instead
+		message := strings.TrimSpace(string(body))
+		if len(message) > maxErrorBodyLength {
+			message = message[:maxErrorBodyLength] + "..."
+		}
+		vwErr = models.VaultwardenError{Message: message}
+	}
+	vwErr.StatusCode = resp.StatusCode
+
+	// Error messages end up in diagnostics and logs, make sure they don't leak any secrets
+	vwErr.Message = RedactSecrets(vwErr.Message)
+	vwErr.ErrorDescription = RedactSecrets(vwErr.ErrorDescription)
+	for field, messages := range vwErr.ValidationErrors {
+		for i, message := range messages {
+			messages[i] = RedactSecrets(message)
+		}
+		vwErr.ValidationErrors[field] = messages
+	}
+
+	// Add the request context so that the error can be matched with the server logs
+	if resp.Request != nil {
+		vwErr.Method = resp.Request.Method
+		vwErr.Path = resp.Request.URL.Path
+		vwErr.RequestID = resp.Request.Header.Get(requestIDHeader)
+	}
+
+	return &vwErr
+}
+
+// ResponseTooLargeError is returned when a response exceeds the maximum response size of the client
+type ResponseTooLargeError struct {
+	MaxSize int64
+}
+
+// Error returns the string representation of the error
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds the maximum size of %d bytes", e.MaxSize)
+}
+
+// limitedReader reads from the reader until the remaining bytes are exhausted, failing with a
+// ResponseTooLargeError if there is more to read
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+	maxSize   int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		// Check whether the body ends exactly at the limit
+		var probe [1]byte
+		if n, err := r.reader.Read(probe[:]); n == 0 && err != nil {
+			return 0, err
+		}
+		return 0, &ResponseTooLargeError{MaxSize: r.maxSize}
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
+
+// handleResponse reads the response and decodes it into respBody. If respBody is an io.Writer,
+// the response body is streamed into it instead, e.g. for downloading attachments.
+func (c *Client) handleResponse(resp *http.Response, respBody interface{}) (*http.Response, error) {
+	// Handle error responses
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorResponseSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return resp, parseErrorResponse(resp, body)
+	}
+
+	// Stream the response body if a writer is provided, it isn't held in memory
+	if w, ok := respBody.(io.Writer); ok {
+		if _, err := io.Copy(w, resp.Body); err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return resp, nil
+	}
+
+	// Drain small responses that aren't decoded, so that the connection can be reused
+	if respBody == nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorResponseSize))
+		return resp, nil
+	}
+
+	// Decode the response as it is read instead of buffering it, a misbehaving proxy or an unexpectedly
+	// large response must not exhaust the memory
+	if resp.ContentLength > c.maxResponseSize {
+		return nil, &ResponseTooLargeError{MaxSize: c.maxResponseSize}
+	}
+	body := &limitedReader{reader: resp.Body, remaining: c.maxResponseSize, maxSize: c.maxResponseSize}
+	if err := json.NewDecoder(body).Decode(respBody); err != nil && !errors.Is(err, io.EOF) {
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// parseEndpoint parses and normalizes the endpoint URL. The endpoint may include a sub-path
+// the server is served under, e.g. https://example.com/vaultwarden.
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	parsedURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint URL: %w", err)
+	}
+
+	if (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint URL %q: must be an absolute http or https URL", endpoint)
+	}
+	if parsedURL.RawQuery != "" || parsedURL.Fragment != "" {
+		return nil, fmt.Errorf("invalid endpoint URL %q: must not contain a query or fragment", endpoint)
+	}
+
+	// Remove trailing slashes so that the sub-path can be stripped from request paths
+	parsedURL.Path = strings.TrimRight(parsedURL.Path, "/")
+	parsedURL.RawPath = strings.TrimRight(parsedURL.RawPath, "/")
+
+	return parsedURL, nil
+}
+
+// apiPath returns the path of the request URL relative to the endpoint, i.e. without the
+// sub-path the server is served under
+func (c *Client) apiPath(reqURL *url.URL) string {
+	apiPath := strings.TrimPrefix(reqURL.Path, c.endpoints[c.endpointIndex(reqURL)].Path)
+	if !strings.HasPrefix(apiPath, "/") {
+		apiPath = "/" + apiPath
+	}
+	return apiPath
+}
+
+// buildURL joins the request path onto the active endpoint, preserving any query string
+func (c *Client) buildURL(path string) *url.URL {
+	rawPath, rawQuery, _ := strings.Cut(path, "?")
+	c.endpointMu.RLock()
+	reqURL := c.endpoints[c.activeEndpoint].JoinPath(rawPath)
+	c.endpointMu.RUnlock()
+	reqURL.RawQuery = rawQuery
+	return reqURL
+}
+
+// doUnauthenticatedRequest performs a request without authentication
+//
+//nolint:unparam
+func (c *Client) doUnauthenticatedRequest(ctx context.Context, method, path string, reqBody, respBody interface{}) (*http.Response, error) {
+	return c.sendRequest(ctx, c.httpClient, false, method, path, reqBody, respBody)
+}
+
+// doRequest performs a request with appropriate authentication
+//
+//nolint:unparam
+func (c *Client) doRequest(ctx context.Context, method, path string, reqBody, respBody interface{}) (*http.Response, error) {
+	if _, streamed := respBody.(io.Writer); method == http.MethodGet && reqBody == nil && !streamed {
+		return c.doSharedRequest(ctx, method, path, respBody)
+	}
+
+	return c.sendRequest(ctx, c.httpClient, true, method, path, reqBody, respBody)
+}
+
+// sharedResponse is the result of a request shared between concurrent callers
+type sharedResponse struct {
+	resp *http.Response
+	body json.RawMessage
+
+	// Whether the request failed because the context of the caller that issued it was done
+	cancelled bool
+}
+
+// doSharedRequest performs an authenticated request without a body, sharing the response with
+// identical requests that are issued concurrently, e.g. when Terraform refreshes many resources
+// of the same organization in parallel. The request is bound to the context of the caller that
+// issued it, if that context is done while others still wait, they issue the request again.
+func (c *Client) doSharedRequest(ctx context.Context, method, path string, respBody interface{}) (*http.Response, error) {
+	for {
+		key := strconv.FormatUint(c.writeEpoch.Load(), 10) + " " + method + " " + path
+		resultCh := c.inflight.DoChan(key, func() (interface{}, error) {
+			var body json.RawMessage
+			resp, err := c.sendRequest(ctx, c.httpClient, true, method, path, nil, &body)
+			return sharedResponse{resp: resp, body: body, cancelled: err != nil && ctx.Err() != nil}, err
+		})
+
+		var result singleflight.Result
+		select {
+		case result = <-resultCh:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		shared, _ := result.Val.(sharedResponse)
+		if result.Err != nil {
+			// Retry if the request was cancelled by another caller
+			if shared.cancelled && ctx.Err() == nil {
+				continue
+			}
+			return shared.resp, result.Err
+		}
+
+		// Decode the shared response separately for every caller
+		if respBody != nil && len(shared.body) > 0 {
+			if err := json.Unmarshal(shared.body, respBody); err != nil {
+				return nil, fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+
+		return shared.resp, nil
+	}
+}
+
+// sendRequest sends a request using the given HTTP client and handles the response. Authentication,
+// retries and other cross-cutting behavior are implemented by the middlewares of the client's transport.
+func (c *Client) sendRequest(ctx context.Context, httpClient *http.Client, authenticated bool, method, path string, reqBody, respBody interface{}) (resp *http.Response, err error) {
+	ctx = WithLogSubsystems(ctx)
+
+	// Trace the request including its logins and retries
+	reqURL := c.buildURL(path)
+	ctx, span := c.startRequestSpan(ctx, method, reqURL)
+	defer func() { endRequestSpan(span, resp, err) }()
+
+	// Prepare request body
+	bodyReader, contentType, err := prepareRequestBody(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare request body: %w", err)
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(withAuthentication(ctx, authenticated), method, reqURL.String(), bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set content type if body is present
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	// Tag the request with a correlation ID, retries of the request share the same ID
+	requestID := uuid.New().String()
+	req.Header.Set(requestIDHeader, requestID)
+	span.SetAttributes(spanAttributeRequestID.String(requestID))
+
+	// Propagate the trace context, e.g. to a reverse proxy in front of the server
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	logFields := map[string]interface{}{
+		LogFieldRequestID: requestID,
+		"method":          method,
+		"path":            reqURL.Path,
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystemHTTP, "Sending Vaultwarden API request", logFields)
+
+	// Send request
+	resp, err = httpClient.Do(req)
+	if err != nil {
+		logFields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, LogSubsystemHTTP, "Vaultwarden API request failed", logFields)
+		return nil, fmt.Errorf("failed to send request (request ID: %s): %w", requestID, err)
+	}
+	defer resp.Body.Close()
+
+	logFields["status"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, LogSubsystemHTTP, "Received Vaultwarden API response", logFields)
+
+	return c.handleResponse(resp, respBody)
+}
+
+// IgnoreDecryptionErrors reports whether values that cannot be decrypted should be
+// reported as warnings instead of errors
+func (c *Client) IgnoreDecryptionErrors() bool {
+	return c.ignoreDecryptionErrors
+}
+
+// encryptString encrypts the value with the symmetric key, verifying it in the configured safe mode
+func (c *Client) encryptString(value []byte, key symmetrickey.Key) (string, error) {
+	return crypt.EncryptAsStringWithSafeMode(value, key, c.cryptoSafeMode)
+}
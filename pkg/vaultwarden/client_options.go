@@ -0,0 +1,196 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/totp"
+	"go.opentelemetry.io/otel/trace"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ClientOption defines a function type for configuring the Client
+type ClientOption func(*Client) error
+
+// WithHTTPClient allows setting a custom HTTP client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("HTTP client cannot be nil")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithDialContext sets a custom function to establish connections to the Vaultwarden server,
+// e.g. to connect through an SSH tunnel. The HTTP client transport must be an *http.Transport.
+func WithDialContext(dialContext func(ctx context.Context, network, addr string) (net.Conn, error)) ClientOption {
+	return func(c *Client) error {
+		if dialContext == nil {
+			return fmt.Errorf("dial function cannot be nil")
+		}
+		c.dialContext = dialContext
+		return nil
+	}
+}
+
+// WithUnixSocket connects to the Vaultwarden server through the Unix domain socket at the given path
+// instead of over TCP. The endpoint is still used to build the request URLs and Host header.
+func WithUnixSocket(socketPath string) ClientOption {
+	if socketPath == "" {
+		return func(c *Client) error {
+			return fmt.Errorf("unix socket path cannot be empty")
+		}
+	}
+
+	var dialer net.Dialer
+	return WithDialContext(func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", socketPath)
+	})
+}
+
+// WithFailoverEndpoints adds endpoints of replicas of the Vaultwarden server, e.g. behind regional URLs, in
+// order of preference. When the active endpoint can't be reached, the client fails over to the first replica
+// that passes a health check and keeps using it until it fails as well. The replicas must share the database
+// and signing keys of the server, so that sessions stay valid.
+func WithFailoverEndpoints(endpoints ...string) ClientOption {
+	return func(c *Client) error {
+		for _, endpoint := range endpoints {
+			parsedURL, err := parseEndpoint(endpoint)
+			if err != nil {
+				return err
+			}
+			c.endpoints = append(c.endpoints, parsedURL)
+		}
+		return nil
+	}
+}
+
+// WithDeviceType sets a custom device type
+func WithDeviceType(deviceType string) ClientOption {
+	return func(c *Client) error {
+		if deviceType == "" {
+			return fmt.Errorf("device type cannot be empty")
+		}
+		c.DeviceInfo.DeviceType = deviceType
+		return nil
+	}
+}
+
+// WithDeviceName sets a custom device name
+func WithDeviceName(deviceName string) ClientOption {
+	return func(c *Client) error {
+		if deviceName == "" {
+			return fmt.Errorf("device name cannot be empty")
+		}
+		c.DeviceInfo.DeviceName = deviceName
+		return nil
+	}
+}
+
+// WithAdminToken sets the admin token for the client
+func WithAdminToken(token string) ClientOption {
+	return func(c *Client) error {
+		c.Credentials.AdminToken = token
+		return nil
+	}
+}
+
+// WithUserCredentials sets the email and master password for the client
+func WithUserCredentials(email, masterPassword string) ClientOption {
+	return func(c *Client) error {
+		c.Credentials.Email = email
+		c.Credentials.MasterPassword = masterPassword
+		return nil
+	}
+}
+
+// WithTOTPSecret sets the base32 encoded secret of the authenticator app provider, so that the user
+// can log in with the master password when two-step login is enabled
+func WithTOTPSecret(secret string) ClientOption {
+	return func(c *Client) error {
+		if _, err := totp.Code(secret, time.Now()); err != nil {
+			return fmt.Errorf("invalid TOTP secret: %w", err)
+		}
+		c.Credentials.TOTPSecret = secret
+		return nil
+	}
+}
+
+// WithOAuth2Credentials sets the client ID and secret for OAuth2 authentication
+func WithOAuth2Credentials(clientID, clientSecret string) ClientOption {
+	return func(c *Client) error {
+		c.Credentials.ClientID = clientID
+		c.Credentials.ClientSecret = clientSecret
+		return nil
+	}
+}
+
+// WithIgnoreDecryptionErrors configures whether values that cannot be decrypted
+// should be reported as warnings instead of errors
+func WithIgnoreDecryptionErrors(ignore bool) ClientOption {
+	return func(c *Client) error {
+		c.ignoreDecryptionErrors = ignore
+		return nil
+	}
+}
+
+// WithCryptoSafeMode configures whether every encrypted value is verified by decrypting it again, which is enabled
+// by default
+func WithCryptoSafeMode(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.cryptoSafeMode = enabled
+		return nil
+	}
+}
+
+// WithMetrics reports measurements of the requests, retries and logins of the client to the given metrics
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(c *Client) error {
+		if metrics == nil {
+			return fmt.Errorf("metrics cannot be nil")
+		}
+		c.metrics = metrics
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the decoded responses, DefaultMaxResponseSize by default. Responses
+// streamed to a writer, such as attachment downloads, aren't limited.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		if maxSize <= 0 {
+			return fmt.Errorf("maximum response size must be greater than zero")
+		}
+		c.maxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithTracerProvider traces the requests of the client with the given OpenTelemetry tracer provider instead of
+// the global one
+func WithTracerProvider(tracerProvider trace.TracerProvider) ClientOption {
+	return func(c *Client) error {
+		if tracerProvider == nil {
+			return fmt.Errorf("tracer provider cannot be nil")
+		}
+		c.tracerProvider = tracerProvider
+		return nil
+	}
+}
+
+// WithMiddleware adds middlewares wrapping the transport used for all requests.
+// Middlewares are applied in the order they are given, the first one being the outermost.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) error {
+		for _, mw := range middlewares {
+			if mw == nil {
+				return fmt.Errorf("middleware cannot be nil")
+			}
+		}
+		c.middlewares = append(c.middlewares, middlewares...)
+		return nil
+	}
+}
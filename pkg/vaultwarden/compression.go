@@ -0,0 +1,89 @@
+package vaultwarden
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressionMiddleware requests compressed responses and transparently decompresses gzip and
+// deflate encoded response bodies. This speeds up large payloads, such as sync or user listings,
+// over slow links. Requests that set their own Accept-Encoding header are passed through as is.
+func compressionMiddleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("Accept-Encoding") != "" {
+			return next.RoundTrip(req)
+		}
+
+		compressedReq := req.Clone(req.Context())
+		compressedReq.Header.Set("Accept-Encoding", "gzip, deflate")
+
+		resp, err := next.RoundTrip(compressedReq)
+		if err != nil || req.Method == http.MethodHead || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+			return resp, err
+		}
+
+		encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+		var decoder io.Reader
+		switch encoding {
+		case "gzip", "x-gzip":
+			gzipReader, err := gzip.NewReader(resp.Body)
+			if err != nil {
+				resp.Body.Close()
+				return nil, fmt.Errorf("failed to decompress gzip response: %w", err)
+			}
+			decoder = gzipReader
+		case "deflate":
+			deflateReader, err := newDeflateReader(resp.Body)
+			if err != nil {
+				resp.Body.Close()
+				return nil, fmt.Errorf("failed to decompress deflate response: %w", err)
+			}
+			decoder = deflateReader
+		default:
+			return resp, nil
+		}
+
+		// The decompressed length is unknown
+		resp.Body = &decompressedBody{Reader: decoder, body: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+
+		return resp, nil
+	})
+}
+
+// newDeflateReader returns a reader for a deflate encoded body. Although the deflate content
+// coding is defined as zlib-wrapped data, some servers send raw deflate data instead.
+func newDeflateReader(body io.Reader) (io.Reader, error) {
+	bufferedBody := bufio.NewReader(body)
+	header, err := bufferedBody.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	// A zlib header declares the deflate compression method and is a multiple of 31
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(bufferedBody)
+	}
+
+	return flate.NewReader(bufferedBody), nil
+}
+
+// decompressedBody reads the decompressed response body and closes the original one
+type decompressedBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+// Close closes the original response body
+func (b *decompressedBody) Close() error {
+	return b.body.Close()
+}
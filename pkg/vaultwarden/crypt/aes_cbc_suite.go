@@ -0,0 +1,116 @@
+package crypt
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+)
+
+// aesCbcSuite implements the AES-CBC encryption types, authenticated with HMAC-SHA256 if the suite has a MAC key
+type aesCbcSuite struct {
+	encType      symmetrickey.EncryptionType
+	encKeyLength int
+	macKeyLength int
+}
+
+func (s *aesCbcSuite) EncryptionType() symmetrickey.EncryptionType {
+	return s.encType
+}
+
+func (s *aesCbcSuite) ValidateKey(key *symmetrickey.Key) error {
+	if key.EncryptionType != s.encType {
+		return fmt.Errorf("bad encryption type: %d!=%d", key.EncryptionType, s.encType)
+	}
+
+	if len(key.EncryptionKey) != s.encKeyLength {
+		return fmt.Errorf("bad encryption key length: %d!=%d", len(key.EncryptionKey), s.encKeyLength)
+	}
+
+	if len(key.MacKey) != s.macKeyLength {
+		return fmt.Errorf("bad mac key length: %d!=%d", len(key.MacKey), s.macKeyLength)
+	}
+
+	return nil
+}
+
+func (s *aesCbcSuite) Encrypt(plainValue []byte, key *symmetrickey.Key) (*encryptedstring.EncryptedString, error) {
+	if err := s.ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	randomIV := make([]byte, 16)
+	if _, err := rand.Read(randomIV); err != nil {
+		return nil, fmt.Errorf("error generating random bytes: %w", err)
+	}
+
+	data, err := aes256Encode(plainValue, key.EncryptionKey, randomIV, 16)
+	if err != nil {
+		return nil, fmt.Errorf("error to aes256encoding data: %w", err)
+	}
+
+	var mac []byte
+	if s.macKeyLength > 0 {
+		mac = s.mac(randomIV, data, key.MacKey)
+	}
+
+	res := encryptedstring.New(randomIV, data, mac, *key)
+	return &res, nil
+}
+
+func (s *aesCbcSuite) Decrypt(encString *encryptedstring.EncryptedString, key *symmetrickey.Key) ([]byte, error) {
+	key, err := s.resolveLegacyKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	if s.macKeyLength > 0 {
+		if len(encString.Hmac) == 0 {
+			return nil, fmt.Errorf("hmac value is missing")
+		}
+
+		if len(encString.Hmac) != sha256.Size {
+			return nil, fmt.Errorf("bad hmac length: %d!=%d", len(encString.Hmac), sha256.Size)
+		}
+
+		// Compare in constant time and don't reveal the MAC values to avoid leaking information about the key
+		if !hmac.Equal(s.mac(encString.IV, encString.Data, key.MacKey), encString.Hmac) {
+			return nil, fmt.Errorf("hmac verification failed")
+		}
+	}
+
+	decData, err := aes256Decode(encString.Data, key.EncryptionKey, encString.IV)
+	if err != nil {
+		return nil, fmt.Errorf("error aes256Decoding: %w", err)
+	}
+	return decData, nil
+}
+
+// mac computes the HMAC-SHA256 of the IV and the encrypted data, without concatenating them first
+func (s *aesCbcSuite) mac(iv, data, macKey []byte) []byte {
+	h := hmac.New(sha256.New, macKey)
+	h.Write(iv)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// resolveLegacyKey returns the key to decrypt the value with. Values encrypted with the legacy
+// AesCbc128_HmacSha256_B64 scheme by old clients use a 256-bit key without MAC key, whose first
+// half is used as AES-128 encryption key and whose second half is used as MAC key.
+func (s *aesCbcSuite) resolveLegacyKey(key *symmetrickey.Key) (*symmetrickey.Key, error) {
+	if s.encType != symmetrickey.AesCbc128_HmacSha256_B64 || key.EncryptionType != symmetrickey.AesCbc256_B64 {
+		return key, nil
+	}
+
+	legacyKey, err := symmetrickey.NewFromRawBytesWithEncryptionType(key.Key, symmetrickey.AesCbc128_HmacSha256_B64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to use key for legacy AesCbc128_HmacSha256_B64 value: %w", err)
+	}
+	return legacyKey, nil
+}
@@ -0,0 +1,74 @@
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+)
+
+func pkcs5Unpadding(src []byte, blockSize int) ([]byte, error) {
+	srcLen := len(src)
+	if srcLen == 0 {
+		return nil, fmt.Errorf("bad padding: no data")
+	}
+
+	paddingLen := int(src[srcLen-1])
+	if paddingLen == 0 || paddingLen > blockSize || paddingLen > srcLen {
+		return nil, fmt.Errorf("bad padding size")
+	}
+
+	for _, b := range src[srcLen-paddingLen:] {
+		if int(b) != paddingLen {
+			return nil, fmt.Errorf("bad padding")
+		}
+	}
+	return src[:srcLen-paddingLen], nil
+}
+
+func aes256Decode(cipherText []byte, key []byte, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating new cipher block: %w", err)
+	}
+
+	// The cipher mode panics on input of bad lengths, reject it up front
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("bad IV length: %d!=%d", len(iv), block.BlockSize())
+	}
+	if len(cipherText) == 0 || len(cipherText)%block.BlockSize() != 0 {
+		return nil, fmt.Errorf("bad data length: %d is not a positive multiple of %d", len(cipherText), block.BlockSize())
+	}
+
+	plainText := make([]byte, len(cipherText))
+
+	mode := cipher.NewCBCDecrypter(block, iv)
+	mode.CryptBlocks(plainText, cipherText)
+
+	return pkcs5Unpadding(plainText, block.BlockSize())
+}
+
+// pkcs5Padding returns a padded copy of the plain text, the plain text itself is left untouched
+func pkcs5Padding(plainText []byte, blockSize int) []byte {
+	padding := blockSize - len(plainText)%blockSize
+	padded := make([]byte, len(plainText)+padding)
+	copy(padded, plainText)
+	for i := len(plainText); i < len(padded); i++ {
+		padded[i] = byte(padding)
+	}
+	return padded
+}
+
+func aes256Encode(plainText []byte, key []byte, iv []byte, blockSize int) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating new cipher block: %w", err)
+	}
+
+	// Encrypt the padded copy in place instead of allocating another buffer for the cipher text
+	cipherText := pkcs5Padding(plainText, blockSize)
+
+	mode := cipher.NewCBCEncrypter(block, iv)
+	mode.CryptBlocks(cipherText, cipherText)
+
+	return cipherText, nil
+}
@@ -0,0 +1,52 @@
+package crypt
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"sync"
+)
+
+// CipherSuite implements the symmetric encryption scheme of an encryption type
+type CipherSuite interface {
+	// EncryptionType returns the encryption type implemented by the suite
+	EncryptionType() symmetrickey.EncryptionType
+
+	// ValidateKey returns an error if the key cannot be used with the suite
+	ValidateKey(key *symmetrickey.Key) error
+
+	// Encrypt encrypts the value with the key
+	Encrypt(plainValue []byte, key *symmetrickey.Key) (*encryptedstring.EncryptedString, error)
+
+	// Decrypt decrypts the value with the key, verifying its integrity if supported by the scheme
+	Decrypt(encString *encryptedstring.EncryptedString, key *symmetrickey.Key) ([]byte, error)
+}
+
+var (
+	cipherSuitesMu sync.RWMutex
+
+	// cipherSuites contains the supported symmetric encryption types.
+	// Values of other types are reported with an UnsupportedEncryptionTypeError.
+	cipherSuites = map[symmetrickey.EncryptionType]CipherSuite{
+		symmetrickey.AesCbc256_B64:            &aesCbcSuite{encType: symmetrickey.AesCbc256_B64, encKeyLength: 32},
+		symmetrickey.AesCbc128_HmacSha256_B64: &aesCbcSuite{encType: symmetrickey.AesCbc128_HmacSha256_B64, encKeyLength: 16, macKeyLength: 16},
+		symmetrickey.AesCbc256_HmacSha256_B64: &aesCbcSuite{encType: symmetrickey.AesCbc256_HmacSha256_B64, encKeyLength: 32, macKeyLength: 32},
+	}
+)
+
+// RegisterCipherSuite registers the cipher suite of an encryption type, replacing any suite
+// registered before. The format of the type must be registered with encryptedstring.RegisterFormat as well.
+func RegisterCipherSuite(suite CipherSuite) {
+	cipherSuitesMu.Lock()
+	defer cipherSuitesMu.Unlock()
+
+	cipherSuites[suite.EncryptionType()] = suite
+}
+
+// LookupCipherSuite returns the cipher suite of an encryption type
+func LookupCipherSuite(encType symmetrickey.EncryptionType) (CipherSuite, bool) {
+	cipherSuitesMu.RLock()
+	defer cipherSuitesMu.RUnlock()
+
+	suite, ok := cipherSuites[encType]
+	return suite, ok
+}
@@ -2,46 +2,22 @@ package crypt
 
 import (
 	"bytes"
-	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha256"
 	"crypto/x509"
 	"fmt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/encryptedstring"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/helpers"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/symmetrickey"
-)
-
-var (
-	SafeMode = true
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
 )
 
+// Decrypt decrypts the value with the symmetric key, using the cipher suite of its encryption type
 func Decrypt(encString *encryptedstring.EncryptedString, key *symmetrickey.Key) ([]byte, error) {
-	if encString.Key.EncryptionType == symmetrickey.AesCbc128_HmacSha256_B64 && key.EncryptionType == symmetrickey.AesCbc256_B64 {
-		return nil, fmt.Errorf("unsupported old scheme")
-	}
-
-	if encString.Key.EncryptionType != key.EncryptionType {
-		return nil, fmt.Errorf("bad encryption type: %d!=%d", encString.Key.EncryptionType, key.EncryptionType)
-	}
-
-	if len(encString.Hmac) == 0 && len(key.MacKey) > 0 {
-		return nil, fmt.Errorf("hmac value is missing")
-	}
-
-	if len(encString.Hmac) != len(key.MacKey) {
-		return nil, fmt.Errorf("hmac lengths differ: %d!=%d", len(encString.Hmac), len(key.MacKey))
+	suite, ok := LookupCipherSuite(encString.Key.EncryptionType)
+	if !ok {
+		return nil, &encryptedstring.UnsupportedEncryptionTypeError{EncryptionType: encString.Key.EncryptionType}
 	}
 
-	computedHmac := helpers.HMACSum(append(append([]byte{}, encString.IV...), encString.Data...), key.MacKey, sha256.New)
-	if !bytes.Equal(computedHmac, encString.Hmac) {
-		return nil, fmt.Errorf("hmac comparison failed: %v != %v", computedHmac, encString.Hmac)
-	}
-	decData, err := aes256Decode(encString.Data, key.EncryptionKey, encString.IV)
-	if err != nil {
-		return nil, fmt.Errorf("error aes256Decoding: %w", err)
-	}
-	return decData, nil
+	return suite.Decrypt(encString, key)
 }
 
 func DecryptEncryptionKey(encryptedKeyStr string, key symmetrickey.Key) (*symmetrickey.Key, error) {
@@ -59,13 +35,14 @@ func DecryptEncryptionKey(encryptedKeyStr string, key symmetrickey.Key) (*symmet
 		}
 	case symmetrickey.AesCbc256_HmacSha256_B64:
 		newKey := key.StretchKey()
+		defer newKey.Zero()
 
 		decEncKey, err = Decrypt(encKeyCipher, &newKey)
 		if err != nil {
 			return nil, fmt.Errorf("error decrypting encryption key: %w", err)
 		}
 	default:
-		return nil, fmt.Errorf("unsupported encryption key type: %d", encKeyCipher.Key.EncryptionType)
+		return nil, fmt.Errorf("error decrypting encryption key: %w", &encryptedstring.UnsupportedEncryptionTypeError{EncryptionType: encKeyCipher.Key.EncryptionType})
 	}
 
 	encryptionKey, err := symmetrickey.NewFromRawBytes(decEncKey)
@@ -85,6 +62,7 @@ func DecryptPrivateKey(encryptedPrivateKeyStr string, encryptionKey symmetrickey
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt the private key using the provided encryption key: %w", err)
 	}
+	defer helpers.ZeroBytes(decryptedPrivateKey)
 
 	p, err := x509.ParsePKCS8PrivateKey(decryptedPrivateKey)
 	if err != nil {
@@ -99,39 +77,31 @@ func DecryptPrivateKey(encryptedPrivateKeyStr string, encryptionKey symmetrickey
 	return privateKey, nil
 }
 
+// Encrypt encrypts the value with the symmetric key, using the cipher suite of its encryption type. The encrypted
+// value is verified by decrypting it again.
 func Encrypt(plainValue []byte, key symmetrickey.Key) (*encryptedstring.EncryptedString, error) {
+	return EncryptWithSafeMode(plainValue, key, true)
+}
+
+// EncryptWithSafeMode encrypts the value with the symmetric key like Encrypt. Safe mode verifies the encrypted value by
+// decrypting it again, at the cost of doubling the cryptographic work.
+func EncryptWithSafeMode(plainValue []byte, key symmetrickey.Key, safeMode bool) (*encryptedstring.EncryptedString, error) {
 	if len(plainValue) == 0 {
 		return nil, fmt.Errorf("trying to encrypt nothing")
 	}
-	randomIV := make([]byte, 16)
-	_, err := rand.Read(randomIV)
-	if err != nil {
-		return nil, fmt.Errorf("error generating random bytes: %w", err)
-	}
 
-	if len(key.EncryptionKey) == 0 {
-		return nil, fmt.Errorf("no encryption key was provided: %v", key.EncryptionKey)
-	}
-	if len(randomIV) != 16 {
-		return nil, fmt.Errorf("bad IV length - expected 16, got: %d", len(randomIV))
+	suite, ok := LookupCipherSuite(key.EncryptionType)
+	if !ok {
+		return nil, &encryptedstring.UnsupportedEncryptionTypeError{EncryptionType: key.EncryptionType}
 	}
 
-	data, err := aes256Encode(plainValue, key.EncryptionKey, randomIV, 16)
+	res, err := suite.Encrypt(plainValue, &key)
 	if err != nil {
-		return nil, fmt.Errorf("error to aes256encoding data: %w", err)
+		return nil, err
 	}
 
-	// Create a new slice with zero length but enough capacity
-	iv := make([]byte, 0, 16+len(data))
-	iv = append(iv, randomIV...)
-	iv = append(iv, data...)
-
-	hmac := helpers.HMACSum(iv, key.MacKey, sha256.New)
-
-	res := encryptedstring.New(randomIV, data, hmac, key)
-
-	if SafeMode {
-		safeDecryptedValue, err := Decrypt(&res, &key)
+	if safeMode {
+		safeDecryptedValue, err := suite.Decrypt(res, &key)
 		if err != nil {
 			return nil, fmt.Errorf("error reversing decryption (safe mode): %w", err)
 		}
@@ -140,11 +110,16 @@ func Encrypt(plainValue []byte, key symmetrickey.Key) (*encryptedstring.Encrypte
 		}
 	}
 
-	return &res, nil
+	return res, nil
 }
 
 func EncryptAsString(plainValue []byte, key symmetrickey.Key) (string, error) {
-	res, err := Encrypt(plainValue, key)
+	return EncryptAsStringWithSafeMode(plainValue, key, true)
+}
+
+// EncryptAsStringWithSafeMode encrypts the value like EncryptWithSafeMode and returns its string representation
+func EncryptAsStringWithSafeMode(plainValue []byte, key symmetrickey.Key, safeMode bool) (string, error) {
+	res, err := EncryptWithSafeMode(plainValue, key, safeMode)
 	if err != nil {
 		return "", err
 	}
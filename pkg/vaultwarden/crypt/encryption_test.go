@@ -0,0 +1,275 @@
+package crypt
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"testing"
+)
+
+// testKeyBytes returns the bytes start, start+1, ... of the given length
+func testKeyBytes(start, length int) []byte {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = byte(start + i)
+	}
+	return b
+}
+
+// testVectors are computed independently with OpenSSL using the key bytes 0x00...0x3f
+// and the IV bytes 0xa0...0xaf, following the encryption scheme of the official clients
+var testVectors = []struct {
+	name      string
+	keyLength int
+	value     string
+	plainText string
+}{
+	{
+		name:      "AesCbc256_B64",
+		keyLength: 32,
+		value:     "0.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==",
+		plainText: "hello world",
+	},
+	{
+		name:      "AesCbc128_HmacSha256_B64 with legacy key",
+		keyLength: 32,
+		value:     "1.oKGio6SlpqeoqaqrrK2urw==|qLSomNdFrDl+yVWBoTf+iQ==|A8dnfUQcyM/jUIuAyquQ6rTNrSnIEf16X3j0ZXgrVl4=",
+		plainText: "legacy",
+	},
+	{
+		name:      "AesCbc256_HmacSha256_B64",
+		keyLength: 64,
+		value:     "2.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=",
+		plainText: "hello world",
+	},
+	{
+		name:      "AesCbc256_HmacSha256_B64 block sized",
+		keyLength: 64,
+		value:     "2.oKGio6SlpqeoqaqrrK2urw==|gq/CFiT93x9ngA0LnGuKsw==|s3HtSCZ/2Ezmt/LGd7DSwYrFQJXe0/u+egn1ndYW4xI=",
+		plainText: "Vaultwarden",
+	},
+}
+
+func TestDecryptTestVectors(t *testing.T) {
+	for _, tt := range testVectors {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, tt.keyLength))
+			if err != nil {
+				t.Fatalf("failed to create key: %v", err)
+			}
+
+			encString, err := encryptedstring.NewFromEncryptedValue(tt.value)
+			if err != nil {
+				t.Fatalf("failed to parse value: %v", err)
+			}
+
+			plainText, err := Decrypt(encString, key)
+			if err != nil {
+				t.Fatalf("failed to decrypt value: %v", err)
+			}
+			if string(plainText) != tt.plainText {
+				t.Fatalf("got %q, want %q", plainText, tt.plainText)
+			}
+		})
+	}
+}
+
+func TestDecryptTamperedValue(t *testing.T) {
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 64))
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+
+	for _, piece := range []string{"iv", "data", "hmac"} {
+		t.Run(piece, func(t *testing.T) {
+			encString, err := encryptedstring.NewFromEncryptedValue(testVectors[2].value)
+			if err != nil {
+				t.Fatalf("failed to parse value: %v", err)
+			}
+
+			switch piece {
+			case "iv":
+				encString.IV[0] ^= 1
+			case "data":
+				encString.Data[0] ^= 1
+			case "hmac":
+				encString.Hmac[0] ^= 1
+			}
+
+			if _, err := Decrypt(encString, key); err == nil {
+				t.Fatalf("expected an error when decrypting a tampered value")
+			}
+		})
+	}
+}
+
+func TestDecryptWrongKeyType(t *testing.T) {
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 32))
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+
+	encString, err := encryptedstring.NewFromEncryptedValue(testVectors[2].value)
+	if err != nil {
+		t.Fatalf("failed to parse value: %v", err)
+	}
+
+	if _, err := Decrypt(encString, key); err == nil {
+		t.Fatalf("expected an error when decrypting with a key of another type")
+	}
+}
+
+func TestDecryptUnsupportedEncryptionType(t *testing.T) {
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 64))
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+
+	encString, err := encryptedstring.NewFromEncryptedValue("4.AAECAw==")
+	if err != nil {
+		t.Fatalf("failed to parse value: %v", err)
+	}
+
+	_, err = Decrypt(encString, key)
+	var unsupportedErr *encryptedstring.UnsupportedEncryptionTypeError
+	if !errors.As(err, &unsupportedErr) {
+		t.Fatalf("expected an UnsupportedEncryptionTypeError, got: %v", err)
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	for _, keyLength := range []int{32, 64} {
+		key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, keyLength))
+		if err != nil {
+			t.Fatalf("failed to create key: %v", err)
+		}
+
+		for _, plainText := range []string{"a", "exactly 16 bytes", "a longer value spanning multiple AES blocks"} {
+			value, err := EncryptAsString([]byte(plainText), *key)
+			if err != nil {
+				t.Fatalf("failed to encrypt %q: %v", plainText, err)
+			}
+
+			encString, err := encryptedstring.NewFromEncryptedValue(value)
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", value, err)
+			}
+
+			decrypted, err := Decrypt(encString, key)
+			if err != nil {
+				t.Fatalf("failed to decrypt %q: %v", value, err)
+			}
+			if string(decrypted) != plainText {
+				t.Fatalf("got %q, want %q", decrypted, plainText)
+			}
+		}
+	}
+}
+
+func FuzzDecrypt(f *testing.F) {
+	for _, tt := range testVectors {
+		f.Add(tt.value, tt.keyLength)
+	}
+	f.Add("2.AAAAAAAAAAAAAAAAAAAAAA==|AA==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=", 64)
+
+	f.Fuzz(func(t *testing.T, value string, keyLength int) {
+		key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, keyLength&0x7f))
+		if err != nil {
+			return
+		}
+
+		encString, err := encryptedstring.NewFromEncryptedValue(value)
+		if err != nil {
+			return
+		}
+
+		// Malformed values must be reported as errors instead of panicking
+		_, _ = Decrypt(encString, key)
+	})
+}
+
+func FuzzDecryptEncryptionKey(f *testing.F) {
+	for _, tt := range testVectors {
+		f.Add(tt.value)
+	}
+	f.Add("4.AAECAw==")
+
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 32))
+	if err != nil {
+		f.Fatalf("failed to create key: %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		// Protected keys come from the server, malformed ones must be reported as errors
+		_, _ = DecryptEncryptionKey(value, *key)
+	})
+}
+
+func FuzzDecryptPrivateKey(f *testing.F) {
+	for _, tt := range testVectors {
+		f.Add(tt.value)
+	}
+
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 64))
+	if err != nil {
+		f.Fatalf("failed to create key: %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		_, _ = DecryptPrivateKey(value, *key)
+	})
+}
+
+func BenchmarkHashPassword(b *testing.B) {
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 32))
+	if err != nil {
+		b.Fatalf("failed to create key: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		HashPassword("correct horse battery staple", *key, false)
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 64))
+	if err != nil {
+		b.Fatalf("failed to create key: %v", err)
+	}
+	plainValue := make([]byte, 256)
+
+	for _, safeMode := range []bool{false, true} {
+		b.Run(fmt.Sprintf("SafeMode=%t", safeMode), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := EncryptAsStringWithSafeMode(plainValue, *key, safeMode); err != nil {
+					b.Fatalf("failed to encrypt: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	key, err := symmetrickey.NewFromRawBytes(testKeyBytes(0, 64))
+	if err != nil {
+		b.Fatalf("failed to create key: %v", err)
+	}
+	encrypted, err := EncryptAsString(make([]byte, 256), *key)
+	if err != nil {
+		b.Fatalf("failed to encrypt: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		encString, err := encryptedstring.NewFromEncryptedValue(encrypted)
+		if err != nil {
+			b.Fatalf("failed to parse: %v", err)
+		}
+		if _, err := Decrypt(encString, key); err != nil {
+			b.Fatalf("failed to decrypt: %v", err)
+		}
+	}
+}
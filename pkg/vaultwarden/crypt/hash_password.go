@@ -0,0 +1,23 @@
+package crypt
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func HashPassword(password string, key symmetrickey.Key, localAuthorization bool) string {
+	iterations := 1
+	if localAuthorization {
+		iterations = 2
+	}
+	passwordBytes := []byte(password)
+	defer helpers.ZeroBytes(passwordBytes)
+
+	derivedKey := pbkdf2.Key(key.Key, passwordBytes, iterations, 32, sha256.New)
+	defer helpers.ZeroBytes(derivedKey)
+
+	return base64.StdEncoding.EncodeToString(derivedKey)
+}
@@ -0,0 +1,31 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+)
+
+// GetDevices retrieves the devices the authenticated user has logged in with
+func (c *Client) GetDevices(ctx context.Context) ([]models.Device, error) {
+	devices, err := getAllPages[models.Device](ctx, c, "/api/devices")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// DeactivateDevice deactivates a device of the authenticated user, which has to log in again to be used
+func (c *Client) DeactivateDevice(ctx context.Context, deviceID string) error {
+	if deviceID == "" {
+		return fmt.Errorf("device ID is required")
+	}
+
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/devices/%s/deactivate", deviceID), nil, nil); err != nil {
+		return fmt.Errorf("failed to deactivate device: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+// Package vaultwarden implements a client for the API of Vaultwarden, the Bitwarden compatible server.
+// The client authenticates as a user, with the master password or an API key, and as an administrator
+// with the admin token. Like the official clients, it encrypts and decrypts the values of the vault
+// locally, the master password and the keys never leave the client.
+//
+// The subpackages provide the building blocks of the client:
+//
+//   - models contains the requests and responses of the API
+//   - crypt, keybuilder, symmetrickey and encryptedstring implement the cryptography of the vault
+//   - fakeserver implements an in-process fake of the server for tests
+//   - recorder records and replays the interactions with a server for tests
+//
+// Every request is traced as an OpenTelemetry client span, which includes the logins and retries the request
+// triggered. The spans are created with the global tracer provider unless one is passed with WithTracerProvider.
+//
+// The client is developed together with the Terraform provider and shares its releases. Breaking
+// changes to the exported API are listed in the changelog.
+package vaultwarden
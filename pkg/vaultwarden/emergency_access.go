@@ -0,0 +1,27 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+)
+
+// GetTrustedEmergencyAccess retrieves the emergency contacts the authenticated user has granted access to their vault
+func (c *Client) GetTrustedEmergencyAccess(ctx context.Context) ([]models.EmergencyAccess, error) {
+	grants, err := getAllPages[models.EmergencyAccess](ctx, c, "/api/emergency-access/trusted")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trusted emergency contacts: %w", err)
+	}
+
+	return grants, nil
+}
+
+// GetGrantedEmergencyAccess retrieves the users who have granted the authenticated user emergency access to their vault
+func (c *Client) GetGrantedEmergencyAccess(ctx context.Context) ([]models.EmergencyAccess, error) {
+	grants, err := getAllPages[models.EmergencyAccess](ctx, c, "/api/emergency-access/granted")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list granted emergency access: %w", err)
+	}
+
+	return grants, nil
+}
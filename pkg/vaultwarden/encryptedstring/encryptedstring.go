@@ -0,0 +1,156 @@
+package encryptedstring
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"strconv"
+	"strings"
+)
+
+const (
+	BUFFER_ENC_TYPE_LENGTH = 1
+	BUFFER_IV_LENGTH       = 16
+	BUFFER_MAC_LENGTH      = 32
+	BUFFER_MIN_DATA_LENGTH = 1
+)
+
+type EncryptedString struct {
+	IV   []byte
+	Data []byte
+	Hmac []byte
+	Key  symmetrickey.Key
+}
+
+func New(iv, data, hmac []byte, key symmetrickey.Key) EncryptedString {
+	return EncryptedString{
+		IV:   iv,
+		Key:  key,
+		Data: data,
+		Hmac: hmac,
+	}
+}
+
+func NewFromEncryptedValue(encryptedValue string) (*EncryptedString, error) {
+	if len(encryptedValue) == 0 {
+		return nil, fmt.Errorf("the provided encrypted value is empty")
+	}
+
+	var encPieces []string
+	encString := EncryptedString{}
+
+	if header, body, found := strings.Cut(encryptedValue, "."); found {
+		// Base64 doesn't use dots, so a second one can only belong to a malformed value
+		if strings.Contains(body, ".") {
+			return nil, fmt.Errorf("bad header: the value contains more than one encryption type separator")
+		}
+
+		encType, err := parseEncryptionType(header)
+		if err != nil {
+			return nil, err
+		}
+		encString.Key.EncryptionType = encType
+		encPieces = strings.Split(body, "|")
+	} else {
+		encPieces = strings.Split(encryptedValue, "|")
+		if len(encPieces) == 3 {
+			encString.Key.EncryptionType = symmetrickey.AesCbc128_HmacSha256_B64
+		} else {
+			encString.Key.EncryptionType = symmetrickey.AesCbc256_B64
+		}
+	}
+
+	format, ok := LookupFormat(encString.Key.EncryptionType)
+	if !ok {
+		return nil, &UnsupportedEncryptionTypeError{EncryptionType: encString.Key.EncryptionType}
+	}
+
+	// Check the number of pieces before indexing them below
+	if len(encPieces) != format.Pieces() {
+		return nil, fmt.Errorf("bad amount of pieces for %s (expected: %d, got: %d)", format.Name, format.Pieces(), len(encPieces))
+	}
+
+	// The pieces are ordered as IV, data and HMAC, the IV and HMAC are optional
+	if format.HasIV {
+		encString.IV = []byte(encPieces[0])
+		encPieces = encPieces[1:]
+	}
+	encString.Data = []byte(encPieces[0])
+	if format.HasHmac {
+		encString.Hmac = []byte(encPieces[1])
+	}
+
+	base64DecodedIV, err := base64.StdEncoding.DecodeString(string(encString.IV))
+	if err != nil {
+		return nil, fmt.Errorf("unable to base64 decode IV: %w", err)
+	}
+
+	base64DecodedData, err := base64.StdEncoding.DecodeString(string(encString.Data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to base64 decode data: %w", err)
+	}
+
+	base64DecodedMac, err := base64.StdEncoding.DecodeString(string(encString.Hmac))
+	if err != nil {
+		return nil, fmt.Errorf("unable to base64 decode hmac: %w", err)
+	}
+
+	// Reject values with pieces of bad lengths up front instead of failing with unclear errors during decryption
+	if format.HasIV && len(base64DecodedIV) != BUFFER_IV_LENGTH {
+		return nil, fmt.Errorf("bad IV length (expected: %d, got: %d)", BUFFER_IV_LENGTH, len(base64DecodedIV))
+	}
+
+	if len(base64DecodedData) < BUFFER_MIN_DATA_LENGTH {
+		return nil, fmt.Errorf("bad data length (expected at least: %d, got: %d)", BUFFER_MIN_DATA_LENGTH, len(base64DecodedData))
+	}
+
+	if format.HasHmac && len(base64DecodedMac) != BUFFER_MAC_LENGTH {
+		return nil, fmt.Errorf("bad hmac length (expected: %d, got: %d)", BUFFER_MAC_LENGTH, len(base64DecodedMac))
+	}
+
+	encString.IV = base64DecodedIV
+	encString.Data = base64DecodedData
+	encString.Hmac = base64DecodedMac
+	return &encString, nil
+}
+
+// maxHeaderLength limits the length of the encryption type header, so that errors about malformed
+// values don't echo arbitrary amounts of server provided data
+const maxHeaderLength = 3
+
+// parseEncryptionType parses the encryption type header of an encrypted value
+func parseEncryptionType(header string) (symmetrickey.EncryptionType, error) {
+	if len(header) == 0 {
+		return 0, fmt.Errorf("bad header: the encryption type is empty")
+	}
+	if len(header) > maxHeaderLength {
+		return 0, fmt.Errorf("bad header: the encryption type is longer than %d characters", maxHeaderLength)
+	}
+
+	encType, err := strconv.ParseInt(header, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse encryption type from header %q: %w", header, err)
+	}
+	return symmetrickey.EncryptionType(encType), nil
+}
+
+func (encString *EncryptedString) String() string {
+	base64EncodedIV := base64.StdEncoding.EncodeToString(encString.IV)
+	base64EncodedData := base64.StdEncoding.EncodeToString(encString.Data)
+	base64EncodedHmac := base64.StdEncoding.EncodeToString(encString.Hmac)
+
+	encType := fmt.Sprintf("%d", encString.Key.EncryptionType)
+
+	var encryptedString string
+	if len(encString.IV) > 0 {
+		encryptedString = encType + "." + base64EncodedIV + "|" + base64EncodedData
+	} else {
+		encryptedString = encType + "." + base64EncodedData
+	}
+
+	if len(encString.Hmac) > 0 {
+		encryptedString = encryptedString + "|" + base64EncodedHmac
+	}
+
+	return encryptedString
+}
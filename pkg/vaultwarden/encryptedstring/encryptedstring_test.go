@@ -0,0 +1,109 @@
+package encryptedstring
+
+import (
+	"bytes"
+	"errors"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"testing"
+)
+
+func TestNewFromEncryptedValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		encType  symmetrickey.EncryptionType
+		ivLen    int
+		dataLen  int
+		hmacLen  int
+		wantErr  bool
+		wantType bool
+	}{
+		{name: "AesCbc256_B64", value: "0.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==", encType: symmetrickey.AesCbc256_B64, ivLen: 16, dataLen: 16},
+		{name: "AesCbc128_HmacSha256_B64", value: "1.oKGio6SlpqeoqaqrrK2urw==|qLSomNdFrDl+yVWBoTf+iQ==|A8dnfUQcyM/jUIuAyquQ6rTNrSnIEf16X3j0ZXgrVl4=", encType: symmetrickey.AesCbc128_HmacSha256_B64, ivLen: 16, dataLen: 16, hmacLen: 32},
+		{name: "AesCbc256_HmacSha256_B64", value: "2.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=", encType: symmetrickey.AesCbc256_HmacSha256_B64, ivLen: 16, dataLen: 16, hmacLen: 32},
+		{name: "Rsa2048_OaepSha1_B64", value: "4.AAECAw==", encType: symmetrickey.Rsa2048_OaepSha1_B64, dataLen: 4},
+		{name: "Rsa2048_OaepSha256_HmacSha256_B64", value: "5.AAECAw==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=", encType: symmetrickey.Rsa2048_OaepSha256_HmacSha256_B64, dataLen: 4, hmacLen: 32},
+		{name: "headerless with HMAC", value: "oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=", encType: symmetrickey.AesCbc128_HmacSha256_B64, ivLen: 16, dataLen: 16, hmacLen: 32},
+		{name: "headerless without HMAC", value: "oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==", encType: symmetrickey.AesCbc256_B64, ivLen: 16, dataLen: 16},
+		{name: "empty", value: "", wantErr: true},
+		{name: "bad header", value: "x.AAECAw==", wantErr: true},
+		{name: "unknown type", value: "42.AAECAw==", wantErr: true, wantType: true},
+		{name: "negative type", value: "-1.AAECAw==", wantErr: true, wantType: true},
+		{name: "missing HMAC", value: "2.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==", wantErr: true},
+		{name: "too many pieces", value: "0.AA==|AA==|AA==", wantErr: true},
+		{name: "bad IV encoding", value: "0.!!|AA==", wantErr: true},
+		{name: "bad data encoding", value: "0.AA==|!!", wantErr: true},
+		{name: "bad HMAC encoding", value: "2.AA==|AA==|!!", wantErr: true},
+		{name: "bad IV length", value: "0.AAECAw==|2+YViIy6g2m8cdSbnLZNkg==", wantErr: true},
+		{name: "empty data", value: "4.", wantErr: true},
+		{name: "bad HMAC length", value: "2.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==|AAECAw==", wantErr: true},
+		{name: "multiple headers", value: "2.2.AA==|AA==|AA==", wantErr: true},
+		{name: "empty header", value: ".AAECAw==", wantErr: true},
+		{name: "long header", value: "0002.AAECAw==", wantErr: true},
+		{name: "trailing separator", value: "2.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=|", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encString, err := NewFromEncryptedValue(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				var unsupportedErr *UnsupportedEncryptionTypeError
+				if errors.As(err, &unsupportedErr) != tt.wantType {
+					t.Fatalf("unexpected error type: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if encString.Key.EncryptionType != tt.encType {
+				t.Errorf("encryption type: got %d, want %d", encString.Key.EncryptionType, tt.encType)
+			}
+			if len(encString.IV) != tt.ivLen {
+				t.Errorf("IV length: got %d, want %d", len(encString.IV), tt.ivLen)
+			}
+			if len(encString.Data) != tt.dataLen {
+				t.Errorf("data length: got %d, want %d", len(encString.Data), tt.dataLen)
+			}
+			if len(encString.Hmac) != tt.hmacLen {
+				t.Errorf("HMAC length: got %d, want %d", len(encString.Hmac), tt.hmacLen)
+			}
+		})
+	}
+}
+
+func FuzzNewFromEncryptedValue(f *testing.F) {
+	f.Add("0.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==")
+	f.Add("2.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=")
+	f.Add("4.AAECAw==")
+	f.Add("6.AAECAw==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=")
+	f.Add("oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==")
+	f.Add("")
+	f.Add("|")
+	f.Add(".")
+	f.Add("2.|||")
+	f.Add("-128.AA==")
+
+	f.Fuzz(func(t *testing.T, value string) {
+		encString, err := NewFromEncryptedValue(value)
+		if err != nil {
+			return
+		}
+
+		// Values that could be parsed must survive a round trip
+		reparsed, err := NewFromEncryptedValue(encString.String())
+		if err != nil {
+			t.Fatalf("failed to parse %q serialized from %q: %v", encString.String(), value, err)
+		}
+		if reparsed.Key.EncryptionType != encString.Key.EncryptionType ||
+			!bytes.Equal(reparsed.IV, encString.IV) ||
+			!bytes.Equal(reparsed.Data, encString.Data) ||
+			!bytes.Equal(reparsed.Hmac, encString.Hmac) {
+			t.Fatalf("round trip of %q changed the value", value)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package encryptedstring
+
+import (
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"sync"
+)
+
+// Format describes how values of an encryption type are encoded, i.e. which of the
+// |-separated pieces besides the data are present
+type Format struct {
+	Name    string
+	HasIV   bool
+	HasHmac bool
+}
+
+// Pieces returns the number of pieces of values encoded in this format
+func (f Format) Pieces() int {
+	pieces := 1
+	if f.HasIV {
+		pieces++
+	}
+	if f.HasHmac {
+		pieces++
+	}
+	return pieces
+}
+
+var (
+	formatsMu sync.RWMutex
+
+	// formats contains the known encryption types. Values of types that can be parsed
+	// but not decrypted, such as COSE encoded values, are still registered so that they
+	// can be reported as unsupported instead of as malformed.
+	formats = map[symmetrickey.EncryptionType]Format{
+		symmetrickey.AesCbc256_B64:                     {Name: "AesCbc256_B64", HasIV: true},
+		symmetrickey.AesCbc128_HmacSha256_B64:          {Name: "AesCbc128_HmacSha256_B64", HasIV: true, HasHmac: true},
+		symmetrickey.AesCbc256_HmacSha256_B64:          {Name: "AesCbc256_HmacSha256_B64", HasIV: true, HasHmac: true},
+		symmetrickey.Rsa2048_OaepSha256_B64:            {Name: "Rsa2048_OaepSha256_B64"},
+		symmetrickey.Rsa2048_OaepSha1_B64:              {Name: "Rsa2048_OaepSha1_B64"},
+		symmetrickey.Rsa2048_OaepSha256_HmacSha256_B64: {Name: "Rsa2048_OaepSha256_HmacSha256_B64", HasHmac: true},
+		symmetrickey.Rsa2048_OaepSha1_HmacSha256_B64:   {Name: "Rsa2048_OaepSha1_HmacSha256_B64", HasHmac: true},
+		symmetrickey.CoseEncrypt0:                      {Name: "CoseEncrypt0"},
+	}
+)
+
+// RegisterFormat registers the format of an encryption type, so that its values can be parsed
+func RegisterFormat(encType symmetrickey.EncryptionType, format Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	formats[encType] = format
+}
+
+// LookupFormat returns the format of an encryption type
+func LookupFormat(encType symmetrickey.EncryptionType) (Format, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	format, ok := formats[encType]
+	return format, ok
+}
+
+// UnsupportedEncryptionTypeError is returned for values of encryption types that the provider
+// can't parse or decrypt, e.g. because they were introduced by newer clients
+type UnsupportedEncryptionTypeError struct {
+	EncryptionType symmetrickey.EncryptionType
+}
+
+// Error returns the string representation of the error
+func (e *UnsupportedEncryptionTypeError) Error() string {
+	if format, ok := LookupFormat(e.EncryptionType); ok {
+		return fmt.Sprintf("unsupported encryption type %d (%s)", e.EncryptionType, format.Name)
+	}
+	return fmt.Sprintf("unsupported encryption type %d", e.EncryptionType)
+}
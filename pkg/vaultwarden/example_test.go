@@ -0,0 +1,41 @@
+package vaultwarden_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"log"
+)
+
+func Example() {
+	ctx := context.Background()
+
+	client, err := vaultwarden.New("https://vaultwarden.example.com",
+		vaultwarden.WithUserCredentials("user@example.com", "master-password"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The client logs in with the first request
+	profile, err := client.GetProfile(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Collection names are encrypted with the key of their organization
+	for _, org := range profile.Organizations {
+		collections, err := client.GetOrganizationCollections(ctx, org.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, collection := range collections.Data {
+			name, err := client.DecryptOrganizationString(ctx, org.ID, collection.Name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s: %s\n", org.Name, name)
+		}
+	}
+}
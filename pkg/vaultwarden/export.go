@@ -0,0 +1,135 @@
+package vaultwarden
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"slices"
+)
+
+// organizationExportResponse represents the response of the organization export endpoint. Depending on
+// the client version, the server returns the collections and items either as plain arrays or as lists.
+type organizationExportResponse struct {
+	Collections json.RawMessage `json:"collections"`
+	Ciphers     json.RawMessage `json:"ciphers"`
+}
+
+// EncryptedExport represents an encrypted export in the format of the Bitwarden clients. The collections
+// and items keep the values encrypted with the organization key, so they are exported as returned by the server.
+type EncryptedExport struct {
+	Encrypted     bool                         `json:"encrypted"`
+	KeyValidation string                       `json:"encKeyValidation_DO_NOT_EDIT"`
+	Collections   []EncryptedExportCollection  `json:"collections"`
+	Items         []map[string]json.RawMessage `json:"items"`
+}
+
+// EncryptedExportCollection represents a collection of an encrypted export
+type EncryptedExportCollection struct {
+	ID             string  `json:"id"`
+	OrganizationID string  `json:"organizationId"`
+	Name           string  `json:"name"`
+	ExternalID     *string `json:"externalId"`
+}
+
+// exportOmittedItemFields are the fields of items returned by the server which are not part of exports
+var exportOmittedItemFields = []string{"object", "edit", "viewPassword", "organizationUseTotp", "attachments", "permissions"}
+
+// decodeExportList decodes a list of the export response, which is either an array or a list object
+func decodeExportList[T any](raw json.RawMessage) ([]T, error) {
+	var items []T
+	if err := json.Unmarshal(raw, &items); err == nil {
+		return items, nil
+	}
+
+	var list listResponse[T]
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	return list.Data, nil
+}
+
+// ExportOrganization creates an encrypted export of the vault of an organization, which can be imported by the
+// Bitwarden and Vaultwarden clients of members of the organization. If collection IDs are given, only these
+// collections and the items assigned to them are exported.
+func (c *Client) ExportOrganization(ctx context.Context, orgID string, collectionIDs []string) (*EncryptedExport, error) {
+	// Get the organization key, loading it if necessary
+	orgSecret, err := c.GetOrganizationSecret(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var exportResp organizationExportResponse
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s/export", orgID), nil, &exportResp); err != nil {
+		return nil, fmt.Errorf("failed to export organization: %w", err)
+	}
+
+	collections, err := decodeExportList[models.Collection](exportResp.Collections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exported collections: %w", err)
+	}
+
+	ciphers, err := decodeExportList[map[string]json.RawMessage](exportResp.Ciphers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exported items: %w", err)
+	}
+
+	// The clients verify the key of an encrypted export by decrypting this value
+	keyValidation, err := c.encryptString([]byte(uuid.New().String()), orgSecret.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt export key validation: %w", err)
+	}
+
+	export := &EncryptedExport{
+		Encrypted:     true,
+		KeyValidation: keyValidation,
+		Collections:   []EncryptedExportCollection{},
+		Items:         []map[string]json.RawMessage{},
+	}
+
+	for _, collection := range collections {
+		if len(collectionIDs) > 0 && !slices.Contains(collectionIDs, collection.ID) {
+			continue
+		}
+
+		exported := EncryptedExportCollection{
+			ID:             collection.ID,
+			OrganizationID: orgID,
+			Name:           collection.Name,
+		}
+		if collection.ExternalID != "" {
+			exported.ExternalID = &collection.ExternalID
+		}
+		export.Collections = append(export.Collections, exported)
+	}
+
+	for _, cipher := range ciphers {
+		// Deleted items are not exported
+		if deletedDate, ok := cipher["deletedDate"]; ok && string(deletedDate) != "null" {
+			continue
+		}
+
+		if len(collectionIDs) > 0 {
+			var cipherCollectionIDs []string
+			if raw, ok := cipher["collectionIds"]; ok {
+				if err := json.Unmarshal(raw, &cipherCollectionIDs); err != nil {
+					return nil, fmt.Errorf("failed to decode collections of exported item: %w", err)
+				}
+			}
+
+			if !slices.ContainsFunc(cipherCollectionIDs, func(id string) bool { return slices.Contains(collectionIDs, id) }) {
+				continue
+			}
+		}
+
+		for _, field := range exportOmittedItemFields {
+			delete(cipher, field)
+		}
+		export.Items = append(export.Items, cipher)
+	}
+
+	return export, nil
+}
@@ -0,0 +1,176 @@
+package vaultwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// failoverStatusCodes are the responses of a reverse proxy in front of a Vaultwarden server that is unavailable
+var failoverStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// readOnlyPaths are the endpoints posted to which don't change the state of the server, other than issuing a token
+// or session, and can be sent again to another endpoint like GET requests
+var readOnlyPaths = []string{"/identity/accounts/prelogin", "/identity/connect/token", "/admin"}
+
+// endpointIndex returns the index of the endpoint the request URL was built from, preferring the longest
+// matching sub-path, or the active endpoint if none matches
+func (c *Client) endpointIndex(reqURL *url.URL) int {
+	index := -1
+	for i, endpoint := range c.endpoints {
+		if endpoint.Scheme != reqURL.Scheme || endpoint.Host != reqURL.Host || !strings.HasPrefix(reqURL.Path, endpoint.Path) {
+			continue
+		}
+		if index < 0 || len(endpoint.Path) > len(c.endpoints[index].Path) {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		c.endpointMu.RLock()
+		defer c.endpointMu.RUnlock()
+		return c.activeEndpoint
+	}
+	return index
+}
+
+// rebaseURL moves the request URL from one endpoint to another, preserving the path relative to the endpoint
+// and the query string
+func rebaseURL(reqURL, from, to *url.URL) (*url.URL, error) {
+	// Parse the joined URL again, so that the path is absolute even if the endpoint has no path
+	rebased, err := url.Parse(to.JoinPath(strings.TrimPrefix(reqURL.Path, from.Path)).String())
+	if err != nil {
+		return nil, err
+	}
+	rebased.RawQuery = reqURL.RawQuery
+	return rebased, nil
+}
+
+// shouldFailover reports whether the attempt failed because the endpoint is unavailable and can be sent again to
+// another endpoint. A proxy error or a connection lost mid-flight doesn't tell whether the server has applied the
+// request, so requests that change the state of the server are only failed over if the connection couldn't be
+// established at all. Requests canceled by the caller are not failed over.
+func (c *Client) shouldFailover(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil && req.Context().Err() != nil {
+		return false
+	}
+
+	if !c.isIdempotent(req) {
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+
+	if err != nil {
+		return true
+	}
+	return slices.Contains(failoverStatusCodes, resp.StatusCode)
+}
+
+// isIdempotent reports whether sending the request again has no other effect than sending it once
+func (c *Client) isIdempotent(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, c.endpoints[c.endpointIndex(req.URL)].Path)
+	return req.Method == http.MethodPost && slices.Contains(readOnlyPaths, "/"+strings.TrimPrefix(path, "/"))
+}
+
+// failoverMiddleware sends requests that failed because the endpoint is unavailable again to the first healthy
+// failover endpoint. Requests with streamed bodies are not retried as the body has already been consumed.
+func (c *Client) failoverMiddleware(next http.RoundTripper) http.RoundTripper {
+	if len(c.endpoints) < 2 {
+		return next
+	}
+
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if !c.shouldFailover(req, resp, err) || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		failed := c.endpointIndex(req.URL)
+		index, ok := c.failover(req.Context(), next, failed)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		retryReq := req.Clone(req.Context())
+		if retryReq.URL, err = rebaseURL(req.URL, c.endpoints[failed], c.endpoints[index]); err != nil {
+			return nil, fmt.Errorf("failed to fail over request: %w", err)
+		}
+		retryReq.Host = retryReq.URL.Host
+		if req.GetBody != nil {
+			if retryReq.Body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+		c.observeRetry(req, retryReasonFailover)
+
+		return next.RoundTrip(retryReq)
+	})
+}
+
+// failover makes the first healthy endpoint other than the failed one the active endpoint, in the configured order.
+// It returns the active endpoint right away if another request has already failed over.
+func (c *Client) failover(ctx context.Context, transport http.RoundTripper, failed int) (int, bool) {
+	c.failoverMu.Lock()
+	defer c.failoverMu.Unlock()
+
+	c.endpointMu.RLock()
+	active := c.activeEndpoint
+	c.endpointMu.RUnlock()
+	if active != failed {
+		return active, true
+	}
+
+	for i, endpoint := range c.endpoints {
+		if i == failed || !checkEndpointHealth(ctx, transport, endpoint) {
+			continue
+		}
+
+		tflog.SubsystemWarn(ctx, LogSubsystemHTTP, "Vaultwarden endpoint is unavailable, failing over", map[string]interface{}{
+			"failed_endpoint": c.endpoints[failed].String(),
+			"endpoint":        endpoint.String(),
+		})
+
+		c.endpointMu.Lock()
+		c.activeEndpoint = i
+		c.endpointMu.Unlock()
+
+		return i, true
+	}
+
+	tflog.SubsystemWarn(ctx, LogSubsystemHTTP, "Vaultwarden endpoint is unavailable and no failover endpoint is healthy", map[string]interface{}{
+		"failed_endpoint": c.endpoints[failed].String(),
+	})
+	return failed, false
+}
+
+// checkEndpointHealth reports whether the /alive endpoint of the server responds successfully
+func checkEndpointHealth(ctx context.Context, transport http.RoundTripper, endpoint *url.URL) bool {
+	ctx, cancel := context.WithTimeout(withAuthentication(ctx, false), DefaultHealthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.JoinPath("/alive").String(), nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
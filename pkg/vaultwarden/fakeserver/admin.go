@@ -0,0 +1,158 @@
+package fakeserver
+
+import (
+	"crypto/subtle"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// adminSessionLifetime is the lifetime of the admin sessions, Vaultwarden defaults to 20 minutes
+const adminSessionLifetime = 20 * time.Minute
+
+func (s *Server) registerAdminRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /admin", s.publicHandler(s.handleAdminLogin))
+	mux.HandleFunc("GET /admin/diagnostics/config", s.adminHandler(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"domain":              s.URL,
+			"signups_allowed":     true,
+			"invitations_allowed": true,
+			"smtp_host":           nil,
+			"admin_token":         "***",
+		})
+	}))
+	mux.HandleFunc("POST /admin/invite", s.adminHandler(s.handleAdminInvite))
+	mux.HandleFunc("GET /admin/users", s.adminHandler(func(w http.ResponseWriter, r *http.Request) {
+		users := make([]*user, 0, len(s.users))
+		for _, u := range s.users {
+			users = append(users, u)
+		}
+		slices.SortFunc(users, func(a, b *user) int { return strings.Compare(a.Email, b.Email) })
+
+		usersJSON := make([]models.User, 0, len(users))
+		for _, u := range users {
+			usersJSON = append(usersJSON, u.json(s.profileOrganizations(u)))
+		}
+		writeJSON(w, http.StatusOK, usersJSON)
+	}))
+	mux.HandleFunc("GET /admin/users/{userID}", s.adminHandler(func(w http.ResponseWriter, r *http.Request) {
+		if u, ok := s.adminUser(w, r); ok {
+			writeJSON(w, http.StatusOK, u.json(s.profileOrganizations(u)))
+		}
+	}))
+	mux.HandleFunc("GET /admin/users/by-mail/{email}", s.adminHandler(func(w http.ResponseWriter, r *http.Request) {
+		u := s.userByEmail(r.PathValue("email"))
+		if u == nil {
+			writeError(w, http.StatusNotFound, "User doesn't exist")
+			return
+		}
+		writeJSON(w, http.StatusOK, u.json(s.profileOrganizations(u)))
+	}))
+	mux.HandleFunc("POST /admin/users/{userID}/delete", s.adminHandler(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := s.adminUser(w, r)
+		if !ok {
+			return
+		}
+
+		for _, org := range s.organizations {
+			if m := org.member(u.ID); m != nil {
+				delete(org.Members, m.ID)
+			}
+		}
+		s.revokeTokens(u)
+		delete(s.users, u.ID)
+	}))
+	mux.HandleFunc("POST /admin/users/{userID}/deauth", s.adminHandler(func(w http.ResponseWriter, r *http.Request) {
+		if u, ok := s.adminUser(w, r); ok {
+			s.revokeTokens(u)
+			u.Devices = nil
+		}
+	}))
+	mux.HandleFunc("POST /admin/organizations/{orgID}/delete", s.adminHandler(func(w http.ResponseWriter, r *http.Request) {
+		if _, exists := s.organizations[r.PathValue("orgID")]; !exists {
+			writeError(w, http.StatusNotFound, "Organization doesn't exist")
+			return
+		}
+		s.deleteOrganization(r.PathValue("orgID"))
+	}))
+}
+
+// adminUser returns the user of the request path, writing an error response if it doesn't exist
+func (s *Server) adminUser(w http.ResponseWriter, r *http.Request) (*user, bool) {
+	u, exists := s.users[r.PathValue("userID")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "User doesn't exist")
+		return nil, false
+	}
+	return u, true
+}
+
+// revokeTokens invalidates the access tokens of the user, so the user has to log in again
+func (s *Server) revokeTokens(u *user) {
+	for token, userID := range s.tokens {
+		if userID == u.ID {
+			delete(s.tokens, token)
+		}
+	}
+}
+
+// RevokeTokens invalidates the access tokens of a user, e.g. to test that the client logs in again
+func (s *Server) RevokeTokens(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u, exists := s.users[userID]; exists {
+		s.revokeTokens(u)
+	}
+}
+
+func (s *Server) handleAdminLogin(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if s.AdminToken == "" || subtle.ConstantTimeCompare([]byte(r.PostForm.Get("token")), []byte(s.AdminToken)) != 1 {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Invalid admin token, please try again."))
+		return
+	}
+
+	session := randomString()
+	expires := time.Now().Add(adminSessionLifetime)
+	s.adminSessions[session] = expires
+
+	// Vaultwarden redirects back to the admin panel after logging in
+	http.SetCookie(w, &http.Cookie{
+		Name:     adminCookieName,
+		Value:    session,
+		Path:     "/admin",
+		Expires:  expires,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.Header().Set("Location", "/admin")
+	w.WriteHeader(http.StatusSeeOther)
+}
+
+func (s *Server) handleAdminInvite(w http.ResponseWriter, r *http.Request) {
+	var req models.User
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if s.userByEmail(req.Email) != nil {
+		writeError(w, http.StatusConflict, "User already exists")
+		return
+	}
+
+	// Vaultwarden names invited users after their email until they register
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	u := &user{ID: newID(), Email: email, Name: email}
+	s.users[u.ID] = u
+
+	writeJSON(w, http.StatusOK, u.json(nil))
+}
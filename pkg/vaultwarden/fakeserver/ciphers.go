@@ -0,0 +1,166 @@
+package fakeserver
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// cipherJSON returns a copy of the item with the given object type
+func cipherJSON(cipher *models.Cipher, object string) models.Cipher {
+	c := *cipher
+	if c.CollectionIDs == nil {
+		c.CollectionIDs = []string{}
+	}
+	c.Object = object
+	return c
+}
+
+// AddAttachment attaches a file to an item, as if it had been uploaded with the Vaultwarden clients. The file
+// name has to be encrypted with the key of the item. The content of the file is not stored.
+func (s *Server) AddAttachment(cipherID, fileName string, size int64) models.Attachment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cipher, exists := s.ciphers[cipherID]
+	if !exists {
+		s.t.Fatalf("item %s does not exist", cipherID)
+	}
+
+	attachment := models.Attachment{
+		ID:       newID(),
+		FileName: fileName,
+		Size:     strconv.FormatInt(size, 10),
+		SizeName: strconv.FormatInt(size, 10) + " Bytes",
+		Object:   "attachment",
+	}
+	attachment.URL = s.URL + "/attachments/" + cipherID + "/" + attachment.ID
+	cipher.Attachments = append(cipher.Attachments, attachment)
+
+	return attachment
+}
+
+// adminCipher returns the item if the user is an owner or admin of its organization, writing an error response otherwise
+func (s *Server) adminCipher(w http.ResponseWriter, r *http.Request, u *user) (*models.Cipher, bool) {
+	cipher, exists := s.ciphers[r.PathValue("cipherID")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "Cipher doesn't exist")
+		return nil, false
+	}
+
+	org, exists := s.organizations[cipher.OrganizationID]
+	if !exists || org.member(u.ID) == nil || !org.member(u.ID).isAdmin() {
+		writeError(w, http.StatusNotFound, "Cipher doesn't exist")
+		return nil, false
+	}
+
+	return cipher, true
+}
+
+// validCollections reports whether all collections belong to the organization, writing an error response otherwise
+func (s *Server) validCollections(w http.ResponseWriter, orgID string, collectionIDs []string) bool {
+	for _, id := range collectionIDs {
+		if c, exists := s.collections[id]; !exists || c.OrganizationID != orgID {
+			writeError(w, http.StatusBadRequest, "Invalid collection ID provided")
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) registerCipherRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/ciphers/create", s.userHandler(s.handleCreateCipher))
+	mux.HandleFunc("GET /api/ciphers/organization-details", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		r.SetPathValue("orgID", r.URL.Query().Get("organizationId"))
+		org, ok := s.adminOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		ciphers := []models.Cipher{}
+		for _, cipher := range s.ciphers {
+			if cipher.OrganizationID == org.ID {
+				ciphers = append(ciphers, cipherJSON(cipher, "cipherMiniDetails"))
+			}
+		}
+		sort.Slice(ciphers, func(i, j int) bool {
+			return ciphers[i].ID < ciphers[j].ID
+		})
+		writeList(s, w, r, ciphers)
+	}))
+	mux.HandleFunc("DELETE /api/ciphers/admin", s.userHandler(s.handleDeleteCiphersAdmin))
+	mux.HandleFunc("PUT /api/ciphers/{cipherID}/delete-admin", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		if cipher, ok := s.adminCipher(w, r, u); ok {
+			deletedDate := time.Now().UTC().Format(time.RFC3339Nano)
+			cipher.DeletedDate = &deletedDate
+		}
+	}))
+	mux.HandleFunc("GET /api/ciphers/{cipherID}/admin", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		if cipher, ok := s.adminCipher(w, r, u); ok {
+			writeJSON(w, http.StatusOK, cipherJSON(cipher, "cipherMiniDetails"))
+		}
+	}))
+	mux.HandleFunc("POST /api/ciphers/{cipherID}/collections-admin", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		cipher, ok := s.adminCipher(w, r, u)
+		if !ok {
+			return
+		}
+
+		var req struct {
+			CollectionIDs []string `json:"collectionIds"`
+		}
+		if !decodeJSON(w, r, &req) || !s.validCollections(w, cipher.OrganizationID, req.CollectionIDs) {
+			return
+		}
+
+		cipher.CollectionIDs = req.CollectionIDs
+		writeJSON(w, http.StatusOK, cipherJSON(cipher, "cipherMiniDetails"))
+	}))
+}
+
+func (s *Server) handleDeleteCiphersAdmin(w http.ResponseWriter, r *http.Request, u *user) {
+	var req struct {
+		IDs            []string `json:"ids"`
+		OrganizationID string   `json:"organizationId"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	r.SetPathValue("orgID", req.OrganizationID)
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	for _, id := range req.IDs {
+		if cipher, exists := s.ciphers[id]; exists && cipher.OrganizationID == org.ID {
+			delete(s.ciphers, id)
+		}
+	}
+}
+
+func (s *Server) handleCreateCipher(w http.ResponseWriter, r *http.Request, u *user) {
+	var req struct {
+		Cipher        models.Cipher `json:"cipher"`
+		CollectionIDs []string      `json:"collectionIds"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	r.SetPathValue("orgID", req.Cipher.OrganizationID)
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok || !s.validCollections(w, org.ID, req.CollectionIDs) {
+		return
+	}
+
+	cipher := req.Cipher
+	cipher.ID = newID()
+	cipher.CollectionIDs = req.CollectionIDs
+	s.ciphers[cipher.ID] = &cipher
+
+	writeJSON(w, http.StatusOK, cipherJSON(&cipher, "cipher"))
+}
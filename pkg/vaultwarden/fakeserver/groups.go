@@ -0,0 +1,157 @@
+package fakeserver
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"slices"
+)
+
+// groupJSON returns the representation of a group returned by the group endpoints
+func groupJSON(g *models.Group) models.Group {
+	group := *g
+	group.Users = nil
+	group.Object = "group"
+	return group
+}
+
+// groupOrganization returns the organization if the user is an owner or admin of it and groups are enabled,
+// writing an error response otherwise
+func (s *Server) groupOrganization(w http.ResponseWriter, r *http.Request, u *user) (*organization, bool) {
+	if !s.OrgGroupsEnabled {
+		writeError(w, http.StatusBadRequest, "Group support is disabled")
+		return nil, false
+	}
+
+	return s.adminOrganization(w, r, u)
+}
+
+// organizationGroup returns the group of the organization, writing an error response if it doesn't exist
+func organizationGroup(w http.ResponseWriter, r *http.Request, org *organization) (*models.Group, bool) {
+	g, exists := org.Groups[r.PathValue("groupID")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "Group not found")
+		return nil, false
+	}
+	return g, true
+}
+
+// groupMembers returns the IDs of the members of the group, which are still members of the organization
+func groupMembers(org *organization, g *models.Group) []string {
+	members := []string{}
+	for _, id := range g.Users {
+		if _, exists := org.Members[id]; exists {
+			members = append(members, id)
+		}
+	}
+	return members
+}
+
+func (s *Server) registerGroupRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/organizations/{orgID}/groups", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.groupOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		var req models.Group
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+
+		if req.Name == "" {
+			writeError(w, http.StatusBadRequest, "The field Name is required.")
+			return
+		}
+
+		g := &models.Group{
+			ID:             newID(),
+			OrganizationID: org.ID,
+			Name:           req.Name,
+			AccessAll:      req.AccessAll,
+			ExternalID:     req.ExternalID,
+			Collections:    req.Collections,
+			Users:          req.Users,
+		}
+		org.Groups[g.ID] = g
+
+		writeJSON(w, http.StatusOK, groupJSON(g))
+	}))
+	mux.HandleFunc("GET /api/organizations/{orgID}/groups/{groupID}", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.groupOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		if g, ok := organizationGroup(w, r, org); ok {
+			writeJSON(w, http.StatusOK, groupJSON(g))
+		}
+	}))
+	mux.HandleFunc("DELETE /api/organizations/{orgID}/groups/{groupID}", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.groupOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		if g, ok := organizationGroup(w, r, org); ok {
+			delete(org.Groups, g.ID)
+		}
+	}))
+	mux.HandleFunc("GET /api/organizations/{orgID}/groups/{groupID}/users", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.groupOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		if g, ok := organizationGroup(w, r, org); ok {
+			writeJSON(w, http.StatusOK, groupMembers(org, g))
+		}
+	}))
+	mux.HandleFunc("GET /api/organizations/{orgID}/users/{memberID}/groups", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.groupOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		memberID := r.PathValue("memberID")
+		if _, exists := org.Members[memberID]; !exists {
+			writeError(w, http.StatusNotFound, "The specified user isn't a member of the organization")
+			return
+		}
+
+		groupIDs := []string{}
+		for _, g := range org.Groups {
+			if slices.Contains(g.Users, memberID) {
+				groupIDs = append(groupIDs, g.ID)
+			}
+		}
+		slices.Sort(groupIDs)
+		writeJSON(w, http.StatusOK, groupIDs)
+	}))
+	mux.HandleFunc("PUT /api/organizations/{orgID}/groups/{groupID}/users", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.groupOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		g, ok := organizationGroup(w, r, org)
+		if !ok {
+			return
+		}
+
+		var req []string
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+
+		for _, id := range req {
+			if _, exists := org.Members[id]; !exists {
+				writeError(w, http.StatusNotFound, "User could not be found")
+				return
+			}
+		}
+
+		// Vaultwarden replaces all members of the group
+		slices.Sort(req)
+		g.Users = slices.Compact(req)
+	}))
+}
@@ -0,0 +1,417 @@
+package fakeserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/keybuilder"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// user represents an account of the fake server. Invited users don't have a password until they register.
+type user struct {
+	ID           string
+	Email        string
+	Name         string
+	PasswordHash string
+	Key          string
+	PublicKey    string
+	PrivateKey   string
+	APIKey       string
+	Kdf          models.KdfConfiguration
+	Devices      []models.Device
+
+	// Two-step login, the recovery code is generated when the first provider is enabled
+	Authenticator *authenticator
+	RecoveryCode  string
+}
+
+// json returns the representation of the user returned by the profile and admin endpoints
+func (u *user) json(organizations []models.Organization) models.User {
+	return models.User{
+		ID:               u.ID,
+		Name:             u.Name,
+		Email:            u.Email,
+		Key:              u.Key,
+		PrivateKey:       u.PrivateKey,
+		TwoFactorEnabled: u.Authenticator != nil && u.Authenticator.Enabled,
+		Organizations:    organizations,
+	}
+}
+
+// registered reports whether the user has set a master password
+func (u *user) registered() bool {
+	return u.PasswordHash != ""
+}
+
+// AddUser registers a user with the given master password and returns its ID. The keys of the user
+// are generated and encrypted like the official clients do on registration.
+func (s *Server) AddUser(email, masterPassword string) string {
+	s.t.Helper()
+
+	kdf := s.kdf
+	masterKey, err := keybuilder.BuildPreloginKey(masterPassword, email, &kdf)
+	if err != nil {
+		s.t.Fatalf("failed to build master key: %v", err)
+	}
+	defer masterKey.Zero()
+
+	userKey, encryptedUserKey, err := keybuilder.GenerateEncryptionKey(*masterKey)
+	if err != nil {
+		s.t.Fatalf("failed to generate user key: %v", err)
+	}
+	defer userKey.Zero()
+
+	publicKey, encryptedPrivateKey, err := keybuilder.GenerateEncryptedRSAKeyPair(*userKey)
+	if err != nil {
+		s.t.Fatalf("failed to generate key pair: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.userByEmail(email)
+	if u == nil {
+		u = &user{ID: newID(), Email: strings.ToLower(strings.TrimSpace(email))}
+		s.users[u.ID] = u
+	}
+	u.PasswordHash = crypt.HashPassword(masterPassword, *masterKey, false)
+	u.Key = encryptedUserKey
+	u.PublicKey = publicKey
+	u.PrivateKey = encryptedPrivateKey
+	u.APIKey = randomString()
+	u.Kdf = kdf
+
+	return u.ID
+}
+
+// UserAPIKey returns the OAuth2 client credentials of a user added with AddUser
+func (s *Server) UserAPIKey(userID string) (clientID, clientSecret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, exists := s.users[userID]
+	if !exists {
+		s.t.Fatalf("user %s does not exist", userID)
+	}
+
+	return "user." + u.ID, u.APIKey
+}
+
+// userByEmail returns the user with the given email, the caller has to hold the lock
+func (s *Server) userByEmail(email string) *user {
+	email = strings.ToLower(strings.TrimSpace(email))
+	for _, u := range s.users {
+		if u.Email == email {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) registerIdentityRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /alive", s.publicHandler(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, time.Now().UTC().Format(time.RFC3339))
+	}))
+	mux.HandleFunc("GET /api/version", s.publicHandler(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.Version)
+	}))
+	mux.HandleFunc("POST /identity/accounts/prelogin", s.publicHandler(s.handlePrelogin))
+	mux.HandleFunc("POST /identity/connect/token", s.publicHandler(s.handleToken))
+	mux.HandleFunc("POST /api/accounts/register", s.publicHandler(s.handleRegister))
+	mux.HandleFunc("GET /api/accounts/profile", s.userHandler(s.handleProfile))
+	mux.HandleFunc("POST /api/accounts/kdf", s.userHandler(s.handleChangeKdf))
+	mux.HandleFunc("GET /api/sync", s.userHandler(s.handleSync))
+	mux.HandleFunc("GET /api/devices", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		writeList(s, w, r, u.Devices)
+	}))
+	mux.HandleFunc("GET /api/users/{userID}/public-key", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		other, exists := s.users[r.PathValue("userID")]
+		if !exists || other.PublicKey == "" {
+			writeError(w, http.StatusNotFound, "User doesn't exist")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]string{
+			"userId":    other.ID,
+			"publicKey": other.PublicKey,
+			"object":    "userKey",
+		})
+	}))
+}
+
+func (s *Server) handlePrelogin(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email string `json:"email"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Unknown emails get the default configuration, so accounts cannot be enumerated
+	kdf := s.kdf
+	if u := s.userByEmail(req.Email); u != nil && u.registered() {
+		kdf = u.Kdf
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"kdf":            kdf.KdfType,
+		"kdfIterations":  kdf.KdfIterations,
+		"kdfMemory":      nilIfZero(kdf.KdfMemory),
+		"kdfParallelism": nilIfZero(kdf.KdfParallelism),
+	})
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, "invalid_request", err.Error())
+		return
+	}
+
+	var u *user
+	switch r.PostForm.Get("grant_type") {
+	case "password":
+		u = s.userByEmail(r.PostForm.Get("username"))
+		if u == nil || !u.registered() || u.PasswordHash != r.PostForm.Get("password") {
+			writeTokenError(w, "invalid_grant", "Username or password is incorrect. Try again")
+			return
+		}
+		if !s.verifyTwoFactor(w, r, u) {
+			return
+		}
+	case "client_credentials":
+		userID, found := strings.CutPrefix(r.PostForm.Get("client_id"), "user.")
+		u = s.users[userID]
+		if !found || u == nil || u.APIKey == "" || u.APIKey != r.PostForm.Get("client_secret") {
+			writeTokenError(w, "invalid_client", "Client ID or secret is incorrect. Try again")
+			return
+		}
+	default:
+		writeTokenError(w, "unsupported_grant_type", "Unsupported grant type")
+		return
+	}
+
+	s.registerDevice(u, r.PostForm.Get("deviceIdentifier"), r.PostForm.Get("deviceName"), r.PostForm.Get("deviceType"))
+
+	expiresAt := time.Now().Add(tokenLifetime)
+	token := s.issueToken(u, expiresAt)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"Kdf":                 u.Kdf.KdfType,
+		"KdfIterations":       u.Kdf.KdfIterations,
+		"kdfMemory":           nilIfZero(u.Kdf.KdfMemory),
+		"kdfParallelism":      nilIfZero(u.Kdf.KdfParallelism),
+		"Key":                 u.Key,
+		"PrivateKey":          u.PrivateKey,
+		"ResetMasterPassword": false,
+		"access_token":        token,
+		"expires_in":          int(tokenLifetime.Seconds()),
+		"refresh_token":       randomString(),
+		"scope":               "api offline_access",
+		"token_type":          "Bearer",
+		"unofficialServer":    true,
+	})
+}
+
+// issueToken issues an access token for the user, which is a JWT with the claims the client relies on.
+// The signature is not verified by the client, the fake only accepts the tokens it has issued.
+func (s *Server) issueToken(u *user, expiresAt time.Time) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"nbf":   time.Now().Unix(),
+		"exp":   expiresAt.Unix(),
+		"iss":   s.URL + "|login",
+		"sub":   u.ID,
+		"email": u.Email,
+		"name":  u.Name,
+	})
+
+	token := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims) + "." +
+		randomString()
+	s.tokens[token] = u.ID
+
+	return token
+}
+
+// registerDevice adds the device a user logs in with to the devices of the user
+func (s *Server) registerDevice(u *user, identifier, name, deviceType string) {
+	for _, device := range u.Devices {
+		if device.Identifier == identifier {
+			return
+		}
+	}
+
+	typeValue, _ := strconv.ParseInt(deviceType, 10, 64)
+
+	u.Devices = append(u.Devices, models.Device{
+		ID:           newID(),
+		Name:         name,
+		Type:         typeValue,
+		Identifier:   identifier,
+		CreationDate: time.Now().UTC().Format(time.RFC3339),
+		Object:       "device",
+	})
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email              string         `json:"email"`
+		MasterPasswordHash string         `json:"masterPasswordHash"`
+		Name               string         `json:"name"`
+		Key                string         `json:"key"`
+		Kdf                models.KdfType `json:"kdf"`
+		KdfIterations      int            `json:"kdfIterations"`
+		KdfMemory          int            `json:"kdfMemory"`
+		KdfParallelism     int            `json:"kdfParallelism"`
+		Keys               models.KeyPair `json:"keys"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	u := s.userByEmail(req.Email)
+	if u != nil && u.registered() {
+		writeError(w, http.StatusBadRequest, "Registration not allowed or user already exists")
+		return
+	}
+	if u == nil {
+		u = &user{ID: newID(), Email: strings.ToLower(strings.TrimSpace(req.Email))}
+		s.users[u.ID] = u
+	}
+
+	u.Name = req.Name
+	u.PasswordHash = req.MasterPasswordHash
+	u.Key = req.Key
+	u.PublicKey = req.Keys.PublicKey
+	u.PrivateKey = req.Keys.EncryptedPrivateKey
+	u.APIKey = randomString()
+	u.Kdf = models.KdfConfiguration{
+		KdfType:        req.Kdf,
+		KdfIterations:  req.KdfIterations,
+		KdfMemory:      req.KdfMemory,
+		KdfParallelism: req.KdfParallelism,
+	}
+
+	// Without mail delivery, Vaultwarden accepts the pending invitations of the user on registration
+	for _, org := range s.organizations {
+		if m := org.member(u.ID); m != nil && m.Status == models.UserOrgStatusInvited {
+			m.Status = models.UserOrgStatusAccepted
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"object": "register"})
+}
+
+func (s *Server) handleProfile(w http.ResponseWriter, r *http.Request, u *user) {
+	writeJSON(w, http.StatusOK, u.json(s.profileOrganizations(u)))
+}
+
+// handleChangeKdf replaces the KDF configuration, password hash and encrypted user key. Like Vaultwarden,
+// it enforces the minimum parameters and logs out all sessions of the user.
+func (s *Server) handleChangeKdf(w http.ResponseWriter, r *http.Request, u *user) {
+	var req struct {
+		Kdf                   models.KdfType `json:"kdf"`
+		KdfIterations         int            `json:"kdfIterations"`
+		KdfMemory             int            `json:"kdfMemory"`
+		KdfParallelism        int            `json:"kdfParallelism"`
+		MasterPasswordHash    string         `json:"masterPasswordHash"`
+		NewMasterPasswordHash string         `json:"newMasterPasswordHash"`
+		Key                   string         `json:"key"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.MasterPasswordHash != u.PasswordHash {
+		writeError(w, http.StatusBadRequest, "Invalid password")
+		return
+	}
+
+	switch req.Kdf {
+	case models.KdfTypePBKDF2_SHA256:
+		if req.KdfIterations < 100000 {
+			writeError(w, http.StatusBadRequest, "PBKDF2 KDF iterations must be at least 100000.")
+			return
+		}
+	case models.KdfTypeArgon2:
+		if req.KdfIterations < 1 || req.KdfMemory < 15 || req.KdfMemory > 1024 || req.KdfParallelism < 1 || req.KdfParallelism > 16 {
+			writeError(w, http.StatusBadRequest, "Argon2 KDF parameters are out of range.")
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "Unsupported KDF type")
+		return
+	}
+
+	u.PasswordHash = req.NewMasterPasswordHash
+	u.Key = req.Key
+	u.Kdf = models.KdfConfiguration{
+		KdfType:        req.Kdf,
+		KdfIterations:  req.KdfIterations,
+		KdfMemory:      req.KdfMemory,
+		KdfParallelism: req.KdfParallelism,
+	}
+	s.revokeTokens(u)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request, u *user) {
+	collections := []models.Collection{}
+	for _, c := range s.sortedCollections() {
+		if s.canAccessCollection(u, c) {
+			collections = append(collections, c.json("collectionDetails"))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, models.Sync{
+		Profile:     u.json(s.profileOrganizations(u)),
+		Collections: collections,
+		Object:      "sync",
+	})
+}
+
+// verifyTwoFactor checks the two-step login of a master password login, if the user has enabled it.
+// API key logins don't require two-step login, like with Vaultwarden.
+func (s *Server) verifyTwoFactor(w http.ResponseWriter, r *http.Request, u *user) bool {
+	if u.Authenticator == nil || !u.Authenticator.Enabled {
+		return true
+	}
+
+	token := r.PostForm.Get("twoFactorToken")
+	if token == "" || r.PostForm.Get("twoFactorProvider") != strconv.Itoa(int(models.TwoFactorTypeAuthenticator)) {
+		writeTokenError(w, "invalid_grant", "Two factor required.")
+		return false
+	}
+
+	if !u.Authenticator.verify(token) {
+		writeInvalidTOTPCode(w)
+		return false
+	}
+	return true
+}
+
+// writeTokenError writes an error response in the OAuth2 format of the identity endpoints
+func writeTokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"error":             code,
+		"error_description": description,
+		"ErrorModel": map[string]string{
+			"Message": description,
+			"Object":  "error",
+		},
+	})
+}
+
+// nilIfZero returns nil for zero values, which are encoded as null
+func nilIfZero(i int) interface{} {
+	if i == 0 {
+		return nil
+	}
+	return i
+}
@@ -0,0 +1,823 @@
+package fakeserver
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// organization represents an organization of the fake server
+type organization struct {
+	ID           string
+	Name         string
+	BillingEmail string
+	Identifier   string
+	PlanType     int64
+	Keys         models.KeyPair
+	Members      map[string]*member
+	Policies     map[models.PolicyType]*models.Policy
+	Groups       map[string]*models.Group
+	APIKey       string
+	APIKeyDate   time.Time
+	seq          int
+}
+
+// json returns the representation of the organization returned by the organization endpoints
+func (o *organization) json() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           o.ID,
+		"name":         o.Name,
+		"billingEmail": o.BillingEmail,
+		"identifier":   nilIfEmpty(o.Identifier),
+		"planType":     o.PlanType,
+		"enabled":      true,
+		"object":       "organization",
+	}
+}
+
+// organizationJSON returns the representation of the organization with the features, which Vaultwarden
+// enables for all organizations by its settings
+func (s *Server) organizationJSON(o *organization) map[string]interface{} {
+	org := o.json()
+	org["useGroups"] = s.OrgGroupsEnabled
+	org["useEvents"] = s.OrgEventsEnabled
+	org["useApi"] = true
+	org["useDirectory"] = false
+	return org
+}
+
+// member returns the membership of the user in the organization
+func (o *organization) member(userID string) *member {
+	for _, m := range o.Members {
+		if m.UserID == userID {
+			return m
+		}
+	}
+	return nil
+}
+
+// member represents the membership of a user in an organization
+type member struct {
+	ID          string
+	UserID      string
+	Status      models.UserOrgStatus
+	Type        models.UserOrgType
+	AccessAll   bool
+	Permissions *models.OrganizationUserPermissions
+	Collections []models.CollectionAccess
+	Key         string
+	seq         int
+
+	// restoreStatus is the status of a revoked member to restore, Vaultwarden keeps it while the member is revoked
+	restoreStatus models.UserOrgStatus
+}
+
+// isAdmin reports whether the member is a confirmed owner or admin of the organization
+func (m *member) isAdmin() bool {
+	return m.Status == models.UserOrgStatusConfirmed && (m.Type == models.UserOrgTypeOwner || m.Type == models.UserOrgTypeAdmin)
+}
+
+// keepsOwner reports whether the organization still has a confirmed owner after the given members are removed or
+// demoted, which Vaultwarden requires
+func (o *organization) keepsOwner(memberIDs ...string) bool {
+	removesOwner := false
+	for _, m := range o.Members {
+		if m.Status != models.UserOrgStatusConfirmed || m.Type != models.UserOrgTypeOwner {
+			continue
+		}
+		if !slices.Contains(memberIDs, m.ID) {
+			return true
+		}
+		removesOwner = true
+	}
+	return !removesOwner
+}
+
+// ConfirmMember confirms the membership of a user in an organization, as if the user had accepted the invitation
+// and an owner had confirmed it
+func (s *Server) ConfirmMember(orgID, email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, exists := s.organizations[orgID]
+	u := s.userByEmail(email)
+	if !exists || u == nil || org.member(u.ID) == nil {
+		s.t.Fatalf("user %s is not a member of organization %s", email, orgID)
+	}
+
+	org.member(u.ID).Status = models.UserOrgStatusConfirmed
+}
+
+// memberJSON represents a member as returned by the organization user endpoints
+type memberJSON struct {
+	models.OrganizationUserDetails
+	Name   string `json:"name"`
+	Object string `json:"object"`
+}
+
+// collection represents a collection of an organization. The name is encrypted with the organization key.
+type collection struct {
+	ID             string
+	OrganizationID string
+	Name           string
+	ExternalID     string
+	seq            int
+}
+
+// json returns the representation of the collection with the given object type
+func (c *collection) json(object string) models.Collection {
+	return models.Collection{
+		ID:             c.ID,
+		OrganizationID: c.OrganizationID,
+		ExternalID:     c.ExternalID,
+		Name:           c.Name,
+		Groups:         []string{},
+		Users:          []string{},
+		Object:         object,
+	}
+}
+
+// profileOrganizations returns the organizations the user is a confirmed member of, with the organization
+// key encrypted with the public key of the user
+func (s *Server) profileOrganizations(u *user) []models.Organization {
+	organizations := []models.Organization{}
+	for _, org := range s.sortedOrganizations() {
+		m := org.member(u.ID)
+		if m == nil || m.Status != models.UserOrgStatusConfirmed {
+			continue
+		}
+
+		organizations = append(organizations, models.Organization{
+			ID:       org.ID,
+			Name:     org.Name,
+			Key:      m.Key,
+			PlanType: org.PlanType,
+			Enabled:  true,
+		})
+	}
+	return organizations
+}
+
+// canAccessCollection reports whether the user has access to the collection
+func (s *Server) canAccessCollection(u *user, c *collection) bool {
+	org, exists := s.organizations[c.OrganizationID]
+	if !exists {
+		return false
+	}
+
+	m := org.member(u.ID)
+	if m == nil || m.Status != models.UserOrgStatusConfirmed {
+		return false
+	}
+
+	return m.AccessAll || m.isAdmin() || slices.ContainsFunc(m.Collections, func(access models.CollectionAccess) bool {
+		return access.ID == c.ID
+	})
+}
+
+// sortedOrganizations returns the organizations in the order they were created
+func (s *Server) sortedOrganizations() []*organization {
+	organizations := make([]*organization, 0, len(s.organizations))
+	for _, org := range s.organizations {
+		organizations = append(organizations, org)
+	}
+	sort.SliceStable(organizations, func(i, j int) bool {
+		return organizations[i].seq < organizations[j].seq
+	})
+	return organizations
+}
+
+// sortedCollections returns the collections in the order they were created
+func (s *Server) sortedCollections() []*collection {
+	collections := make([]*collection, 0, len(s.collections))
+	for _, c := range s.collections {
+		collections = append(collections, c)
+	}
+	sort.SliceStable(collections, func(i, j int) bool {
+		return collections[i].seq < collections[j].seq
+	})
+	return collections
+}
+
+// sortedMembers returns the members of the organization in the order they joined
+func sortedMembers(org *organization) []*member {
+	members := make([]*member, 0, len(org.Members))
+	for _, m := range org.Members {
+		members = append(members, m)
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].seq < members[j].seq
+	})
+	return members
+}
+
+// memberJSON returns the representation of a member
+func (s *Server) memberJSON(m *member) memberJSON {
+	u := s.users[m.UserID]
+
+	collections := m.Collections
+	if collections == nil {
+		collections = []models.CollectionAccess{}
+	}
+
+	return memberJSON{
+		OrganizationUserDetails: models.OrganizationUserDetails{
+			ID:          m.ID,
+			UserID:      u.ID,
+			Email:       u.Email,
+			Status:      m.Status,
+			Type:        m.Type,
+			AccessAll:   m.AccessAll,
+			Permissions: m.Permissions,
+			Collections: collections,
+		},
+		Name:   u.Name,
+		Object: "organizationUserUserDetails",
+	}
+}
+
+// memberOrganization returns the organization if the user is a confirmed member of it, writing an error response otherwise.
+// Like Vaultwarden, organizations the user isn't a member of are reported with 401.
+func (s *Server) memberOrganization(w http.ResponseWriter, r *http.Request, u *user) (*organization, bool) {
+	org, exists := s.organizations[r.PathValue("orgID")]
+	if !exists {
+		writeError(w, http.StatusUnauthorized, "The current user isn't member of the organization")
+		return nil, false
+	}
+
+	m := org.member(u.ID)
+	if m == nil || m.Status != models.UserOrgStatusConfirmed {
+		writeError(w, http.StatusUnauthorized, "The current user isn't member of the organization")
+		return nil, false
+	}
+
+	return org, true
+}
+
+// adminOrganization returns the organization if the user is an owner or admin of it, writing an error response otherwise
+func (s *Server) adminOrganization(w http.ResponseWriter, r *http.Request, u *user) (*organization, bool) {
+	org, ok := s.memberOrganization(w, r, u)
+	if !ok {
+		return nil, false
+	}
+
+	if !org.member(u.ID).isAdmin() {
+		writeError(w, http.StatusUnauthorized, "You need to be Admin or Owner to call this endpoint")
+		return nil, false
+	}
+
+	return org, true
+}
+
+// deleteOrganization deletes the organization with its collections and items
+func (s *Server) deleteOrganization(orgID string) {
+	delete(s.organizations, orgID)
+
+	for id, c := range s.collections {
+		if c.OrganizationID == orgID {
+			delete(s.collections, id)
+		}
+	}
+
+	for id, cipher := range s.ciphers {
+		if cipher.OrganizationID == orgID {
+			delete(s.ciphers, id)
+		}
+	}
+}
+
+func (s *Server) registerOrganizationRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/organizations", s.userHandler(s.handleCreateOrganization))
+	mux.HandleFunc("GET /api/organizations/{orgID}", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		if org, ok := s.memberOrganization(w, r, u); ok {
+			writeJSON(w, http.StatusOK, s.organizationJSON(org))
+		}
+	}))
+	mux.HandleFunc("PUT /api/organizations/{orgID}", s.userHandler(s.handleUpdateOrganization))
+	mux.HandleFunc("DELETE /api/organizations/{orgID}", s.userHandler(s.handleDeleteOrganization))
+	mux.HandleFunc("GET /api/organizations/{orgID}/public-key", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		if org, ok := s.memberOrganization(w, r, u); ok {
+			writeJSON(w, http.StatusOK, map[string]string{
+				"publicKey": org.Keys.PublicKey,
+				"object":    "organizationPublicKey",
+			})
+		}
+	}))
+	mux.HandleFunc("POST /api/organizations/{orgID}/api-key", s.userHandler(s.handleOrganizationAPIKey))
+	mux.HandleFunc("POST /api/organizations/{orgID}/rotate-api-key", s.userHandler(s.handleOrganizationAPIKey))
+	mux.HandleFunc("GET /api/organizations/{orgID}/api-key-information/{type}", s.userHandler(s.handleOrganizationAPIKeyInformation))
+
+	mux.HandleFunc("GET /api/organizations/{orgID}/collections", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.memberOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		collections := []models.Collection{}
+		for _, c := range s.sortedCollections() {
+			if c.OrganizationID == org.ID {
+				collections = append(collections, c.json("collection"))
+			}
+		}
+		writeList(s, w, r, collections)
+	}))
+	mux.HandleFunc("POST /api/organizations/{orgID}/collections", s.userHandler(s.handleCreateCollection))
+	mux.HandleFunc("PUT /api/organizations/{orgID}/collections/{collectionID}", s.userHandler(s.handleUpdateCollection))
+	mux.HandleFunc("DELETE /api/organizations/{orgID}/collections/{collectionID}", s.userHandler(s.handleDeleteCollection))
+
+	mux.HandleFunc("GET /api/organizations/{orgID}/users", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.memberOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		members := []memberJSON{}
+		for _, m := range sortedMembers(org) {
+			members = append(members, s.memberJSON(m))
+		}
+		writeList(s, w, r, members)
+	}))
+	mux.HandleFunc("DELETE /api/organizations/{orgID}/users", s.userHandler(s.handleDeleteMembers))
+	mux.HandleFunc("POST /api/organizations/{orgID}/users/invite", s.userHandler(s.handleInviteMembers))
+	mux.HandleFunc("GET /api/organizations/{orgID}/users/{memberID}", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.adminOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		m, exists := org.Members[r.PathValue("memberID")]
+		if !exists {
+			writeError(w, http.StatusNotFound, "The specified user isn't a member of the organization")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.memberJSON(m))
+	}))
+	mux.HandleFunc("PUT /api/organizations/{orgID}/users/{memberID}", s.userHandler(s.handleUpdateMember))
+	mux.HandleFunc("POST /api/organizations/{orgID}/users/{memberID}/reinvite", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.adminOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		m, exists := org.Members[r.PathValue("memberID")]
+		if !exists {
+			writeError(w, http.StatusNotFound, "The user hasn't been invited to the organization.")
+			return
+		}
+		if m.Status != models.UserOrgStatusInvited {
+			writeError(w, http.StatusBadRequest, "The user is already accepted or confirmed to the organization")
+			return
+		}
+	}))
+	mux.HandleFunc("POST /api/organizations/{orgID}/users/{memberID}/confirm", s.userHandler(s.handleConfirmMember))
+	mux.HandleFunc("PUT /api/organizations/{orgID}/users/{memberID}/revoke", s.userHandler(s.handleRevokeMember))
+	mux.HandleFunc("PUT /api/organizations/{orgID}/users/{memberID}/restore", s.userHandler(s.handleRestoreMember))
+	mux.HandleFunc("DELETE /api/organizations/{orgID}/users/{memberID}", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.adminOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		if _, exists := org.Members[r.PathValue("memberID")]; !exists {
+			writeError(w, http.StatusNotFound, "User to delete isn't member of the organization")
+			return
+		}
+		if !org.keepsOwner(r.PathValue("memberID")) {
+			writeError(w, http.StatusBadRequest, "Can't delete the last owner")
+			return
+		}
+		delete(org.Members, r.PathValue("memberID"))
+	}))
+}
+
+func (s *Server) handleCreateOrganization(w http.ResponseWriter, r *http.Request, u *user) {
+	var req models.Organization
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "The field Name is required.")
+		return
+	}
+	if req.Key == "" || req.Keys.PublicKey == "" || req.Keys.EncryptedPrivateKey == "" {
+		writeError(w, http.StatusBadRequest, "The organization keys are required.")
+		return
+	}
+
+	org := &organization{
+		ID:           newID(),
+		Name:         req.Name,
+		BillingEmail: req.BillingEmail,
+		PlanType:     req.PlanType,
+		Keys:         req.Keys,
+		Members:      make(map[string]*member),
+		Policies:     make(map[models.PolicyType]*models.Policy),
+		Groups:       make(map[string]*models.Group),
+		seq:          s.nextSeq(),
+	}
+
+	// The creator becomes the owner, the organization key is encrypted with their public key
+	owner := &member{
+		ID:        newID(),
+		UserID:    u.ID,
+		Status:    models.UserOrgStatusConfirmed,
+		Type:      models.UserOrgTypeOwner,
+		AccessAll: true,
+		Key:       req.Key,
+		seq:       s.nextSeq(),
+	}
+	org.Members[owner.ID] = owner
+	s.organizations[org.ID] = org
+
+	if req.CollectionName != "" {
+		c := &collection{ID: newID(), OrganizationID: org.ID, Name: req.CollectionName, seq: s.nextSeq()}
+		s.collections[c.ID] = c
+	}
+
+	writeJSON(w, http.StatusOK, s.organizationJSON(org))
+}
+
+func (s *Server) handleUpdateOrganization(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	var req models.Organization
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "The field Name is required.")
+		return
+	}
+
+	org.Name = req.Name
+	org.BillingEmail = req.BillingEmail
+	org.Identifier = req.Identifier
+
+	writeJSON(w, http.StatusOK, s.organizationJSON(org))
+}
+
+func (s *Server) handleDeleteOrganization(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		MasterPasswordHash string `json:"masterPasswordHash"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.MasterPasswordHash != u.PasswordHash {
+		writeError(w, http.StatusBadRequest, "Invalid password")
+		return
+	}
+
+	s.deleteOrganization(org.ID)
+}
+
+func (s *Server) handleOrganizationAPIKey(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		MasterPasswordHash string `json:"masterPasswordHash"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.MasterPasswordHash != u.PasswordHash {
+		writeError(w, http.StatusBadRequest, "Invalid password")
+		return
+	}
+
+	// Like Vaultwarden, the API key is generated the first time it is requested, or again when it is rotated
+	if org.APIKey == "" || strings.HasSuffix(r.URL.Path, "/rotate-api-key") {
+		org.APIKey = randomString()
+		org.APIKeyDate = time.Now()
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"apiKey":       org.APIKey,
+		"revisionDate": org.APIKeyDate.UTC().Format(time.RFC3339Nano),
+		"object":       "apiKey",
+	})
+}
+
+// handleOrganizationAPIKeyInformation lists the API key of the organization without generating it, like the Bitwarden API
+func (s *Server) handleOrganizationAPIKeyInformation(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	keys := []map[string]interface{}{}
+	if org.APIKey != "" && r.PathValue("type") == "0" {
+		keys = append(keys, map[string]interface{}{
+			"keyType":      0,
+			"revisionDate": org.APIKeyDate.UTC().Format(time.RFC3339Nano),
+			"object":       "organizationApiKeyInformation",
+		})
+	}
+
+	writeList(s, w, r, keys)
+}
+
+func (s *Server) handleCreateCollection(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	var req models.Collection
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "The field Name is required.")
+		return
+	}
+
+	c := &collection{
+		ID:             newID(),
+		OrganizationID: org.ID,
+		Name:           req.Name,
+		ExternalID:     req.ExternalID,
+		seq:            s.nextSeq(),
+	}
+	s.collections[c.ID] = c
+
+	writeJSON(w, http.StatusOK, c.json("collection"))
+}
+
+// organizationCollection returns the collection of the organization, writing an error response if it doesn't exist
+func (s *Server) organizationCollection(w http.ResponseWriter, r *http.Request, org *organization) (*collection, bool) {
+	c, exists := s.collections[r.PathValue("collectionID")]
+	if !exists || c.OrganizationID != org.ID {
+		writeError(w, http.StatusNotFound, "Collection not found")
+		return nil, false
+	}
+	return c, true
+}
+
+func (s *Server) handleUpdateCollection(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	c, ok := s.organizationCollection(w, r, org)
+	if !ok {
+		return
+	}
+
+	var req models.Collection
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "The field Name is required.")
+		return
+	}
+
+	c.Name = req.Name
+	c.ExternalID = req.ExternalID
+
+	writeJSON(w, http.StatusOK, c.json("collection"))
+}
+
+func (s *Server) handleDeleteCollection(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	c, ok := s.organizationCollection(w, r, org)
+	if !ok {
+		return
+	}
+
+	delete(s.collections, c.ID)
+
+	// Remove the collection from the members and items it was assigned to
+	for _, m := range org.Members {
+		m.Collections = slices.DeleteFunc(m.Collections, func(access models.CollectionAccess) bool {
+			return access.ID == c.ID
+		})
+	}
+	for _, cipher := range s.ciphers {
+		cipher.CollectionIDs = slices.DeleteFunc(cipher.CollectionIDs, func(id string) bool {
+			return id == c.ID
+		})
+	}
+}
+
+func (s *Server) handleInviteMembers(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Emails      []string                            `json:"emails"`
+		Collections []models.CollectionAccess           `json:"collections"`
+		AccessAll   bool                                `json:"accessAll"`
+		Type        models.UserOrgType                  `json:"type"`
+		Permissions *models.OrganizationUserPermissions `json:"permissions"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	for _, email := range req.Emails {
+		invited := s.userByEmail(email)
+		if invited != nil && org.member(invited.ID) != nil {
+			writeError(w, http.StatusBadRequest, "User already in organization: "+email)
+			return
+		}
+	}
+
+	for _, email := range req.Emails {
+		// Unknown users are invited to Vaultwarden as well
+		invited := s.userByEmail(email)
+		if invited == nil {
+			invited = &user{ID: newID(), Email: strings.ToLower(strings.TrimSpace(email))}
+			s.users[invited.ID] = invited
+		}
+
+		// Without mail delivery, Vaultwarden accepts invitations of registered users right away
+		status := models.UserOrgStatusInvited
+		if invited.registered() {
+			status = models.UserOrgStatusAccepted
+		}
+
+		m := &member{
+			ID:          newID(),
+			UserID:      invited.ID,
+			Status:      status,
+			Type:        req.Type,
+			AccessAll:   req.AccessAll,
+			Permissions: req.Permissions,
+			Collections: req.Collections,
+			seq:         s.nextSeq(),
+		}
+		org.Members[m.ID] = m
+	}
+}
+
+func (s *Server) handleConfirmMember(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Key string `json:"key"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	m, exists := org.Members[r.PathValue("memberID")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "The specified user isn't a member of the organization")
+		return
+	}
+	if m.Status != models.UserOrgStatusAccepted {
+		writeError(w, http.StatusBadRequest, "User in invalid state")
+		return
+	}
+	if req.Key == "" {
+		writeError(w, http.StatusBadRequest, "Invalid key provided")
+		return
+	}
+
+	m.Status = models.UserOrgStatusConfirmed
+	m.Key = req.Key
+}
+
+func (s *Server) handleUpdateMember(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	m, exists := org.Members[r.PathValue("memberID")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "The specified user isn't member of the organization")
+		return
+	}
+
+	var req models.OrganizationUserDetails
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Type != models.UserOrgTypeOwner && !org.keepsOwner(m.ID) {
+		writeError(w, http.StatusBadRequest, "Can't delete the last owner")
+		return
+	}
+
+	// Vaultwarden replaces the collections and groups of the member on every update
+	m.Type = req.Type
+	m.AccessAll = req.AccessAll
+	m.Permissions = req.Permissions
+	m.Collections = req.Collections
+
+	if s.OrgGroupsEnabled {
+		for _, g := range org.Groups {
+			g.Users = slices.DeleteFunc(g.Users, func(id string) bool { return id == m.ID })
+		}
+		for _, groupID := range req.Groups {
+			if g, exists := org.Groups[groupID]; exists {
+				g.Users = append(g.Users, m.ID)
+			}
+		}
+	}
+}
+
+func (s *Server) handleRevokeMember(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	m, exists := org.Members[r.PathValue("memberID")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "User not found in organization")
+		return
+	}
+	if m.UserID == u.ID {
+		writeError(w, http.StatusBadRequest, "You cannot revoke yourself")
+		return
+	}
+	if !org.keepsOwner(m.ID) {
+		writeError(w, http.StatusBadRequest, "Organization must have at least one confirmed owner")
+		return
+	}
+
+	if m.Status != models.UserOrgStatusRevoked {
+		m.restoreStatus = m.Status
+		m.Status = models.UserOrgStatusRevoked
+	}
+}
+
+func (s *Server) handleRestoreMember(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	m, exists := org.Members[r.PathValue("memberID")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "User not found in organization")
+		return
+	}
+
+	if m.Status == models.UserOrgStatusRevoked {
+		m.Status = m.restoreStatus
+	}
+}
+
+func (s *Server) handleDeleteMembers(w http.ResponseWriter, r *http.Request, u *user) {
+	org, ok := s.adminOrganization(w, r, u)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		IDs []string `json:"ids"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	results := []map[string]string{}
+	for _, id := range req.IDs {
+		result := map[string]string{"id": id, "error": "", "object": "OrganizationBulkConfirmResponseModel"}
+		if _, exists := org.Members[id]; !exists {
+			result["error"] = "User to delete isn't member of the organization"
+		} else if !org.keepsOwner(id) {
+			result["error"] = "Can't delete the last owner"
+		} else {
+			delete(org.Members, id)
+		}
+		results = append(results, result)
+	}
+
+	writeList(s, w, r, results)
+}
@@ -0,0 +1,85 @@
+package fakeserver
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"strconv"
+)
+
+// policyJSON returns the representation of a policy returned by the policy endpoints
+func policyJSON(p *models.Policy) map[string]interface{} {
+	return map[string]interface{}{
+		"id":             p.ID,
+		"organizationId": p.OrganizationID,
+		"type":           p.Type,
+		"enabled":        p.Enabled,
+		"data":           p.Data,
+		"object":         "policy",
+	}
+}
+
+// organizationPolicy returns the policy of the type in the path, or a disabled one if it has never been configured
+func organizationPolicy(w http.ResponseWriter, r *http.Request, org *organization) (*models.Policy, bool) {
+	value, err := strconv.ParseInt(r.PathValue("policyType"), 10, 64)
+	policyType := models.PolicyType(value)
+	if err != nil || policyType < models.PolicyTypeTwoFactorAuthentication || policyType > models.PolicyTypeDisablePersonalVaultExport {
+		writeError(w, http.StatusBadRequest, "Invalid or unsupported policy type")
+		return nil, false
+	}
+
+	if p, exists := org.Policies[policyType]; exists {
+		return p, true
+	}
+	return &models.Policy{ID: newID(), OrganizationID: org.ID, Type: policyType}, true
+}
+
+func (s *Server) registerPolicyRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/organizations/{orgID}/policies", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.adminOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		policies := []map[string]interface{}{}
+		for policyType := models.PolicyTypeTwoFactorAuthentication; policyType <= models.PolicyTypeDisablePersonalVaultExport; policyType++ {
+			if p, exists := org.Policies[policyType]; exists {
+				policies = append(policies, policyJSON(p))
+			}
+		}
+		writeList(s, w, r, policies)
+	}))
+	mux.HandleFunc("GET /api/organizations/{orgID}/policies/{policyType}", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.adminOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		if p, ok := organizationPolicy(w, r, org); ok {
+			writeJSON(w, http.StatusOK, policyJSON(p))
+		}
+	}))
+	mux.HandleFunc("PUT /api/organizations/{orgID}/policies/{policyType}", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		org, ok := s.adminOrganization(w, r, u)
+		if !ok {
+			return
+		}
+
+		p, ok := organizationPolicy(w, r, org)
+		if !ok {
+			return
+		}
+
+		var req struct {
+			Enabled bool                   `json:"enabled"`
+			Data    map[string]interface{} `json:"data"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+
+		p.Enabled = req.Enabled
+		p.Data = req.Data
+		org.Policies[p.Type] = p
+		writeJSON(w, http.StatusOK, policyJSON(p))
+	}))
+}
@@ -0,0 +1,272 @@
+// Package fakeserver implements an in-process fake of the Vaultwarden server, so that the client and the
+// resources can be unit tested without running Vaultwarden in Docker.
+//
+// The fake implements the identity, api and admin endpoints used by the client. Like Vaultwarden itself,
+// it stores the encrypted values sent by the client as they are, while the keys of the users added with
+// AddUser are generated the same way the official clients do, so the client can decrypt everything it
+// receives. The fake does not aim to reproduce every validation of Vaultwarden.
+package fakeserver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// DefaultAdminToken is the admin token accepted by the admin endpoints unless changed
+	DefaultAdminToken = "admin_token"
+
+	// DefaultVersion is the Vaultwarden version reported by the version endpoint unless changed
+	DefaultVersion = "1.34.1"
+
+	// defaultKdfIterations keeps the key derivation fast, Vaultwarden itself defaults to 600000
+	defaultKdfIterations = 5000
+
+	// tokenLifetime is the lifetime of the access tokens issued by the fake
+	tokenLifetime = time.Hour
+
+	adminCookieName = "VW_ADMIN"
+)
+
+// Server is a fake Vaultwarden server listening on a local address
+type Server struct {
+	*httptest.Server
+
+	// AdminToken is the token accepted by the admin login
+	AdminToken string
+
+	// Version is the version reported by the version endpoint
+	Version string
+
+	// PageSize splits the list responses into pages of the given size if greater than zero, to test
+	// the handling of continuation tokens
+	PageSize int
+
+	// OrgGroupsEnabled and OrgEventsEnabled enable groups and event logs for all organizations, like the
+	// ORG_GROUPS_ENABLED and ORG_EVENTS_ENABLED settings of Vaultwarden
+	OrgGroupsEnabled bool
+	OrgEventsEnabled bool
+
+	t  testing.TB
+	mu sync.Mutex
+
+	kdf           models.KdfConfiguration
+	users         map[string]*user
+	organizations map[string]*organization
+	collections   map[string]*collection
+	ciphers       map[string]*models.Cipher
+	tokens        map[string]string
+	adminSessions map[string]time.Time
+	requests      []string
+	seq           int
+}
+
+// New starts a fake Vaultwarden server, which is closed when the test finishes
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		AdminToken: DefaultAdminToken,
+		Version:    DefaultVersion,
+		t:          t,
+		kdf: models.KdfConfiguration{
+			KdfType:       models.KdfTypePBKDF2_SHA256,
+			KdfIterations: defaultKdfIterations,
+		},
+		users:         make(map[string]*user),
+		organizations: make(map[string]*organization),
+		collections:   make(map[string]*collection),
+		ciphers:       make(map[string]*models.Cipher),
+		tokens:        make(map[string]string),
+		adminSessions: make(map[string]time.Time),
+	}
+
+	mux := http.NewServeMux()
+	s.registerIdentityRoutes(mux)
+	s.registerOrganizationRoutes(mux)
+	s.registerPolicyRoutes(mux)
+	s.registerGroupRoutes(mux)
+	s.registerCipherRoutes(mux)
+	s.registerAdminRoutes(mux)
+	s.registerTwoFactorRoutes(mux)
+
+	s.Server = httptest.NewServer(s.recordRequests(mux))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Requests returns the requests received by the server as "METHOD /path", in the order they were received
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// nextSeq returns the next sequence number, which orders the objects of a list like Vaultwarden does by creation.
+// The caller has to hold the lock.
+func (s *Server) nextSeq() int {
+	s.seq++
+	return s.seq
+}
+
+// recordRequests records every request before passing it on to the handler
+func (s *Server) recordRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// userHandler handles a request of an authenticated user, the state of the server is locked while handling it
+func (s *Server) userHandler(handler func(w http.ResponseWriter, r *http.Request, u *user)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		token, ok := bearerToken(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "Invalid claim")
+			return
+		}
+
+		userID, exists := s.tokens[token]
+		if !exists {
+			writeError(w, http.StatusUnauthorized, "Invalid claim")
+			return
+		}
+
+		handler(w, r, s.users[userID])
+	}
+}
+
+// adminHandler handles a request authenticated with an admin session, the state of the server is locked while handling it
+func (s *Server) adminHandler(handler func(w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		cookie, err := r.Cookie(adminCookieName)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		if expires, exists := s.adminSessions[cookie.Value]; !exists || time.Now().After(expires) {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// publicHandler handles an unauthenticated request, the state of the server is locked while handling it
+func (s *Server) publicHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		handler(w, r)
+	}
+}
+
+// bearerToken returns the access token of the Authorization header
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || header[:len(prefix)] != prefix {
+		return "", false
+	}
+	return header[len(prefix):], true
+}
+
+// decodeJSON decodes the JSON body of a request, writing an error response if it is invalid
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format used by Vaultwarden
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"message": message,
+		"validationErrors": map[string][]string{
+			"": {message},
+		},
+		"object": "error",
+	})
+}
+
+// writeList writes a list response, split into pages if the page size of the server is set
+func writeList[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T) {
+	offset := 0
+	if token := r.URL.Query().Get("continuationToken"); token != "" {
+		var err error
+		if offset, err = strconv.Atoi(token); err != nil || offset < 0 || offset > len(items) {
+			writeError(w, http.StatusBadRequest, "Invalid continuation token")
+			return
+		}
+	}
+
+	end := len(items)
+	continuationToken := ""
+	if s.PageSize > 0 && offset+s.PageSize < end {
+		end = offset + s.PageSize
+		continuationToken = strconv.Itoa(end)
+	}
+
+	page := items[offset:end]
+	if page == nil {
+		page = []T{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":              page,
+		"continuationToken": nilIfEmpty(continuationToken),
+		"object":            "list",
+	})
+}
+
+// nilIfEmpty returns nil for empty strings, which are encoded as null
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// newID returns a new random ID in the UUID format used by Vaultwarden
+func newID() string {
+	return uuid.New().String()
+}
+
+// randomString returns a random URL safe string
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
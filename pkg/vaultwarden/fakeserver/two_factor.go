@@ -0,0 +1,144 @@
+package fakeserver
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/totp"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// authenticator is the authenticator app provider of a user
+type authenticator struct {
+	Secret  string
+	Enabled bool
+
+	// LastStep is the last time step a code was accepted for, codes can't be used twice
+	LastStep int64
+}
+
+func (s *Server) registerTwoFactorRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/two-factor", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		providers := []models.TwoFactorProvider{}
+		if u.Authenticator != nil && u.Authenticator.Enabled {
+			providers = append(providers, models.TwoFactorProvider{Enabled: true, Type: models.TwoFactorTypeAuthenticator, Object: "twoFactorProvider"})
+		}
+		writeList(s, w, r, providers)
+	}))
+	mux.HandleFunc("POST /api/two-factor/get-authenticator", s.userHandler(s.handleGetAuthenticator))
+	mux.HandleFunc("POST /api/two-factor/authenticator", s.userHandler(s.handleEnableAuthenticator))
+	mux.HandleFunc("POST /api/two-factor/get-recover", s.userHandler(func(w http.ResponseWriter, r *http.Request, u *user) {
+		var req struct {
+			MasterPasswordHash string `json:"masterPasswordHash"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+
+		if req.MasterPasswordHash != u.PasswordHash {
+			writeError(w, http.StatusBadRequest, "Invalid password")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.TwoFactorRecover{Code: u.RecoveryCode, Object: "twoFactorRecover"})
+	}))
+	mux.HandleFunc("PUT /api/two-factor/disable", s.userHandler(s.handleDisableTwoFactor))
+}
+
+func (s *Server) handleGetAuthenticator(w http.ResponseWriter, r *http.Request, u *user) {
+	var req struct {
+		MasterPasswordHash string `json:"masterPasswordHash"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.MasterPasswordHash != u.PasswordHash {
+		writeError(w, http.StatusBadRequest, "Invalid password")
+		return
+	}
+
+	if u.Authenticator != nil && u.Authenticator.Enabled {
+		writeJSON(w, http.StatusOK, models.TwoFactorAuthenticator{Enabled: true, Key: u.Authenticator.Secret, Object: "twoFactorAuthenticator"})
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, models.TwoFactorAuthenticator{Key: secret, Object: "twoFactorAuthenticator"})
+}
+
+func (s *Server) handleEnableAuthenticator(w http.ResponseWriter, r *http.Request, u *user) {
+	var req struct {
+		Key                string `json:"key"`
+		Token              string `json:"token"`
+		MasterPasswordHash string `json:"masterPasswordHash"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.MasterPasswordHash != u.PasswordHash {
+		writeError(w, http.StatusBadRequest, "Invalid password")
+		return
+	}
+
+	pending := &authenticator{Secret: req.Key}
+	if !pending.verify(req.Token) {
+		writeInvalidTOTPCode(w)
+		return
+	}
+	pending.Enabled = true
+	u.Authenticator = pending
+	if u.RecoveryCode == "" {
+		u.RecoveryCode = strings.ToUpper(randomString()[:32])
+	}
+
+	writeJSON(w, http.StatusOK, models.TwoFactorAuthenticator{Enabled: true, Key: req.Key, Object: "twoFactorAuthenticator"})
+}
+
+func (s *Server) handleDisableTwoFactor(w http.ResponseWriter, r *http.Request, u *user) {
+	var req struct {
+		Type               models.TwoFactorType `json:"type"`
+		MasterPasswordHash string               `json:"masterPasswordHash"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.MasterPasswordHash != u.PasswordHash {
+		writeError(w, http.StatusBadRequest, "Invalid password")
+		return
+	}
+
+	if req.Type == models.TwoFactorTypeAuthenticator {
+		u.Authenticator = nil
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"enabled": false, "type": req.Type, "object": "twoFactorProvider"})
+}
+
+// verify checks a code of the authenticator, accepting the codes of the adjacent time steps to allow for
+// clock drift. Like Vaultwarden, it rejects codes of time steps that were used before.
+func (a *authenticator) verify(code string) bool {
+	current := totp.Step(time.Now())
+	for step := current - 1; step <= current+1; step++ {
+		expected, err := totp.StepCode(a.Secret, step)
+		if err != nil {
+			return false
+		}
+		if expected == code && step > a.LastStep {
+			a.LastStep = step
+			return true
+		}
+	}
+	return false
+}
+
+// writeInvalidTOTPCode writes the error Vaultwarden responds with to a rejected code
+func writeInvalidTOTPCode(w http.ResponseWriter) {
+	writeError(w, http.StatusBadRequest, "Invalid TOTP code! Server time: "+time.Now().UTC().Format(time.RFC3339))
+}
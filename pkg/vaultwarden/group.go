@@ -0,0 +1,73 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+)
+
+// CreateOrganizationGroup creates a new group in an organization. Groups have to be enabled with the
+// ORG_GROUPS_ENABLED setting of Vaultwarden.
+func (c *Client) CreateOrganizationGroup(ctx context.Context, orgID string, group models.Group) (*models.Group, error) {
+	// Set empty lists for collections and users when none are provided
+	if group.Collections == nil {
+		group.Collections = []models.CollectionAccess{}
+	}
+
+	if group.Users == nil {
+		group.Users = []string{}
+	}
+
+	var groupResp models.Group
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/organizations/%s/groups", orgID), group, &groupResp); err != nil {
+		return nil, fmt.Errorf("failed to create organization group: %w", err)
+	}
+
+	return &groupResp, nil
+}
+
+// GetOrganizationGroup retrieves a group of an organization
+func (c *Client) GetOrganizationGroup(ctx context.Context, orgID, groupID string) (*models.Group, error) {
+	var group models.Group
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s/groups/%s", orgID, groupID), nil, &group); err != nil {
+		return nil, fmt.Errorf("failed to get organization group: %w", err)
+	}
+
+	return &group, nil
+}
+
+// DeleteOrganizationGroup deletes a group of an organization, the members of the group stay in the organization
+func (c *Client) DeleteOrganizationGroup(ctx context.Context, orgID, groupID string) error {
+	if _, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/organizations/%s/groups/%s", orgID, groupID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete organization group: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrganizationGroupUsers retrieves the IDs of the organization users who are members of a group
+func (c *Client) GetOrganizationGroupUsers(ctx context.Context, orgID, groupID string) ([]string, error) {
+	var userIDs []string
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s/groups/%s/users", orgID, groupID), nil, &userIDs); err != nil {
+		return nil, fmt.Errorf("failed to get organization group users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// UpdateOrganizationGroupUsers replaces the members of a group with the given organization users in a single request,
+// members not listed are removed from the group
+func (c *Client) UpdateOrganizationGroupUsers(ctx context.Context, orgID, groupID string, userIDs []string) error {
+	// Vaultwarden rejects a missing list
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/organizations/%s/groups/%s/users", orgID, groupID), userIDs, nil); err != nil {
+		return fmt.Errorf("failed to update organization group users: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package vaultwarden
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultHealthCheckTimeout = 10 * time.Second
+)
+
+// CheckHealth verifies that the Vaultwarden server is reachable by calling the /alive endpoint
+func (c *Client) CheckHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHealthCheckTimeout)
+	defer cancel()
+
+	resp, err := c.doUnauthenticatedRequest(ctx, http.MethodGet, "/alive", nil, nil)
+	if err == nil {
+		return nil
+	}
+
+	endpoint := c.endpoint.JoinPath("/alive").String()
+
+	// Classify the failure to give an actionable error message
+	var dnsErr *net.DNSError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	var opErr *net.OpError
+
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%s returned 404 Not Found; make sure the endpoint points to the root of the Vaultwarden server, including any sub-path it is served under", endpoint)
+	case resp != nil:
+		return fmt.Errorf("%s returned unexpected status %d: %w", endpoint, resp.StatusCode, err)
+	case errors.As(err, &dnsErr):
+		return fmt.Errorf("unable to resolve host %q; check the endpoint hostname and your DNS configuration: %w", dnsErr.Name, err)
+	case errors.As(err, &unknownAuthorityErr), errors.As(err, &hostnameErr), errors.As(err, &certInvalidErr):
+		return fmt.Errorf("TLS certificate verification failed for %s; check that the server certificate is valid and trusted: %w", endpoint, err)
+	case strings.Contains(err.Error(), "server gave HTTP response to HTTPS client"):
+		return fmt.Errorf("TLS handshake failed for %s; the server does not appear to speak HTTPS, check the endpoint scheme: %w", endpoint, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%s did not respond within %s: %w", endpoint, DefaultHealthCheckTimeout, err)
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return fmt.Errorf("unable to connect to %s; check that the server is running and reachable: %w", endpoint, err)
+	default:
+		return fmt.Errorf("health check of %s failed: %w", endpoint, err)
+	}
+}
@@ -16,6 +16,11 @@ func HMACSum(value, key []byte, algo func() hash.Hash) []byte {
 	return mac.Sum(nil)
 }
 
+// ZeroBytes overwrites the given slice with zeros to clear key material from memory after use
+func ZeroBytes(b []byte) {
+	clear(b)
+}
+
 func HKDFExpand(value, key []byte, algo func() hash.Hash, length int) []byte {
 	encKey := hkdf.Expand(sha256.New, value, key)
 	newEncKey := make([]byte, length)
@@ -0,0 +1,51 @@
+package keybuilder
+
+import (
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"testing"
+)
+
+const (
+	benchmarkEmail    = "user@example.com"
+	benchmarkPassword = "correct horse battery staple"
+)
+
+// benchmarkKdfConfigs are the default KDF configurations of the official clients
+var benchmarkKdfConfigs = map[string]*models.KdfConfiguration{
+	"PBKDF2": {KdfType: models.KdfTypePBKDF2_SHA256, KdfIterations: 600000},
+	"Argon2": {KdfType: models.KdfTypeArgon2, KdfIterations: 3, KdfMemory: 64, KdfParallelism: 4},
+}
+
+func BenchmarkBuildPreloginKey(b *testing.B) {
+	for name, kdfConfig := range benchmarkKdfConfigs {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := BuildPreloginKey(benchmarkPassword, benchmarkEmail, kdfConfig); err != nil {
+					b.Fatalf("failed to build prelogin key: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkRegistration measures the key material generated when registering an account
+func BenchmarkRegistration(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		preloginKey, err := BuildPreloginKey(benchmarkPassword, benchmarkEmail, benchmarkKdfConfigs["PBKDF2"])
+		if err != nil {
+			b.Fatalf("failed to build prelogin key: %v", err)
+		}
+		crypt.HashPassword(benchmarkPassword, *preloginKey, false)
+
+		encryptionKey, _, err := GenerateEncryptionKey(*preloginKey)
+		if err != nil {
+			b.Fatalf("failed to generate encryption key: %v", err)
+		}
+		if _, _, err := GenerateEncryptedRSAKeyPair(*encryptionKey); err != nil {
+			b.Fatalf("failed to generate key pair: %v", err)
+		}
+	}
+}
@@ -3,8 +3,8 @@ package keybuilder
 import (
 	"crypto/rand"
 	"fmt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/crypt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/symmetrickey"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
 )
 
 func GenerateEncryptionKey(key symmetrickey.Key) (*symmetrickey.Key, string, error) {
@@ -20,6 +20,7 @@ func GenerateEncryptionKey(key symmetrickey.Key) (*symmetrickey.Key, string, err
 func EncryptEncryptionKey(key symmetrickey.Key, encryptionKey []byte) (newEncryptionKey *symmetrickey.Key, encryptedEncryptionKey string, err error) {
 	if len(key.Key) == 32 {
 		stretchedKey := key.StretchKey()
+		defer stretchedKey.Zero()
 		encryptedEncryptionKey, err = crypt.EncryptAsString(encryptionKey, stretchedKey)
 		if err != nil {
 			return nil, "", fmt.Errorf("error encrypting encryption key (symmetric key len: %d): %w", len(key.Key), err)
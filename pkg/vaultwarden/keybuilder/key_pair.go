@@ -0,0 +1,58 @@
+package keybuilder
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+)
+
+const (
+	// RSAKeySize2048 is the default size of generated RSA keys, which is supported by all clients
+	RSAKeySize2048 = 2048
+	// RSAKeySize4096 is the size of RSA keys to satisfy stricter crypto policies
+	RSAKeySize4096 = 4096
+)
+
+// GenerateEncryptedRSAKeyPair generates a 2048-bit RSA key pair, encrypting the private key with the symmetric key
+func GenerateEncryptedRSAKeyPair(key symmetrickey.Key) (string, string, error) {
+	return GenerateEncryptedRSAKeyPairWithSize(key, RSAKeySize2048)
+}
+
+// GenerateEncryptedRSAKeyPairWithSize generates an RSA key pair of the given size in bits,
+// encrypting the private key with the symmetric key
+func GenerateEncryptedRSAKeyPairWithSize(key symmetrickey.Key, bits int) (string, string, error) {
+	if bits != RSAKeySize2048 && bits != RSAKeySize4096 {
+		return "", "", fmt.Errorf("unsupported RSA key size: %d, must be one of: %d, %d", bits, RSAKeySize2048, RSAKeySize4096)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating rsa key: %w", err)
+	}
+	return EncryptRSAKeyPair(key, privateKey)
+}
+
+func EncryptRSAKeyPair(key symmetrickey.Key, privateKey *rsa.PrivateKey) (string, string, error) {
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error marshalling PKIX public key: %w", err)
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error marshalling PKIX private key: %w", err)
+	}
+	defer helpers.ZeroBytes(privateKeyBytes)
+
+	encryptedPrivateKey, err := crypt.EncryptAsString(privateKeyBytes, key)
+	if err != nil {
+		return "", "", fmt.Errorf("error encrypting private key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(publicKeyBytes), encryptedPrivateKey, nil
+}
@@ -0,0 +1,88 @@
+package keybuilder
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"math"
+)
+
+// Bounds of the Argon2id parameters that the derivation can handle, stricter limits are left to the server
+const (
+	argon2MinIterations  = 1
+	argon2MinParallelism = 1
+	argon2MaxParallelism = math.MaxUint8
+)
+
+// BuildPreloginKey derives the master key from the master password, using the email as salt.
+// The email is used as is, the salt of accounts registered with a mixed-case email keeps its casing.
+func BuildPreloginKey(masterPassword, email string, kdfConfig *models.KdfConfiguration) (*symmetrickey.Key, error) {
+	return buildKey(masterPassword, email, kdfConfig)
+}
+
+// validateArgon2Config checks the Argon2id parameters, rejecting values that would make the
+// derivation panic or overflow
+func validateArgon2Config(kdfConfig *models.KdfConfiguration) error {
+	if kdfConfig.KdfIterations < argon2MinIterations || int64(kdfConfig.KdfIterations) > math.MaxUint32 {
+		return fmt.Errorf("invalid Argon2id iterations %d: must be between %d and %d", kdfConfig.KdfIterations, argon2MinIterations, uint32(math.MaxUint32))
+	}
+	if kdfConfig.KdfParallelism < argon2MinParallelism || kdfConfig.KdfParallelism > argon2MaxParallelism {
+		return fmt.Errorf("invalid Argon2id parallelism %d: must be between %d and %d", kdfConfig.KdfParallelism, argon2MinParallelism, argon2MaxParallelism)
+	}
+
+	// Argon2 requires at least 8 KiB of memory per lane
+	if memory := int64(kdfConfig.KdfMemory) * 1024; memory < 8*int64(kdfConfig.KdfParallelism) || memory > math.MaxUint32 {
+		return fmt.Errorf("invalid Argon2id memory %d MiB: must be at least 8 KiB for each of the %d lanes and less than 4 TiB", kdfConfig.KdfMemory, kdfConfig.KdfParallelism)
+	}
+	return nil
+}
+
+func buildKey(masterPassword, salt string, kdfConfig *models.KdfConfiguration) (*symmetrickey.Key, error) {
+	if kdfConfig == nil {
+		return nil, fmt.Errorf("missing KDF configuration")
+	}
+
+	// Clear the copy of the master password once the key is derived
+	password := []byte(masterPassword)
+	defer helpers.ZeroBytes(password)
+
+	var rawKey []byte
+	switch kdfConfig.KdfType {
+	case models.KdfTypePBKDF2_SHA256:
+		if kdfConfig.KdfIterations < 1 {
+			return nil, fmt.Errorf("invalid PBKDF2 iterations %d: must be at least 1", kdfConfig.KdfIterations)
+		}
+		rawKey = pbkdf2.Key(password, []byte(salt), kdfConfig.KdfIterations, 32, sha256.New)
+	case models.KdfTypeArgon2:
+		if err := validateArgon2Config(kdfConfig); err != nil {
+			return nil, err
+		}
+
+		// Argon2id uses the SHA-256 hash of the email as salt
+		hashedSalt := sha256.Sum256([]byte(salt))
+
+		var err error
+		err = func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("argon2.IDKey() panicked: %v", r)
+				}
+			}()
+
+			// The memory is configured in MiB, while Argon2 expects KiB
+			rawKey = argon2.IDKey(password, hashedSalt[:], uint32(kdfConfig.KdfIterations), uint32(kdfConfig.KdfMemory*1024), uint8(kdfConfig.KdfParallelism), 32)
+			return
+		}()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported KDF: '%d'", kdfConfig.KdfType)
+	}
+
+	return symmetrickey.NewFromRawBytes(rawKey)
+}
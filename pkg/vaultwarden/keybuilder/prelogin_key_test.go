@@ -0,0 +1,107 @@
+package keybuilder
+
+import (
+	"bytes"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBuildPreloginKey(t *testing.T) {
+	const masterPassword = "67t9b5g67$%Dh89n"
+
+	// The first two vectors are the master key derivation vectors of the Bitwarden SDK
+	tests := map[string]struct {
+		email     string
+		kdfConfig models.KdfConfiguration
+		expected  []byte
+	}{
+		"PBKDF2": {
+			email:     "test_key",
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypePBKDF2_SHA256, KdfIterations: 10000},
+			expected:  []byte{31, 79, 104, 226, 150, 71, 177, 90, 194, 80, 172, 209, 17, 129, 132, 81, 138, 167, 69, 167, 254, 149, 2, 27, 39, 197, 64, 42, 22, 195, 86, 75},
+		},
+		"Argon2id": {
+			email:     "test_key",
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 4, KdfMemory: 32, KdfParallelism: 2},
+			expected:  []byte{207, 240, 225, 177, 162, 19, 163, 76, 98, 106, 179, 175, 224, 9, 17, 240, 20, 147, 237, 47, 246, 150, 141, 184, 62, 225, 131, 242, 51, 53, 225, 242},
+		},
+		// Accounts registered with a mixed-case email derived their master key from that casing
+		"mixed-case email": {
+			email:     "Test@Example.com",
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypePBKDF2_SHA256, KdfIterations: 10000},
+			expected:  []byte{110, 85, 172, 137, 222, 209, 189, 120, 247, 195, 78, 177, 244, 154, 156, 22, 254, 15, 108, 166, 170, 112, 252, 101, 136, 146, 230, 123, 85, 195, 189, 231},
+		},
+	}
+
+	for name, test := range tests {
+		key, err := BuildPreloginKey(masterPassword, test.email, &test.kdfConfig)
+		if err != nil {
+			t.Fatalf("%s: failed to build the prelogin key: %v", name, err)
+		}
+		if !bytes.Equal(key.Key, test.expected) {
+			t.Errorf("%s: got key %v, want %v", name, key.Key, test.expected)
+		}
+	}
+}
+
+func TestBuildPreloginKeyBounds(t *testing.T) {
+	tests := map[string]struct {
+		kdfConfig models.KdfConfiguration
+		err       string
+	}{
+		"PBKDF2 with one iteration": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypePBKDF2_SHA256, KdfIterations: 1},
+		},
+		"PBKDF2 without iterations": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypePBKDF2_SHA256},
+			err:       "invalid PBKDF2 iterations 0",
+		},
+		"Argon2id with one iteration": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 1, KdfMemory: 1, KdfParallelism: 1},
+		},
+		"Argon2id without iterations": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfMemory: 1, KdfParallelism: 1},
+			err:       "invalid Argon2id iterations 0",
+		},
+		"Argon2id with too many iterations": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: math.MaxUint32 + 1, KdfMemory: 1, KdfParallelism: 1},
+			err:       "invalid Argon2id iterations 4294967296",
+		},
+		"Argon2id with the most lanes": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 1, KdfMemory: 2, KdfParallelism: 255},
+		},
+		"Argon2id without lanes": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 1, KdfMemory: 1},
+			err:       "invalid Argon2id parallelism 0",
+		},
+		"Argon2id with too many lanes": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 1, KdfMemory: 64, KdfParallelism: 256},
+			err:       "invalid Argon2id parallelism 256",
+		},
+		"Argon2id without memory": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 1, KdfParallelism: 1},
+			err:       "invalid Argon2id memory 0 MiB",
+		},
+		"Argon2id with too little memory per lane": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 1, KdfMemory: 1, KdfParallelism: 255},
+			err:       "invalid Argon2id memory 1 MiB",
+		},
+		"Argon2id with overflowing memory": {
+			kdfConfig: models.KdfConfiguration{KdfType: models.KdfTypeArgon2, KdfIterations: 1, KdfMemory: 4 * 1024 * 1024, KdfParallelism: 1},
+			err:       "invalid Argon2id memory 4194304 MiB",
+		},
+	}
+
+	for name, test := range tests {
+		_, err := BuildPreloginKey("master password", "user@example.com", &test.kdfConfig)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error %q, got: %v", name, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package keybuilder
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+)
+
+// ParsePublicKey parses a base64 encoded RSA public key in PKIX format, as returned by the server
+func ParsePublicKey(encodedPublicKey string) (*rsa.PublicKey, error) {
+	publicKeyBytes, err := base64.StdEncoding.DecodeString(encodedPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to base64 decode public key: %w", err)
+	}
+
+	p, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key in PKIX format: %w", err)
+	}
+
+	publicKey, ok := p.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("parsed public key is not an RSA public key")
+	}
+
+	return publicKey, nil
+}
+
+// BuildResetPasswordKey encrypts the user key of a member with the public key of the organization
+// for the password reset enrollment, which allows the organization to recover the member's account
+func BuildResetPasswordKey(userKey symmetrickey.Key, orgPublicKey string) (string, error) {
+	if len(userKey.Key) == 0 {
+		return "", fmt.Errorf("no user key was provided")
+	}
+
+	publicKey, err := ParsePublicKey(orgPublicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid organization public key: %w", err)
+	}
+
+	resetPasswordKey, err := RSAEncrypt(userKey.Key, publicKey)
+	if err != nil {
+		return "", fmt.Errorf("error encrypting user key: %w", err)
+	}
+
+	return resetPasswordKey, nil
+}
+
+// DecryptResetPasswordKey decrypts the user key of a member enrolled in password reset with the private key of the organization
+func DecryptResetPasswordKey(resetPasswordKey string, orgPrivateKey *rsa.PrivateKey) (*symmetrickey.Key, error) {
+	userKeyBytes, err := RSADecrypt(resetPasswordKey, orgPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt reset password key: %w", err)
+	}
+
+	userKey, err := symmetrickey.NewFromRawBytes(userKeyBytes)
+	if err != nil {
+		helpers.ZeroBytes(userKeyBytes)
+		return nil, fmt.Errorf("unsupported user key: %w", err)
+	}
+
+	return userKey, nil
+}
@@ -0,0 +1,68 @@
+package keybuilder
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"testing"
+)
+
+func TestResetPasswordKeyRoundTrip(t *testing.T) {
+	orgPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&orgPrivateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	orgPublicKey := base64.StdEncoding.EncodeToString(publicKeyBytes)
+
+	rawUserKey := make([]byte, 64)
+	if _, err := rand.Read(rawUserKey); err != nil {
+		t.Fatalf("failed to generate user key: %v", err)
+	}
+	userKey, err := symmetrickey.NewFromRawBytes(rawUserKey)
+	if err != nil {
+		t.Fatalf("failed to create user key: %v", err)
+	}
+
+	resetPasswordKey, err := BuildResetPasswordKey(*userKey, orgPublicKey)
+	if err != nil {
+		t.Fatalf("failed to build reset password key: %v", err)
+	}
+
+	decrypted, err := DecryptResetPasswordKey(resetPasswordKey, orgPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to decrypt reset password key: %v", err)
+	}
+	if !bytes.Equal(decrypted.Key, userKey.Key) || decrypted.EncryptionType != userKey.EncryptionType {
+		t.Error("the decrypted user key doesn't match the enrolled user key")
+	}
+
+	// Keys of other organizations can't decrypt it
+	otherPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	if _, err := DecryptResetPasswordKey(resetPasswordKey, otherPrivateKey); err == nil {
+		t.Error("expected decrypting with the key of another organization to fail")
+	}
+}
+
+func TestBuildResetPasswordKeyInvalidInput(t *testing.T) {
+	if _, err := BuildResetPasswordKey(symmetrickey.Key{}, ""); err == nil {
+		t.Error("expected an empty user key to be rejected")
+	}
+
+	userKey, err := symmetrickey.NewFromRawBytes(make([]byte, 64))
+	if err != nil {
+		t.Fatalf("failed to create user key: %v", err)
+	}
+	if _, err := BuildResetPasswordKey(*userKey, "not a public key"); err == nil {
+		t.Error("expected an invalid organization public key to be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package keybuilder
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"hash"
+)
+
+func GenerateSharedKey(publicKey *rsa.PublicKey) (string, *symmetrickey.Key, error) {
+	sharedKey := make([]byte, 64)
+	_, err := rand.Read(sharedKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating random bytes: %w", err)
+	}
+
+	newKey, err := symmetrickey.NewFromRawBytes(sharedKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating new symmetric crypto key")
+	}
+
+	encryptedsharedKey, err := RSAEncrypt(sharedKey, publicKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("error encrypting shared key")
+	}
+
+	return encryptedsharedKey, newKey, nil
+}
+
+// oaepHashes contains the hash functions used for RSA-OAEP by the supported asymmetric encryption types
+var oaepHashes = map[symmetrickey.EncryptionType]func() hash.Hash{
+	symmetrickey.Rsa2048_OaepSha256_B64: sha256.New,
+	symmetrickey.Rsa2048_OaepSha1_B64:   sha1.New,
+}
+
+// oaepHash returns the hash function used for RSA-OAEP by the given encryption type
+func oaepHash(encType symmetrickey.EncryptionType) (hash.Hash, error) {
+	newHash, ok := oaepHashes[encType]
+	if !ok {
+		return nil, &encryptedstring.UnsupportedEncryptionTypeError{EncryptionType: encType}
+	}
+	return newHash(), nil
+}
+
+// RSAEncrypt encrypts data using RSA-OAEP with SHA-1, which is supported by all clients
+func RSAEncrypt(data []byte, publicKey *rsa.PublicKey) (string, error) {
+	return RSAEncryptWithEncryptionType(data, publicKey, symmetrickey.Rsa2048_OaepSha1_B64)
+}
+
+// RSAEncryptWithEncryptionType encrypts data using RSA-OAEP with the hash of the given encryption type
+func RSAEncryptWithEncryptionType(data []byte, publicKey *rsa.PublicKey, encType symmetrickey.EncryptionType) (string, error) {
+	oaepHashFunc, err := oaepHash(encType)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedBytes, err := rsa.EncryptOAEP(
+		oaepHashFunc,
+		rand.Reader,
+		publicKey,
+		data,
+		nil)
+	if err != nil {
+		return "", fmt.Errorf("error encrypting data using RSA-OAEP: %w", err)
+	}
+
+	return fmt.Sprintf("%d.%s", encType, base64.StdEncoding.EncodeToString(encryptedBytes)), nil
+}
+
+// RSADecrypt decrypts data encrypted using RSA-OAEP with either SHA-1 or SHA-256
+func RSADecrypt(data string, privateKey *rsa.PrivateKey) ([]byte, error) {
+	s, err := encryptedstring.NewFromEncryptedValue(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encrypted string from data: %w", err)
+	}
+
+	oaepHashFunc, err := oaepHash(s.Key.EncryptionType)
+	if err != nil {
+		return nil, err
+	}
+
+	clearText, err := rsa.DecryptOAEP(oaepHashFunc, nil, privateKey, s.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed decryptRSA to decrypt text: %w", err)
+	}
+
+	return clearText, nil
+}
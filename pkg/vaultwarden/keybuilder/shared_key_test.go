@@ -0,0 +1,52 @@
+package keybuilder
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/symmetrickey"
+	"testing"
+)
+
+func TestRSAEncryptDecrypt(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	for _, encType := range []symmetrickey.EncryptionType{symmetrickey.Rsa2048_OaepSha1_B64, symmetrickey.Rsa2048_OaepSha256_B64} {
+		encrypted, err := RSAEncryptWithEncryptionType([]byte("shared key"), &privateKey.PublicKey, encType)
+		if err != nil {
+			t.Fatalf("failed to encrypt with type %d: %v", encType, err)
+		}
+
+		decrypted, err := RSADecrypt(encrypted, privateKey)
+		if err != nil {
+			t.Fatalf("failed to decrypt with type %d: %v", encType, err)
+		}
+		if !bytes.Equal(decrypted, []byte("shared key")) {
+			t.Errorf("got %q, want %q", decrypted, "shared key")
+		}
+	}
+}
+
+func FuzzRSADecrypt(f *testing.F) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		f.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	encrypted, err := RSAEncrypt([]byte("shared key"), &privateKey.PublicKey)
+	if err != nil {
+		f.Fatalf("failed to encrypt: %v", err)
+	}
+	f.Add(encrypted)
+	f.Add("3.AAECAw==")
+	f.Add("6.AAECAw==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=")
+	f.Add("2.oKGio6SlpqeoqaqrrK2urw==|2+YViIy6g2m8cdSbnLZNkg==|511MlDww3dDnSg+q7RQZ/Yy5ZqK1x36VoDsTNDZbJsQ=")
+
+	f.Fuzz(func(t *testing.T, value string) {
+		// Encrypted organization keys come from the server, malformed ones must be reported as errors
+		_, _ = RSADecrypt(value, privateKey)
+	})
+}
@@ -0,0 +1,55 @@
+package vaultwarden
+
+import (
+	"slices"
+	"sync"
+)
+
+// listingCache caches listings of organization objects, e.g. users or collections, per organization.
+// Entries are invalidated explicitly after writes that change the listing.
+type listingCache[T any] struct {
+	mu         sync.Mutex
+	entries    map[string][]T
+	generation uint64
+}
+
+// get returns a copy of the cached listing of the organization
+func (lc *listingCache[T]) get(orgID string) ([]T, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	items, exists := lc.entries[orgID]
+	return slices.Clone(items), exists
+}
+
+// begin returns the generation to pass to set for a listing that is about to be fetched
+func (lc *listingCache[T]) begin() uint64 {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return lc.generation
+}
+
+// set caches the listing of the organization, unless the cache was invalidated since the
+// listing was fetched, as it might not reflect the latest writes in that case
+func (lc *listingCache[T]) set(orgID string, generation uint64, items []T) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if generation != lc.generation {
+		return
+	}
+	if lc.entries == nil {
+		lc.entries = make(map[string][]T)
+	}
+	lc.entries[orgID] = slices.Clone(items)
+}
+
+// invalidate discards the cached listing of the organization
+func (lc *listingCache[T]) invalidate(orgID string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	delete(lc.entries, orgID)
+	lc.generation++
+}
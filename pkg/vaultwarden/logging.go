@@ -0,0 +1,62 @@
+package vaultwarden
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The client logs to tflog subsystems, so that the output can be filtered by concern. The level of each
+// subsystem can be set with the environment variable listed next to it, it defaults to the provider log level.
+const (
+	// LogSubsystemHTTP logs the requests sent to the server, TF_LOG_PROVIDER_VAULTWARDEN_HTTP
+	LogSubsystemHTTP = "vaultwarden.http"
+
+	// LogSubsystemAuth logs the logins and rejected sessions, TF_LOG_PROVIDER_VAULTWARDEN_AUTH
+	LogSubsystemAuth = "vaultwarden.auth"
+
+	// LogSubsystemCrypto logs the loading of keys and decryption failures, TF_LOG_PROVIDER_VAULTWARDEN_CRYPTO
+	LogSubsystemCrypto = "vaultwarden.crypto"
+)
+
+// Fields used consistently by all subsystems
+const (
+	// LogFieldRequestID is the correlation ID of a request, which is also sent in the X-Request-Id header
+	LogFieldRequestID = "request_id"
+
+	// LogFieldOrganizationID is the ID of the organization a message concerns
+	LogFieldOrganizationID = "org_id"
+
+	// LogFieldResource is the kind of Vaultwarden object a message concerns, e.g. "collection"
+	LogFieldResource = "resource"
+)
+
+// logLevelEnvVarPrefix is joined with the subsystem suffixes to the environment variables setting their level
+const logLevelEnvVarPrefix = "TF_LOG_PROVIDER_VAULTWARDEN"
+
+// logSubsystems maps the subsystems to the suffix of the environment variable setting their level
+var logSubsystems = map[string]string{
+	LogSubsystemHTTP:   "HTTP",
+	LogSubsystemAuth:   "AUTH",
+	LogSubsystemCrypto: "CRYPTO",
+}
+
+// logSubsystemsKey marks contexts in which the log subsystems are registered
+type logSubsystemsKey struct{}
+
+// WithLogSubsystems returns a context in which the log subsystems of the client are registered. The client
+// registers them for every call, callers only need to do so to add fields to the subsystems with
+// tflog.SubsystemSetField.
+func WithLogSubsystems(ctx context.Context) context.Context {
+	if registered, _ := ctx.Value(logSubsystemsKey{}).(bool); registered {
+		return ctx
+	}
+
+	for subsystem, envVarSuffix := range logSubsystems {
+		ctx = tflog.NewSubsystem(ctx, subsystem,
+			tflog.WithLevelFromEnv(logLevelEnvVarPrefix, envVarSuffix),
+			tflog.WithRootFields(),
+		)
+	}
+
+	return context.WithValue(ctx, logSubsystemsKey{}, true)
+}
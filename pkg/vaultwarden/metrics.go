@@ -0,0 +1,139 @@
+package vaultwarden
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of the interactions of the client with the Vaultwarden server, e.g. to
+// export them to Prometheus or to find out why applies against large instances are slow. The methods
+// are called synchronously, implementations must be safe for concurrent use and return quickly.
+type Metrics interface {
+	// RequestCompleted is called after every attempt of a request, including logins and retries
+	RequestCompleted(ctx context.Context, request RequestMetrics)
+
+	// RequestRetried is called before a request is sent again
+	RequestRetried(ctx context.Context, retry RetryMetrics)
+
+	// LoginCompleted is called after every login, including those refreshing an expired or invalidated session
+	LoginCompleted(ctx context.Context, login LoginMetrics)
+}
+
+// RequestMetrics describes an attempt of a request
+type RequestMetrics struct {
+	// Method is the HTTP method of the request
+	Method string
+
+	// Route is the path of the request with IDs and emails replaced by placeholders, e.g.
+	// /api/organizations/{id}/collections, so that it can be used as a metric label
+	Route string
+
+	// StatusCode is the status code of the response, zero if no response was received
+	StatusCode int
+
+	// Duration is the time until the response headers were received
+	Duration time.Duration
+
+	// Err is the error that prevented receiving a response
+	Err error
+}
+
+// RetryMetrics describes the retry of a request
+type RetryMetrics struct {
+	Method string
+	Route  string
+
+	// Reason describes why the request is retried, e.g. "unauthorized"
+	Reason string
+}
+
+// LoginMetrics describes a login
+type LoginMetrics struct {
+	// Kind is either "user" or "admin"
+	Kind string
+
+	// Duration is the time the login took, including the key derivation
+	Duration time.Duration
+
+	// Err is the error the login failed with
+	Err error
+}
+
+const (
+	loginKindUser  = "user"
+	loginKindAdmin = "admin"
+
+	retryReasonUnauthorized = "unauthorized"
+	retryReasonFailover     = "failover"
+)
+
+// uuidPattern matches the IDs used by Vaultwarden
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// requestRoute returns the path of a request with IDs and emails replaced by placeholders
+func requestRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case uuidPattern.MatchString(segment):
+			segments[i] = "{id}"
+		case strings.Contains(segment, "@"):
+			segments[i] = "{email}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// metricsMiddleware reports every request attempt to the configured metrics
+func (c *Client) metricsMiddleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+
+		request := RequestMetrics{
+			Method:   req.Method,
+			Route:    requestRoute(req.URL.Path),
+			Duration: time.Since(start),
+			Err:      err,
+		}
+		if resp != nil {
+			request.StatusCode = resp.StatusCode
+		}
+		c.metrics.RequestCompleted(req.Context(), request)
+
+		return resp, err
+	})
+}
+
+// observeRetry reports the retry of a request to the configured metrics
+func (c *Client) observeRetry(req *http.Request, reason string) {
+	if c.metrics == nil {
+		return
+	}
+
+	c.metrics.RequestRetried(req.Context(), RetryMetrics{
+		Method: req.Method,
+		Route:  requestRoute(req.URL.Path),
+		Reason: reason,
+	})
+}
+
+// observeLogin performs a login and reports it to the configured metrics
+func (c *Client) observeLogin(ctx context.Context, kind string, login func(context.Context) error) error {
+	if c.metrics == nil {
+		return login(ctx)
+	}
+
+	start := time.Now()
+	err := login(ctx)
+	c.metrics.LoginCompleted(ctx, LoginMetrics{
+		Kind:     kind,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+
+	return err
+}
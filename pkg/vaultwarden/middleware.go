@@ -0,0 +1,152 @@
+package vaultwarden
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+)
+
+// Middleware wraps the transport used for all requests to the Vaultwarden server. Middlewares
+// can inspect or modify requests and responses to add cross-cutting behavior such as logging,
+// metrics or rate limiting. Like any http.RoundTripper, a middleware must not modify the
+// request it receives but should clone it instead.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// authenticatedRequestKey marks request contexts that require authentication
+type authenticatedRequestKey struct{}
+
+// withAuthentication marks whether requests made with the returned context require authentication.
+// The marker is always set explicitly, as logins are performed with the context of the request
+// that triggered them and must not inherit its marker.
+func withAuthentication(ctx context.Context, authenticated bool) context.Context {
+	return context.WithValue(ctx, authenticatedRequestKey{}, authenticated)
+}
+
+// requiresAuthentication reports whether the request was marked as requiring authentication
+func requiresAuthentication(req *http.Request) bool {
+	authenticated, _ := req.Context().Value(authenticatedRequestKey{}).(bool)
+	return authenticated
+}
+
+// buildTransport composes the configured middlewares around the transport of the HTTP client.
+// The first configured middleware is the outermost one. The built-in middlewares wrap all others,
+// so that the configured middlewares observe every authenticated attempt.
+func (c *Client) buildTransport() error {
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// Use the custom dialer on a copy of the transport
+	if c.dialContext != nil {
+		httpTransport, ok := transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("a custom dialer requires the HTTP client transport to be an *http.Transport, got %T", transport)
+		}
+		httpTransport = httpTransport.Clone()
+		httpTransport.DialContext = c.dialContext
+		transport = httpTransport
+	}
+
+	// Decompress responses before they reach any other middleware
+	transport = compressionMiddleware(transport)
+
+	// Measure every attempt as it is sent to the server
+	if c.metrics != nil {
+		transport = c.metricsMiddleware(transport)
+	}
+
+	// Fail over to another endpoint before any other middleware sees the failed attempt
+	transport = c.failoverMiddleware(transport)
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+	transport = c.syncIndexMiddleware(transport)
+	transport = c.authMiddleware(transport)
+	transport = c.reauthMiddleware(transport)
+
+	// Don't modify the HTTP client passed in by the caller
+	c.baseHTTPClient = c.httpClient
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+
+	return nil
+}
+
+// authMiddleware adds the admin cookie or bearer token to requests that require authentication,
+// logging in first if there is no valid session
+func (c *Client) authMiddleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if !requiresAuthentication(req) {
+			return next.RoundTrip(req)
+		}
+
+		authReq := req.Clone(req.Context())
+		if err := c.authenticateRequest(authReq); err != nil {
+			// The transport is responsible for closing the body, even on errors
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, fmt.Errorf("failed to authenticate request: %w", err)
+		}
+
+		return next.RoundTrip(authReq)
+	})
+}
+
+// reauthMiddleware retries requests once with a fresh login if the server rejects the credentials,
+// e.g. because the session expired or was invalidated. Requests with streamed bodies are not
+// retried as the body has already been consumed.
+func (c *Client) reauthMiddleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || !requiresAuthentication(req) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		// Requests for objects the user cannot access are also rejected with 401, don't retry those
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorResponseSize))
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if parseErrorResponse(resp, body).IsInaccessibleObject() {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			return resp, nil
+		}
+
+		// Clear the rejected credentials and retry with a fresh login
+		tflog.SubsystemDebug(req.Context(), LogSubsystemAuth, "Vaultwarden rejected the session, logging in again", map[string]interface{}{
+			LogFieldRequestID: req.Header.Get(requestIDHeader),
+			"method":          req.Method,
+			"path":            req.URL.Path,
+		})
+		c.invalidateAuth(resp.Request)
+		c.observeRetry(req, retryReasonUnauthorized)
+
+		retryReq := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if retryReq.Body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+
+		return next.RoundTrip(retryReq)
+	})
+}
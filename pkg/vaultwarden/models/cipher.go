@@ -0,0 +1,70 @@
+package models
+
+// CipherType represents the type of a vault item
+type CipherType int64
+
+const (
+	CipherTypeLogin      CipherType = 1
+	CipherTypeSecureNote CipherType = 2
+	CipherTypeCard       CipherType = 3
+	CipherTypeIdentity   CipherType = 4
+	CipherTypeSSHKey     CipherType = 5
+)
+
+// String returns the string representation of the cipher type
+func (t *CipherType) String() string {
+	switch *t {
+	case CipherTypeLogin:
+		return "Login"
+	case CipherTypeSecureNote:
+		return "SecureNote"
+	case CipherTypeCard:
+		return "Card"
+	case CipherTypeIdentity:
+		return "Identity"
+	case CipherTypeSSHKey:
+		return "SshKey"
+	default:
+		return "Unknown"
+	}
+}
+
+// Cipher represents a vault item
+type Cipher struct {
+	ID             string       `json:"id,omitempty"`
+	OrganizationID string       `json:"organizationId,omitempty"`
+	Type           CipherType   `json:"type"`
+	Name           string       `json:"name"`
+	Key            string       `json:"key,omitempty"`
+	Login          *Login       `json:"login,omitempty"`
+	SecureNote     *SecureNote  `json:"secureNote,omitempty"`
+	CollectionIDs  []string     `json:"collectionIds,omitempty"`
+	Attachments    []Attachment `json:"attachments,omitempty"`
+	DeletedDate    *string      `json:"deletedDate,omitempty"`
+	Object         string       `json:"object,omitempty"`
+}
+
+// Login represents the data of a login item, the values are encrypted
+type Login struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// SecureNote represents the data of a secure note item
+type SecureNote struct {
+	Type int64 `json:"type"`
+}
+
+// Attachment represents the metadata of a file attached to a vault item. The file name is encrypted
+// with the key of the item.
+type Attachment struct {
+	ID       string `json:"id"`
+	URL      string `json:"url,omitempty"`
+	FileName string `json:"fileName"`
+	Key      string `json:"key,omitempty"`
+
+	// Size is the size of the file in bytes, Vaultwarden returns it as a string
+	Size     string `json:"size"`
+	SizeName string `json:"sizeName"`
+	Object   string `json:"object,omitempty"`
+}
@@ -10,3 +10,13 @@ type Collection struct {
 	Users          []string `json:"users"`
 	Object         string   `json:"object"`
 }
+
+// CollectionAccess represents the permissions of a user or group on a collection
+type CollectionAccess struct {
+	ID            string `json:"id"`
+	ReadOnly      bool   `json:"readOnly"`
+	HidePasswords bool   `json:"hidePasswords"`
+
+	// Manage allows the user or group to administer the collection, it requires the collection permission model
+	Manage bool `json:"manage"`
+}
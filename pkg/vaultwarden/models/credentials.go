@@ -9,6 +9,9 @@ type Credentials struct {
 	Email          string
 	MasterPassword string
 
+	// Secret of the authenticator app provider, if two-step login is enabled for the user
+	TOTPSecret string
+
 	// OAuth2 credentials
 	ClientID     string
 	ClientSecret string
@@ -0,0 +1,13 @@
+package models
+
+// Device represents a device the user has logged in with
+type Device struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Type             int64   `json:"type"`
+	Identifier       string  `json:"identifier"`
+	CreationDate     string  `json:"creationDate"`
+	LastActivityDate *string `json:"lastActivityDate"`
+	IsTrusted        bool    `json:"isTrusted"`
+	Object           string  `json:"object"`
+}
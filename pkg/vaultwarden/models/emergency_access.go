@@ -0,0 +1,64 @@
+package models
+
+// EmergencyAccessType represents the access an emergency contact gets once access is approved
+type EmergencyAccessType int64
+
+const (
+	EmergencyAccessTypeView     EmergencyAccessType = 0
+	EmergencyAccessTypeTakeover EmergencyAccessType = 1
+)
+
+// String returns the string representation of the emergency access type
+func (t *EmergencyAccessType) String() string {
+	switch *t {
+	case EmergencyAccessTypeView:
+		return "View"
+	case EmergencyAccessTypeTakeover:
+		return "Takeover"
+	default:
+		return "Unknown"
+	}
+}
+
+// EmergencyAccessStatus represents the status of an emergency access grant
+type EmergencyAccessStatus int64
+
+const (
+	EmergencyAccessStatusInvited           EmergencyAccessStatus = 0
+	EmergencyAccessStatusAccepted          EmergencyAccessStatus = 1
+	EmergencyAccessStatusConfirmed         EmergencyAccessStatus = 2
+	EmergencyAccessStatusRecoveryInitiated EmergencyAccessStatus = 3
+	EmergencyAccessStatusRecoveryApproved  EmergencyAccessStatus = 4
+)
+
+// String returns the string representation of the emergency access status
+func (t *EmergencyAccessStatus) String() string {
+	switch *t {
+	case EmergencyAccessStatusInvited:
+		return "Invited"
+	case EmergencyAccessStatusAccepted:
+		return "Accepted"
+	case EmergencyAccessStatusConfirmed:
+		return "Confirmed"
+	case EmergencyAccessStatusRecoveryInitiated:
+		return "RecoveryInitiated"
+	case EmergencyAccessStatusRecoveryApproved:
+		return "RecoveryApproved"
+	default:
+		return "Unknown"
+	}
+}
+
+// EmergencyAccess represents an emergency access grant between a grantor and a grantee. Depending on the
+// side of the grant it is listed from, either the grantee or the grantor is set.
+type EmergencyAccess struct {
+	ID           string                `json:"id"`
+	GranteeID    string                `json:"granteeId,omitempty"`
+	GrantorID    string                `json:"grantorId,omitempty"`
+	Email        string                `json:"email"`
+	Name         string                `json:"name"`
+	Type         EmergencyAccessType   `json:"type"`
+	Status       EmergencyAccessStatus `json:"status"`
+	WaitTimeDays int64                 `json:"waitTimeDays"`
+	Object       string                `json:"object"`
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// notFoundMessages contains fragments of the error messages Vaultwarden uses for missing objects.
+// Vaultwarden reports many of these with a generic 400 status instead of 404.
+var notFoundMessages = []string{
+	"doesn't exist",
+	"does not exist",
+	"collection not found",
+	"group not found",
+	"send not found",
+	"device not found",
+	"user not found in organization",
+	"isn't member of the organization",
+	"isn't a member of the organization",
+}
+
+// inaccessibleObjectMessages contains fragments of the error messages Vaultwarden rejects requests for
+// objects the user cannot access with. Vaultwarden reports these with 401 although the session is valid.
+var inaccessibleObjectMessages = []string{
+	"isn't member of the organization",
+	"isn't a member of the organization",
+}
+
+// VaultwardenError represents an error response returned by the Vaultwarden API
+type VaultwardenError struct {
+	StatusCode       int                 `json:"-"`
+	Message          string              `json:"message"`
+	ValidationErrors map[string][]string `json:"validationErrors"`
+
+	// OAuth2 error fields returned by the identity endpoints
+	ErrorCode        string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+
+	// Context of the failed request
+	Method    string `json:"-"`
+	Path      string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// Error returns the string representation of the error
+func (e *VaultwardenError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = e.ErrorDescription
+	}
+	if message == "" {
+		message = e.ErrorCode
+	}
+
+	request := "request"
+	if e.Method != "" && e.Path != "" {
+		request = fmt.Sprintf("%s %s request", e.Method, e.Path)
+	}
+
+	errMessage := fmt.Sprintf("%s failed with status %d %s", request, e.StatusCode, http.StatusText(e.StatusCode))
+	if message != "" {
+		errMessage += ": " + message
+	}
+	if e.RequestID != "" {
+		errMessage += fmt.Sprintf(" (request ID: %s)", e.RequestID)
+	}
+	return errMessage
+}
+
+// IsNotFound returns whether the error indicates that the requested object does not exist. Messages are only
+// considered for 400 responses, other statuses like 401 mean something else even if the message mentions an object.
+func (e *VaultwardenError) IsNotFound() bool {
+	if e.StatusCode == http.StatusNotFound {
+		return true
+	}
+	if e.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	message := strings.ToLower(e.Message)
+	for _, fragment := range notFoundMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInaccessibleObject returns whether the request was rejected with 401 because the user cannot access
+// the requested object, e.g. an organization the user isn't a member of, rather than because of the session
+func (e *VaultwardenError) IsInaccessibleObject() bool {
+	if e.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	message := strings.ToLower(e.Message)
+	for _, fragment := range inaccessibleObjectMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAuthenticationError returns whether the error indicates missing or invalid authentication
+func (e *VaultwardenError) IsAuthenticationError() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsInvalidTwoFactorCode returns whether the error indicates that the code of the authenticator app
+// provider was rejected, either because it is wrong or because it was used before
+func (e *VaultwardenError) IsInvalidTwoFactorCode() bool {
+	return strings.Contains(strings.ToLower(e.Message), "invalid totp code")
+}
+
+// IsValidationError returns whether the error indicates that the request was rejected as invalid
+func (e *VaultwardenError) IsValidationError() bool {
+	return e.StatusCode == http.StatusBadRequest && !e.IsNotFound()
+}
+
+// ValidationMessages returns the validation error messages sorted by field name
+func (e *VaultwardenError) ValidationMessages() []string {
+	fields := make([]string, 0, len(e.ValidationErrors))
+	for field := range e.ValidationErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var messages []string
+	for _, field := range fields {
+		for _, message := range e.ValidationErrors[field] {
+			if field == "" {
+				messages = append(messages, message)
+			} else {
+				messages = append(messages, field+": "+message)
+			}
+		}
+	}
+	return messages
+}
@@ -0,0 +1,13 @@
+package models
+
+// Group represents a group of users in an organization
+type Group struct {
+	ID             string             `json:"id,omitempty"`
+	OrganizationID string             `json:"organizationId,omitempty"`
+	Name           string             `json:"name"`
+	AccessAll      bool               `json:"accessAll"`
+	ExternalID     string             `json:"externalId,omitempty"`
+	Collections    []CollectionAccess `json:"collections"`
+	Users          []string           `json:"users"`
+	Object         string             `json:"object,omitempty"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "fmt"
+
+// KdfType represents the type of key derivation function used
+type KdfType int
+
+const (
+	KdfTypePBKDF2_SHA256 KdfType = 0
+	KdfTypeArgon2        KdfType = 1
+)
+
+// KdfConfiguration represents the key derivation function configuration
+type KdfConfiguration struct {
+	KdfIterations  int     `json:"kdfIterations,omitempty"`
+	KdfMemory      int     `json:"kdfMemory,omitempty"`
+	KdfParallelism int     `json:"kdfParallelism,omitempty"`
+	KdfType        KdfType `json:"kdfType,omitempty"`
+}
+
+// String returns the string representation of the KDF type
+func (t *KdfType) String() string {
+	switch *t {
+	case KdfTypePBKDF2_SHA256:
+		return "PBKDF2_SHA256"
+	case KdfTypeArgon2:
+		return "Argon2id"
+	default:
+		return "Unknown"
+	}
+}
+
+// FromString returns the KDF type from the string representation
+func (t *KdfType) FromString(s string) error {
+	switch s {
+	case "PBKDF2_SHA256":
+		*t = KdfTypePBKDF2_SHA256
+	case "Argon2id":
+		*t = KdfTypeArgon2
+	default:
+		return fmt.Errorf("invalid KDF type: %s. Must be one of: PBKDF2_SHA256, Argon2id", s)
+	}
+	return nil
+}
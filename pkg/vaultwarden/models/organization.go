@@ -53,6 +53,7 @@ const (
 	UserOrgTypeAdmin   UserOrgType = 1
 	UserOrgTypeUser    UserOrgType = 2
 	UserOrgTypeManager UserOrgType = 3
+	UserOrgTypeCustom  UserOrgType = 4
 )
 
 // String returns the string representation of the user org type
@@ -66,6 +67,8 @@ func (t *UserOrgType) String() string {
 		return "User"
 	case UserOrgTypeManager:
 		return "Manager"
+	case UserOrgTypeCustom:
+		return "Custom"
 	default:
 		return "Unknown"
 	}
@@ -82,8 +85,10 @@ func (t *UserOrgType) FromString(s string) error {
 		*t = UserOrgTypeUser
 	case "Manager":
 		*t = UserOrgTypeManager
+	case "Custom":
+		*t = UserOrgTypeCustom
 	default:
-		return fmt.Errorf("invalid user organization type: %s. Must be one of: Owner, Admin, User, Manager", s)
+		return fmt.Errorf("invalid user organization type: %s. Must be one of: Owner, Admin, User, Manager, Custom", s)
 	}
 	return nil
 }
@@ -93,11 +98,18 @@ type Organization struct {
 	ID             string  `json:"id"`
 	Name           string  `json:"name"`
 	BillingEmail   string  `json:"billingEmail,omitempty"`
+	Identifier     string  `json:"identifier,omitempty"`
 	CollectionName string  `json:"collectionName,omitempty"`
 	Key            string  `json:"key"`
 	Keys           KeyPair `json:"keys,omitempty"`
 	PlanType       int64   `json:"planType"`
 	Enabled        bool    `json:"enabled,omitempty"`
+
+	// Features of the organization, Vaultwarden enables them for all organizations by its settings
+	UseGroups    bool `json:"useGroups,omitempty"`
+	UseEvents    bool `json:"useEvents,omitempty"`
+	UseAPI       bool `json:"useApi,omitempty"`
+	UseDirectory bool `json:"useDirectory,omitempty"`
 }
 
 // OrganizationCollections represents a list of collections in an organization
@@ -107,13 +119,33 @@ type OrganizationCollections struct {
 	Object            string       `json:"object"`
 }
 
+// OrganizationUserPermissions represents the granular permissions of a user with the Custom type
+type OrganizationUserPermissions struct {
+	AccessEventLogs      bool `json:"accessEventLogs"`
+	AccessImportExport   bool `json:"accessImportExport"`
+	AccessReports        bool `json:"accessReports"`
+	CreateNewCollections bool `json:"createNewCollections"`
+	EditAnyCollection    bool `json:"editAnyCollection"`
+	DeleteAnyCollection  bool `json:"deleteAnyCollection"`
+	ManageGroups         bool `json:"manageGroups"`
+	ManagePolicies       bool `json:"managePolicies"`
+	ManageSso            bool `json:"manageSso"`
+	ManageUsers          bool `json:"manageUsers"`
+	ManageResetPassword  bool `json:"manageResetPassword"`
+	ManageScim           bool `json:"manageScim"`
+}
+
 // OrganizationUserDetails represents a user in an organization
 type OrganizationUserDetails struct {
-	ID        string        `json:"id"`
-	Email     string        `json:"email"`
-	Status    UserOrgStatus `json:"status"`
-	Type      UserOrgType   `json:"type"`
-	AccessAll bool          `json:"accessAll"`
+	ID          string                       `json:"id"`
+	UserID      string                       `json:"userId,omitempty"`
+	Email       string                       `json:"email"`
+	Status      UserOrgStatus                `json:"status"`
+	Type        UserOrgType                  `json:"type"`
+	AccessAll   bool                         `json:"accessAll"`
+	Permissions *OrganizationUserPermissions `json:"permissions,omitempty"`
+	Collections []CollectionAccess           `json:"collections,omitempty"`
+	Groups      []string                     `json:"groups,omitempty"`
 }
 
 // OrganizationUsers represents a list of users in an organization
@@ -122,3 +154,11 @@ type OrganizationUsers struct {
 	Data              []OrganizationUserDetails `json:"data"`
 	Object            string                    `json:"object"`
 }
+
+// OrganizationOverview represents an organization of the server as seen through the admin API
+type OrganizationOverview struct {
+	ID        string
+	Name      string
+	Enabled   bool
+	UserCount int
+}
@@ -0,0 +1,27 @@
+package models
+
+// PolicyType represents the type of an organization policy
+type PolicyType int64
+
+const (
+	PolicyTypeTwoFactorAuthentication    PolicyType = 0
+	PolicyTypeMasterPassword             PolicyType = 1
+	PolicyTypePasswordGenerator          PolicyType = 2
+	PolicyTypeSingleOrg                  PolicyType = 3
+	PolicyTypeRequireSso                 PolicyType = 4
+	PolicyTypePersonalOwnership          PolicyType = 5
+	PolicyTypeDisableSend                PolicyType = 6
+	PolicyTypeSendOptions                PolicyType = 7
+	PolicyTypeResetPassword              PolicyType = 8
+	PolicyTypeMaximumVaultTimeout        PolicyType = 9
+	PolicyTypeDisablePersonalVaultExport PolicyType = 10
+)
+
+// Policy represents a policy of an organization. The data holds the options of the policy, which depend on its type.
+type Policy struct {
+	ID             string                 `json:"id,omitempty"`
+	OrganizationID string                 `json:"organizationId,omitempty"`
+	Type           PolicyType             `json:"type"`
+	Enabled        bool                   `json:"enabled"`
+	Data           map[string]interface{} `json:"data"`
+}
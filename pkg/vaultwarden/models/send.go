@@ -0,0 +1,25 @@
+package models
+
+// SendType represents the type of a Send
+type SendType int64
+
+const (
+	SendTypeText SendType = 0
+	SendTypeFile SendType = 1
+)
+
+// Send represents a Send, a text or file shared through a public link
+type Send struct {
+	ID             string   `json:"id"`
+	AccessID       string   `json:"accessId"`
+	Type           SendType `json:"type"`
+	Name           string   `json:"name"`
+	Key            string   `json:"key"`
+	AccessCount    int64    `json:"accessCount"`
+	MaxAccessCount *int64   `json:"maxAccessCount"`
+	Disabled       bool     `json:"disabled"`
+	HideEmail      bool     `json:"hideEmail"`
+	ExpirationDate *string  `json:"expirationDate"`
+	DeletionDate   string   `json:"deletionDate"`
+	Object         string   `json:"object"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Sync represents the response of the sync endpoint
+type Sync struct {
+	Profile     User         `json:"profile"`
+	Collections []Collection `json:"collections"`
+	Object      string       `json:"object"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// TwoFactorType identifies a two-step login provider
+type TwoFactorType int
+
+const (
+	TwoFactorTypeAuthenticator TwoFactorType = 0
+	TwoFactorTypeEmail         TwoFactorType = 1
+	TwoFactorTypeDuo           TwoFactorType = 2
+	TwoFactorTypeYubiKey       TwoFactorType = 3
+	TwoFactorTypeRemember      TwoFactorType = 5
+	TwoFactorTypeWebauthn      TwoFactorType = 7
+)
+
+// TwoFactorProvider represents a two-step login provider of the user
+type TwoFactorProvider struct {
+	Enabled bool          `json:"enabled"`
+	Type    TwoFactorType `json:"type"`
+	Object  string        `json:"object"`
+}
+
+// TwoFactorAuthenticator represents the authenticator app (TOTP) provider of the user, Key being the
+// base32 encoded secret. Until the provider is enabled, Vaultwarden returns a new random secret.
+type TwoFactorAuthenticator struct {
+	Enabled bool   `json:"enabled"`
+	Key     string `json:"key"`
+	Object  string `json:"object"`
+}
+
+// TwoFactorRecover represents the recovery code that disables all two-step login providers of the user
+type TwoFactorRecover struct {
+	Code   string `json:"code"`
+	Object string `json:"object"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type User struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	Email            string         `json:"email"`
+	Key              string         `json:"key"`
+	PrivateKey       string         `json:"privateKey"`
+	TwoFactorEnabled bool           `json:"twoFactorEnabled"`
+	Organizations    []Organization `json:"organizations,omitempty"`
+}
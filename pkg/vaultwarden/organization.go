@@ -0,0 +1,719 @@
+package vaultwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/keybuilder"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"net/mail"
+	"slices"
+	"time"
+)
+
+// ErrLastOrganizationOwner is returned when the last confirmed owner of an organization would be removed or
+// demoted, which Vaultwarden rejects as the organization would be left without an owner
+var ErrLastOrganizationOwner = errors.New("the last confirmed owner of an organization can't be removed or demoted")
+
+// ErrOrganizationAPIKeyInfoUnsupported is returned when the server doesn't provide the metadata of organization API
+// keys without generating them
+var ErrOrganizationAPIKeyInfoUnsupported = errors.New("the server doesn't provide the metadata of organization API keys")
+
+// organizationAPIKeyTypeDefault is the type of the organization API key used by the Directory Connector
+const organizationAPIKeyTypeDefault = 0
+
+// CreateOrganization creates a new Vaultwarden organization with a 2048-bit RSA key pair
+func (c *Client) CreateOrganization(ctx context.Context, org models.Organization) (*models.Organization, error) {
+	return c.CreateOrganizationWithKeySize(ctx, org, keybuilder.RSAKeySize2048)
+}
+
+// CreateOrganizationWithKeySize creates a new Vaultwarden organization with an RSA key pair of the given size in bits
+func (c *Client) CreateOrganizationWithKeySize(ctx context.Context, org models.Organization, keySize int) (*models.Organization, error) {
+	// First ensure we have valid authentication and thus the private key
+	if err := c.ensureUserAuth(ctx); err != nil {
+		return nil, fmt.Errorf("authentication required: %w", err)
+	}
+
+	// Create a shared key for the organization
+	encSharedKey, sharedKey, err := keybuilder.GenerateSharedKey(&c.privateKey().PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate shared key: %w", err)
+	}
+	org.Key = encSharedKey
+
+	// Encrypt the collection name
+	collectionName, err := c.encryptString([]byte(org.CollectionName), *sharedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt collection name: %w", err)
+	}
+	org.CollectionName = collectionName
+
+	// Create the public and private keys
+	publicKey, encryptedPrivateKey, err := keybuilder.GenerateEncryptedRSAKeyPairWithSize(*sharedKey, keySize)
+	if err != nil {
+		sharedKey.Zero()
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+
+	// Add the keys to the organization
+	org.Keys = models.KeyPair{
+		PublicKey:           publicKey,
+		EncryptedPrivateKey: encryptedPrivateKey,
+	}
+
+	// Set billing email to current user's email if not provided
+	if org.BillingEmail == "" {
+		email, err := helpers.ParseJWTEmail(c.accessToken())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user email from token: %w", err)
+		}
+		org.BillingEmail = email
+	}
+
+	var orgResp models.Organization
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/organizations", org, &orgResp); err != nil {
+		sharedKey.Zero()
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	// Cache the organization secret
+	c.cacheOrganizationSecrets(map[string]OrganizationSecret{
+		orgResp.ID: {
+			Key:              *sharedKey,
+			OrganizationUUID: orgResp.ID,
+			Name:             orgResp.Name,
+		},
+	})
+	tflog.SubsystemDebug(WithLogSubsystems(ctx), LogSubsystemCrypto, "Generated organization key", map[string]interface{}{
+		LogFieldOrganizationID: orgResp.ID,
+		LogFieldResource:       "organization",
+	})
+
+	return &orgResp, nil
+}
+
+// GetOrganization retrieves an organization by its ID
+func (c *Client) GetOrganization(ctx context.Context, ID string) (*models.Organization, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("organization ID is required")
+	}
+
+	var org models.Organization
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s", ID), nil, &org); err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return &org, nil
+}
+
+// OrganizationPublicKey represents the response of the organization public key endpoint
+type OrganizationPublicKey struct {
+	PublicKey string `json:"publicKey"`
+	Object    string `json:"object"`
+}
+
+// GetOrganizationPublicKey retrieves the public key of an organization, a base64 encoded DER key
+func (c *Client) GetOrganizationPublicKey(ctx context.Context, ID string) (string, error) {
+	if ID == "" {
+		return "", fmt.Errorf("organization ID is required")
+	}
+
+	var keyResp OrganizationPublicKey
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s/public-key", ID), nil, &keyResp); err != nil {
+		return "", fmt.Errorf("failed to get organization public key: %w", err)
+	}
+
+	return keyResp.PublicKey, nil
+}
+
+// OrganizationAPIKeyRequest represents the request body for the organization API key endpoint
+type OrganizationAPIKeyRequest struct {
+	MasterPasswordHash string `json:"masterPasswordHash"`
+}
+
+// OrganizationAPIKeyInfo represents the metadata of the organization API key. The secret itself is
+// deliberately not decoded, so it never ends up in memory or logs.
+type OrganizationAPIKeyInfo struct {
+	RevisionDate string `json:"revisionDate"`
+	Object       string `json:"object"`
+}
+
+// GetOrganizationAPIKeyInfo retrieves the metadata of the API key of an organization, or nil if the organization has
+// no API key yet. It uses the API key information endpoint of the Bitwarden API, as requesting the API key itself
+// generates it if it doesn't exist. ErrOrganizationAPIKeyInfoUnsupported is returned if the server doesn't provide
+// that endpoint.
+func (c *Client) GetOrganizationAPIKeyInfo(ctx context.Context, ID string) (*OrganizationAPIKeyInfo, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("organization ID is required")
+	}
+
+	keys, err := getAllPages[OrganizationAPIKeyInfo](ctx, c, fmt.Sprintf("/api/organizations/%s/api-key-information/%d", ID, organizationAPIKeyTypeDefault))
+	if err != nil {
+		var apiErr *models.VaultwardenError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("failed to get organization API key information: %w", ErrOrganizationAPIKeyInfoUnsupported)
+		}
+		return nil, fmt.Errorf("failed to get organization API key information: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return &keys[0], nil
+}
+
+// RotateOrganizationAPIKey generates a new API key for an organization, invalidating the previous one
+func (c *Client) RotateOrganizationAPIKey(ctx context.Context, ID string) (*OrganizationAPIKeyInfo, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("organization ID is required")
+	}
+
+	hashedPassword, err := c.masterPasswordHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := OrganizationAPIKeyRequest{
+		MasterPasswordHash: hashedPassword,
+	}
+
+	var keyResp OrganizationAPIKeyInfo
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/organizations/%s/rotate-api-key", ID), body, &keyResp); err != nil {
+		return nil, fmt.Errorf("failed to rotate organization API key: %w", err)
+	}
+
+	return &keyResp, nil
+}
+
+// UpdateOrganization updates an organization by its ID
+func (c *Client) UpdateOrganization(ctx context.Context, ID string, org models.Organization) (*models.Organization, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("organization ID is required")
+	}
+
+	var orgResp models.Organization
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/organizations/%s", ID), org, &orgResp); err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return &orgResp, nil
+}
+
+// DeleteOrganizationRequest represents the request body for deleting an organization
+type DeleteOrganizationRequest struct {
+	MasterPasswordHash string `json:"masterPasswordHash"`
+}
+
+// DeleteOrganization deletes an organization by its ID
+func (c *Client) DeleteOrganization(ctx context.Context, ID string) error {
+	if ID == "" {
+		return fmt.Errorf("organization ID is required")
+	}
+
+	// The admin endpoint does not need the master password, so it also works with OAuth2 only configurations
+	if c.Credentials.AdminToken != "" {
+		return c.deleteOrganizationAsAdmin(ctx, ID)
+	}
+
+	hashedPassword, err := c.masterPasswordHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := DeleteOrganizationRequest{
+		MasterPasswordHash: hashedPassword,
+	}
+
+	defer c.orgUsersCache.invalidate(ID)
+	defer c.orgCollectionsCache.invalidate(ID)
+
+	if _, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/organizations/%s", ID), body, nil); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	return nil
+}
+
+// deleteOrganizationAsAdmin deletes an organization by its ID through the admin API
+func (c *Client) deleteOrganizationAsAdmin(ctx context.Context, ID string) error {
+	defer c.orgUsersCache.invalidate(ID)
+	defer c.orgCollectionsCache.invalidate(ID)
+
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/admin/organizations/%s/delete", ID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	return nil
+}
+
+// InviteOrganizationUserRequest represents the request body for inviting a user to an organization
+type InviteOrganizationUserRequest struct {
+	Emails               []string                            `json:"emails"`
+	Collections          []models.CollectionAccess           `json:"collections"`
+	AccessAll            bool                                `json:"accessAll"`
+	AccessSecretsManager bool                                `json:"accessSecretsManager"`
+	Type                 models.UserOrgType                  `json:"type"`
+	Groups               []string                            `json:"groups"`
+	Permissions          *models.OrganizationUserPermissions `json:"permissions,omitempty"`
+}
+
+// InviteOrganizationUser invites a new user to an organization
+func (c *Client) InviteOrganizationUser(ctx context.Context, req InviteOrganizationUserRequest, email, orgID string) error {
+	return c.InviteOrganizationUsers(ctx, req, []string{email}, orgID)
+}
+
+// InviteOrganizationUsers invites several users with the same settings to an organization in a single request
+func (c *Client) InviteOrganizationUsers(ctx context.Context, req InviteOrganizationUserRequest, emails []string, orgID string) error {
+	// Validate email format
+	for _, email := range emails {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return fmt.Errorf("invalid email format: %s", email)
+		}
+	}
+
+	// Add the emails to the request
+	req.Emails = append(req.Emails, emails...)
+
+	// Set empty lists for collections and groups when none are provided
+	if req.Collections == nil {
+		req.Collections = []models.CollectionAccess{}
+	}
+	if req.Groups == nil {
+		req.Groups = []string{}
+	}
+
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/organizations/%s/users/invite", orgID), req, nil); err != nil {
+		return fmt.Errorf("failed to invite user to organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrganizationUsers retrieves all users in an organization. The listing is cached until
+// the users of the organization are changed through the client.
+func (c *Client) GetOrganizationUsers(ctx context.Context, orgID string) (*models.OrganizationUsers, error) {
+	if users, exists := c.orgUsersCache.get(orgID); exists {
+		return &models.OrganizationUsers{
+			Data:   users,
+			Object: "list",
+		}, nil
+	}
+
+	generation := c.orgUsersCache.begin()
+	users, err := getAllPages[models.OrganizationUserDetails](ctx, c, fmt.Sprintf("/api/organizations/%s/users", orgID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization users: %w", err)
+	}
+	c.orgUsersCache.set(orgID, generation, users)
+
+	return &models.OrganizationUsers{
+		Data:   users,
+		Object: "list",
+	}, nil
+}
+
+// GetOrganizationUserByEmail retrieves a user in an organization by their email
+func (c *Client) GetOrganizationUserByEmail(ctx context.Context, email, orgID string) (*models.OrganizationUserDetails, error) {
+	// First get all users in the organization
+	users, err := c.GetOrganizationUsers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization users: %w", err)
+	}
+
+	// Find the user by email
+	for _, user := range users.Data {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+
+	return nil, &models.VaultwardenError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("user %s not found in organization %s", email, orgID),
+	}
+}
+
+// GetOrganizationOwners retrieves the confirmed owners of an organization, which Vaultwarden requires at least one of
+func (c *Client) GetOrganizationOwners(ctx context.Context, orgID string) ([]models.OrganizationUserDetails, error) {
+	users, err := c.GetOrganizationUsers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []models.OrganizationUserDetails
+	for _, user := range users.Data {
+		if user.Type == models.UserOrgTypeOwner && user.Status == models.UserOrgStatusConfirmed {
+			owners = append(owners, user)
+		}
+	}
+
+	return owners, nil
+}
+
+// CheckOrganizationOwnersRemoval returns ErrLastOrganizationOwner if removing or demoting the given users would
+// leave the organization without a confirmed owner, so that callers can fail before changing anything
+func (c *Client) CheckOrganizationOwnersRemoval(ctx context.Context, orgID string, userIDs []string) error {
+	owners, err := c.GetOrganizationOwners(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	// Organizations without a confirmed owner, e.g. while the owner is still invited, are left to the API
+	if len(owners) == 0 {
+		return nil
+	}
+	for _, owner := range owners {
+		if !slices.Contains(userIDs, owner.ID) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("organization %s: %w", orgID, ErrLastOrganizationOwner)
+}
+
+// TransferOrganizationOwnership makes the user toUserID an owner of the organization if the user fromUserID is its
+// last confirmed owner, so that fromUserID can be removed or demoted afterwards. The new owner has to be a confirmed
+// member, as only confirmed members hold the organization key. Nothing is changed if there are other owners.
+func (c *Client) TransferOrganizationOwnership(ctx context.Context, orgID, fromUserID, toUserID string) error {
+	err := c.CheckOrganizationOwnersRemoval(ctx, orgID, []string{fromUserID})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrLastOrganizationOwner) {
+		return err
+	}
+
+	successor, err := c.GetOrganizationUser(ctx, toUserID, orgID)
+	if err != nil {
+		return err
+	}
+	if successor.Status != models.UserOrgStatusConfirmed {
+		return fmt.Errorf("failed to transfer ownership of organization %s: the new owner %s is not a confirmed member", orgID, successor.Email)
+	}
+
+	// The collections are kept, as Vaultwarden replaces them on every update
+	successor.Type = models.UserOrgTypeOwner
+	successor.Permissions = nil
+	if _, err := c.UpdateOrganizationUser(ctx, toUserID, orgID, *successor); err != nil {
+		return fmt.Errorf("failed to transfer ownership of organization %s: %w", orgID, err)
+	}
+
+	return nil
+}
+
+// GetOrganizationUser retrieves a user in an organization by their ID
+func (c *Client) GetOrganizationUser(ctx context.Context, userID, orgID string) (*models.OrganizationUserDetails, error) {
+	var user models.OrganizationUserDetails
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s/users/%s", orgID, userID), nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get organization user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// WaitForOrganizationUserStatus polls a user in an organization until it has reached at least the given status,
+// for example until an invited user has accepted the invitation, or the context is done
+func (c *Client) WaitForOrganizationUserStatus(ctx context.Context, userID, orgID string, status models.UserOrgStatus, interval time.Duration) (*models.OrganizationUserDetails, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		user, err := c.GetOrganizationUser(ctx, userID, orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.Status >= status {
+			return user, nil
+		}
+
+		// Revoked users don't make any progress until they are restored
+		if user.Status == models.UserOrgStatusRevoked {
+			return nil, fmt.Errorf("organization user %s has been revoked", userID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for organization user %s to reach status %s, current status is %s: %w", userID, status.String(), user.Status.String(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReinviteOrganizationUser sends the invitation email to an invited user in an organization again
+func (c *Client) ReinviteOrganizationUser(ctx context.Context, userID, orgID string) error {
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/organizations/%s/users/%s/reinvite", orgID, userID), nil, nil); err != nil {
+		return fmt.Errorf("failed to reinvite organization user: %w", err)
+	}
+
+	return nil
+}
+
+// UserPublicKeyResponse represents the response body of the user public key endpoint
+type UserPublicKeyResponse struct {
+	UserID    string `json:"userId"`
+	PublicKey string `json:"publicKey"`
+	Object    string `json:"object"`
+}
+
+// GetUserPublicKey retrieves the public key of a user by their ID, which is used to share keys with them
+func (c *Client) GetUserPublicKey(ctx context.Context, ID string) (string, error) {
+	if ID == "" {
+		return "", fmt.Errorf("user ID is required")
+	}
+
+	var keyResp UserPublicKeyResponse
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/users/%s/public-key", ID), nil, &keyResp); err != nil {
+		return "", fmt.Errorf("failed to get user public key: %w", err)
+	}
+
+	return keyResp.PublicKey, nil
+}
+
+// ConfirmOrganizationUserRequest represents the request body for confirming a user in an organization
+type ConfirmOrganizationUserRequest struct {
+	Key string `json:"key"`
+}
+
+// ConfirmOrganizationUser confirms a user in an organization who has accepted the invitation, sharing the
+// organization key with them. The key is encrypted with the public key of the user as reported by the server,
+// so the fingerprint of the user is not verified like the official clients may ask to.
+func (c *Client) ConfirmOrganizationUser(ctx context.Context, userID, orgID string) error {
+	user, err := c.GetOrganizationUser(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if user.Status != models.UserOrgStatusAccepted {
+		return fmt.Errorf("failed to confirm organization user %s: the user has status %s, only accepted users can be confirmed", user.Email, user.Status.String())
+	}
+
+	orgSecret, err := c.GetOrganizationSecret(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	encodedPublicKey, err := c.GetUserPublicKey(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := keybuilder.ParsePublicKey(encodedPublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key of user %s: %w", user.Email, err)
+	}
+
+	encryptedKey, err := keybuilder.RSAEncrypt(orgSecret.Key.Key, publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt organization key: %w", err)
+	}
+
+	body := ConfirmOrganizationUserRequest{
+		Key: encryptedKey,
+	}
+
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/organizations/%s/users/%s/confirm", orgID, userID), body, nil); err != nil {
+		return fmt.Errorf("failed to confirm organization user: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOrganizationUser deletes a user in an organization by their ID
+func (c *Client) DeleteOrganizationUser(ctx context.Context, userID, orgID string) error {
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/organizations/%s/users/%s", orgID, userID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete organization user: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOrganizationUser revokes the access of a user to an organization. Unlike deleting the user, it stays a member
+// of the organization and keeps its role and collections, so its access can be restored later.
+func (c *Client) RevokeOrganizationUser(ctx context.Context, userID, orgID string) error {
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/organizations/%s/users/%s/revoke", orgID, userID), nil, nil); err != nil {
+		return fmt.Errorf("failed to revoke organization user: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreOrganizationUser restores the access of a revoked user to an organization with the status it had before
+func (c *Client) RestoreOrganizationUser(ctx context.Context, userID, orgID string) error {
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/organizations/%s/users/%s/restore", orgID, userID), nil, nil); err != nil {
+		return fmt.Errorf("failed to restore organization user: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOrganizationUsersRequest represents the request body for deleting several users in an organization
+type DeleteOrganizationUsersRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// DeleteOrganizationUsers deletes several users in an organization by their IDs in a single request
+func (c *Client) DeleteOrganizationUsers(ctx context.Context, userIDs []string, orgID string) error {
+	body := DeleteOrganizationUsersRequest{
+		IDs: userIDs,
+	}
+
+	defer c.orgUsersCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/organizations/%s/users", orgID), body, nil); err != nil {
+		return fmt.Errorf("failed to delete organization users: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrganizationUserGroups retrieves the IDs of the groups a user in an organization is a member of. Without
+// group support on the server, the user is in no groups.
+func (c *Client) GetOrganizationUserGroups(ctx context.Context, userID, orgID string) ([]string, error) {
+	org, err := c.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.UseGroups {
+		return []string{}, nil
+	}
+
+	var groupIDs []string
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s/users/%s/groups", orgID, userID), nil, &groupIDs); err != nil {
+		return nil, fmt.Errorf("failed to get organization user groups: %w", err)
+	}
+
+	return groupIDs, nil
+}
+
+// UpdateOrganizationUser updates a user in an organization by their ID. Vaultwarden replaces the groups of the user
+// on every update, so the current groups are kept unless the groups are given.
+func (c *Client) UpdateOrganizationUser(ctx context.Context, userID, orgID string, user models.OrganizationUserDetails) (*models.OrganizationUserDetails, error) {
+	if user.Groups == nil {
+		groupIDs, err := c.GetOrganizationUserGroups(ctx, userID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		user.Groups = groupIDs
+	}
+
+	defer c.orgUsersCache.invalidate(orgID)
+	var userResp models.OrganizationUserDetails
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/organizations/%s/users/%s", orgID, userID), user, &userResp); err != nil {
+		return nil, fmt.Errorf("failed to update organization user: %w", err)
+	}
+
+	return &userResp, nil
+}
+
+// UpdateOrganizationUserCollections replaces the collections a user in an organization has access to. Vaultwarden
+// updates the role and permissions of a user together with the collections, so they are kept as they are.
+func (c *Client) UpdateOrganizationUserCollections(ctx context.Context, userID, orgID string, collections []models.CollectionAccess) (*models.OrganizationUserDetails, error) {
+	user, err := c.GetOrganizationUser(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	user.Collections = collections
+
+	return c.UpdateOrganizationUser(ctx, userID, orgID, *user)
+}
+
+// GetOrganizationSecret retrieves the secret of an organization, loading the organization keys
+// of the authenticated user via the sync endpoint if the organization is not cached yet
+func (c *Client) GetOrganizationSecret(ctx context.Context, orgID string) (*OrganizationSecret, error) {
+	// First ensure we have valid authentication and thus the private key
+	if err := c.ensureUserAuth(ctx); err != nil {
+		return nil, fmt.Errorf("authentication required: %w", err)
+	}
+
+	// Get organization data from cache
+	if orgSecret, exists := c.cachedOrganizationSecret(orgID); exists {
+		return &orgSecret, nil
+	}
+
+	// Only refresh the organization keys once at a time
+	c.orgKeysMu.Lock()
+	defer c.orgKeysMu.Unlock()
+
+	// Another request might have loaded the keys while we were waiting
+	if orgSecret, exists := c.cachedOrganizationSecret(orgID); exists {
+		return &orgSecret, nil
+	}
+
+	// The organization might have been created or joined after logging in, refresh the cache
+	ctx = WithLogSubsystems(ctx)
+	tflog.SubsystemDebug(ctx, LogSubsystemCrypto, "Loading organization keys via sync", map[string]interface{}{
+		LogFieldOrganizationID: orgID,
+	})
+	syncResp, err := c.Sync(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load organization keys: %w", err)
+	}
+
+	secrets, err := decryptOrganizationSecrets(syncResp.Profile.Organizations, c.privateKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load organization keys: %w", err)
+	}
+	c.cacheOrganizationSecrets(secrets)
+	tflog.SubsystemDebug(ctx, LogSubsystemCrypto, "Loaded organization keys", map[string]interface{}{
+		LogFieldOrganizationID: orgID,
+		"organizations":        len(secrets),
+	})
+
+	orgSecret, exists := c.cachedOrganizationSecret(orgID)
+	if !exists {
+		return nil, fmt.Errorf("organization %s not found or not accessible to the authenticated user", orgID)
+	}
+
+	return &orgSecret, nil
+}
+
+// EncryptOrganizationString encrypts a value with the organization key
+func (c *Client) EncryptOrganizationString(ctx context.Context, orgID, value string) (string, error) {
+	orgSecret, err := c.GetOrganizationSecret(ctx, orgID)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedValue, err := c.encryptString([]byte(value), orgSecret.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt value: %w", err)
+	}
+
+	return encryptedValue, nil
+}
+
+// DecryptOrganizationString decrypts a value that was encrypted with the organization key
+func (c *Client) DecryptOrganizationString(ctx context.Context, orgID, value string) (string, error) {
+	orgSecret, err := c.GetOrganizationSecret(ctx, orgID)
+	if err != nil {
+		return "", err
+	}
+
+	// Convert the value to an EncryptedString
+	encString, err := encryptedstring.NewFromEncryptedValue(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse encrypted value: %w", err)
+	}
+
+	// Decrypt the value using the organization key
+	decryptedBytes, err := crypt.Decrypt(encString, &orgSecret.Key)
+	if err != nil {
+		tflog.SubsystemDebug(WithLogSubsystems(ctx), LogSubsystemCrypto, "Failed to decrypt organization value", map[string]interface{}{
+			LogFieldOrganizationID: orgID,
+			"error":                err.Error(),
+		})
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return string(decryptedBytes), nil
+}
@@ -0,0 +1,193 @@
+package vaultwarden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"strings"
+)
+
+// CreateOrganizationCollection creates a new Vaultwarden organization collection
+func (c *Client) CreateOrganizationCollection(ctx context.Context, orgID string, collection models.Collection) (*models.Collection, error) {
+	// Get the organization key, loading it if necessary
+	orgSecret, err := c.GetOrganizationSecret(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt the collection name using the organization key
+	collectionName, err := c.encryptString([]byte(collection.Name), orgSecret.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt collection name: %w", err)
+	}
+	collection.Name = collectionName
+
+	// Set empty lists for groups and users when none are provided
+	if collection.Groups == nil {
+		collection.Groups = []string{}
+	}
+
+	if collection.Users == nil {
+		collection.Users = []string{}
+	}
+
+	defer c.orgCollectionsCache.invalidate(orgID)
+	var collectionResp models.Collection
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/organizations/%s/collections", orgID), collection, &collectionResp); err != nil {
+		return nil, fmt.Errorf("failed to create organization collection: %w", err)
+	}
+
+	return &collectionResp, nil
+}
+
+// GetOrganizationCollections retrieves all collections of an organization. The listing is cached
+// until the collections of the organization are changed through the client.
+func (c *Client) GetOrganizationCollections(ctx context.Context, orgID string) (*models.OrganizationCollections, error) {
+	if collections, exists := c.orgCollectionsCache.get(orgID); exists {
+		return &models.OrganizationCollections{
+			Data:   collections,
+			Object: "list",
+		}, nil
+	}
+
+	generation := c.orgCollectionsCache.begin()
+	collections, err := getAllPages[models.Collection](ctx, c, fmt.Sprintf("/api/organizations/%s/collections", orgID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization collections: %w", err)
+	}
+	c.orgCollectionsCache.set(orgID, generation, collections)
+
+	return &models.OrganizationCollections{
+		Data:   collections,
+		Object: "list",
+	}, nil
+}
+
+// GetOrganizationCollection retrieves a specific collection from an organization. The collection
+// is served from the sync index if possible, falling back to listing the organization collections
+// for collections the user is not assigned to or if the sync fails.
+func (c *Client) GetOrganizationCollection(ctx context.Context, orgID string, collectionID string) (*models.Collection, error) {
+	if index, err := c.loadSyncIndex(ctx); err == nil {
+		if collection, exists := index.collection(orgID, collectionID); exists {
+			return &collection, nil
+		}
+	}
+
+	listResp, err := c.GetOrganizationCollections(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the specific collection in the response
+	for _, collection := range listResp.Data {
+		if collection.ID == collectionID {
+			return &collection, nil
+		}
+	}
+
+	return nil, &models.VaultwardenError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("collection %s not found in organization %s", collectionID, orgID),
+	}
+}
+
+// FindOrganizationCollectionByName retrieves the first collection of an organization with the given decrypted name
+func (c *Client) FindOrganizationCollectionByName(ctx context.Context, orgID, name string) (*models.Collection, error) {
+	listResp, err := c.GetOrganizationCollections(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, collection := range listResp.Data {
+		collectionName, err := c.DecryptOrganizationString(ctx, orgID, collection.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt name of collection %s: %w", collection.ID, err)
+		}
+
+		if collectionName == name {
+			return &collection, nil
+		}
+	}
+
+	return nil, &models.VaultwardenError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("collection %s not found in organization %s", name, orgID),
+	}
+}
+
+// CreateOrganizationCollectionParents creates the missing parent collections of a nested collection name, which
+// Vaultwarden separates with "/", e.g. "Engineering" for "Engineering/Backend". The names of the created parent
+// collections are returned.
+func (c *Client) CreateOrganizationCollectionParents(ctx context.Context, orgID, name string) ([]string, error) {
+	parts := strings.Split(name, "/")
+
+	var created []string
+	for i := 1; i < len(parts); i++ {
+		// Empty segments don't form a parent, e.g. in "/Backend" or "Engineering//Backend"
+		if parts[i-1] == "" {
+			continue
+		}
+
+		parent := strings.Join(parts[:i], "/")
+		_, err := c.FindOrganizationCollectionByName(ctx, orgID, parent)
+		if err == nil {
+			continue
+		}
+
+		var vwErr *models.VaultwardenError
+		if !errors.As(err, &vwErr) || !vwErr.IsNotFound() {
+			return created, fmt.Errorf("failed to look up parent collection %s: %w", parent, err)
+		}
+
+		if _, err := c.CreateOrganizationCollection(ctx, orgID, models.Collection{Name: parent}); err != nil {
+			return created, fmt.Errorf("failed to create parent collection %s: %w", parent, err)
+		}
+		created = append(created, parent)
+	}
+
+	return created, nil
+}
+
+// UpdateOrganizationCollection updates an existing Vaultwarden organization collection
+func (c *Client) UpdateOrganizationCollection(ctx context.Context, orgID, colID string, collection models.Collection) (*models.Collection, error) {
+	// Get the organization key, loading it if necessary
+	orgSecret, err := c.GetOrganizationSecret(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt the collection name using the organization key
+	collectionName, err := c.encryptString([]byte(collection.Name), orgSecret.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt collection name: %w", err)
+	}
+	collection.Name = collectionName
+
+	// Set empty lists for groups and users when none are provided
+	if collection.Groups == nil {
+		collection.Groups = []string{}
+	}
+
+	if collection.Users == nil {
+		collection.Users = []string{}
+	}
+
+	defer c.orgCollectionsCache.invalidate(orgID)
+	var collectionResp models.Collection
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/organizations/%s/collections/%s", orgID, colID), collection, &collectionResp); err != nil {
+		return nil, fmt.Errorf("failed to update organization collection: %w", err)
+	}
+
+	return &collectionResp, nil
+}
+
+func (c *Client) DeleteOrganizationCollection(ctx context.Context, orgID, colID string) error {
+	defer c.orgCollectionsCache.invalidate(orgID)
+	if _, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/organizations/%s/collections/%s", orgID, colID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete organization collection: %w", err)
+	}
+
+	return nil
+}
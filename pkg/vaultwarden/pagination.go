@@ -0,0 +1,52 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// listResponse represents a single page of a list endpoint response
+type listResponse[T any] struct {
+	ContinuationToken string `json:"continuationToken"`
+	Data              []T    `json:"data"`
+	Object            string `json:"object"`
+}
+
+// getAllPages retrieves every page of a list endpoint by following the continuation token
+func getAllPages[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var items []T
+	seenTokens := make(map[string]bool)
+	continuationToken := ""
+
+	for {
+		pagePath := path
+		if continuationToken != "" {
+			separator := "?"
+			if strings.Contains(path, "?") {
+				separator = "&"
+			}
+			pagePath = path + separator + "continuationToken=" + url.QueryEscape(continuationToken)
+		}
+
+		var page listResponse[T]
+		if _, err := c.doRequest(ctx, http.MethodGet, pagePath, nil, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+
+		// Stop when there are no more pages
+		if page.ContinuationToken == "" {
+			return items, nil
+		}
+
+		// Guard against servers returning the same token over and over again
+		if seenTokens[page.ContinuationToken] {
+			return nil, fmt.Errorf("pagination of %s did not advance, continuation token %q was returned twice", path, page.ContinuationToken)
+		}
+		seenTokens[page.ContinuationToken] = true
+		continuationToken = page.ContinuationToken
+	}
+}
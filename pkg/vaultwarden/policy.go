@@ -0,0 +1,34 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+)
+
+// GetOrganizationPolicy retrieves a policy of an organization by its type. Vaultwarden returns a disabled policy
+// if it has never been configured.
+func (c *Client) GetOrganizationPolicy(ctx context.Context, orgID string, policyType models.PolicyType) (*models.Policy, error) {
+	var policy models.Policy
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/organizations/%s/policies/%d", orgID, policyType), nil, &policy); err != nil {
+		return nil, fmt.Errorf("failed to get organization policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// UpdateOrganizationPolicy enables, disables or configures a policy of an organization
+func (c *Client) UpdateOrganizationPolicy(ctx context.Context, orgID string, policy models.Policy) (*models.Policy, error) {
+	// Vaultwarden rejects a missing data object
+	if policy.Data == nil {
+		policy.Data = map[string]interface{}{}
+	}
+
+	var policyResp models.Policy
+	if _, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/organizations/%s/policies/%d", orgID, policy.Type), policy, &policyResp); err != nil {
+		return nil, fmt.Errorf("failed to update organization policy: %w", err)
+	}
+
+	return &policyResp, nil
+}
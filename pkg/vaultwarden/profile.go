@@ -0,0 +1,92 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/keybuilder"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"time"
+)
+
+// ChangeKdfRequest represents the request body for changing the KDF configuration of the authenticated user
+type ChangeKdfRequest struct {
+	Kdf                   models.KdfType `json:"kdf"`
+	KdfIterations         int            `json:"kdfIterations"`
+	KdfMemory             int            `json:"kdfMemory,omitempty"`
+	KdfParallelism        int            `json:"kdfParallelism,omitempty"`
+	MasterPasswordHash    string         `json:"masterPasswordHash"`
+	NewMasterPasswordHash string         `json:"newMasterPasswordHash"`
+	Key                   string         `json:"key"`
+}
+
+// GetProfile retrieves the user's profile
+func (c *Client) GetProfile(ctx context.Context) (*models.User, error) {
+	// Ensure we have valid authentication
+	if err := c.ensureUserAuth(ctx); err != nil {
+		return nil, fmt.Errorf("authentication required: %w", err)
+	}
+
+	var user models.User
+	if _, err := c.doRequest(ctx, http.MethodGet, "/api/accounts/profile", nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	return &user, nil
+}
+
+// ChangeKdf changes the KDF configuration of the authenticated user, e.g. from PBKDF2 to Argon2id. The master
+// key is derived again with the new configuration, which changes the master password hash and requires the
+// user key to be encrypted again. The user key itself and thereby the vault data stay the same.
+//
+// Vaultwarden logs out all sessions of the user, so the client logs in again with the new configuration
+// on the next request.
+func (c *Client) ChangeKdf(ctx context.Context, kdfConfig models.KdfConfiguration) error {
+	userKey, err := c.userKey(ctx)
+	if err != nil {
+		return err
+	}
+	defer userKey.Zero()
+
+	hashedPassword, err := c.masterPasswordHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	newMasterKey, err := keybuilder.BuildPreloginKey(c.Credentials.MasterPassword, c.Credentials.Email, &kdfConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build master key for the new KDF configuration: %w", err)
+	}
+	defer newMasterKey.Zero()
+
+	_, encryptedUserKey, err := keybuilder.EncryptEncryptionKey(*newMasterKey, userKey.Key)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt user key: %w", err)
+	}
+
+	body := ChangeKdfRequest{
+		Kdf:                   kdfConfig.KdfType,
+		KdfIterations:         kdfConfig.KdfIterations,
+		MasterPasswordHash:    hashedPassword,
+		NewMasterPasswordHash: crypt.HashPassword(c.Credentials.MasterPassword, *newMasterKey, false),
+		Key:                   encryptedUserKey,
+	}
+	if kdfConfig.KdfType == models.KdfTypeArgon2 {
+		body.KdfMemory = kdfConfig.KdfMemory
+		body.KdfParallelism = kdfConfig.KdfParallelism
+	}
+
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/accounts/kdf", body, nil); err != nil {
+		return fmt.Errorf("failed to change KDF configuration: %w", err)
+	}
+
+	// The session was logged out, the next login fetches the new configuration with a prelogin
+	c.authMu.Lock()
+	c.AuthState.AccessToken = ""
+	c.AuthState.TokenExpiresAt = time.Time{}
+	c.AuthState.KdfConfig = nil
+	c.authMu.Unlock()
+
+	return nil
+}
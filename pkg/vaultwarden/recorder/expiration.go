@@ -0,0 +1,93 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// shiftTokenExpirations moves the expiration of the access token in a token response by the given
+// duration. The client doesn't verify the signature of the token, so it is kept as is. Other bodies
+// are returned unchanged.
+func shiftTokenExpirations(body []byte, shift time.Duration) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+
+	var token string
+	if err := json.Unmarshal(fields["access_token"], &token); err != nil || token == "" {
+		return body
+	}
+
+	shifted, ok := shiftJWTExpiration(token, shift)
+	if !ok {
+		return body
+	}
+
+	encoded, err := json.Marshal(shifted)
+	if err != nil {
+		return body
+	}
+	fields["access_token"] = encoded
+
+	shiftedBody, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return shiftedBody
+}
+
+// shiftJWTExpiration moves the exp claim of a JWT by the given duration
+func shiftJWTExpiration(token string, shift time.Duration) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", false
+	}
+
+	var claims map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return "", false
+	}
+
+	exp, ok := claims["exp"].(json.Number)
+	if !ok {
+		return "", false
+	}
+	expiration, err := exp.Int64()
+	if err != nil {
+		return "", false
+	}
+	claims["exp"] = expiration + int64(shift.Seconds())
+
+	payload, err = json.Marshal(claims)
+	if err != nil {
+		return "", false
+	}
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+
+	return strings.Join(parts, "."), true
+}
+
+// shiftCookieExpirations moves the expiration of the cookies set by a response by the given duration
+func shiftCookieExpirations(header http.Header, shift time.Duration) {
+	values := header.Values("Set-Cookie")
+	for i, value := range values {
+		cookie, err := http.ParseSetCookie(value)
+		if err != nil || cookie.Expires.IsZero() {
+			continue
+		}
+		cookie.Expires = cookie.Expires.Add(shift)
+		values[i] = cookie.String()
+	}
+}
@@ -0,0 +1,303 @@
+// Package recorder implements a transport middleware for the Vaultwarden client that records the
+// interactions with a server to a cassette file and replays them later without a server. Tests can
+// capture their interactions once against a real Vaultwarden instance and then run deterministically
+// and without the latency of the key derivation on the server.
+//
+// Keys generated by the client while replaying, e.g. for a new organization, differ from the recorded
+// ones. The recorded values encrypted with these keys can therefore only be decrypted by clients which
+// load the recorded keys from the server, which is the case for every Terraform command after the one
+// that created the key.
+//
+// Cassettes contain the responses of the server as they were received, including the access tokens
+// and encrypted keys of the test accounts. Only record cassettes with throwaway test accounts.
+package recorder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ModeEnvVar is the environment variable that selects the mode returned by ModeFromEnv
+const ModeEnvVar = "VW_TEST_RECORDER"
+
+// Mode defines whether the recorder records or replays interactions
+type Mode int
+
+const (
+	// ModeDisabled passes all requests through to the server
+	ModeDisabled Mode = iota
+	// ModeRecord passes all requests through to the server and records the interactions
+	ModeRecord
+	// ModeReplay answers all requests from the recorded interactions without contacting the server
+	ModeReplay
+)
+
+// String returns the string representation of the mode
+func (m *Mode) String() string {
+	switch *m {
+	case ModeDisabled:
+		return "disabled"
+	case ModeRecord:
+		return "record"
+	case ModeReplay:
+		return "replay"
+	default:
+		return "Unknown"
+	}
+}
+
+// ModeFromEnv returns the mode configured by the VW_TEST_RECORDER environment variable, which is
+// one of "record" or "replay". The recorder is disabled if the variable is not set.
+func ModeFromEnv() (Mode, error) {
+	switch value := os.Getenv(ModeEnvVar); value {
+	case "", "disabled":
+		return ModeDisabled, nil
+	case "record":
+		return ModeRecord, nil
+	case "replay":
+		return ModeReplay, nil
+	default:
+		return ModeDisabled, fmt.Errorf("invalid value %q for %s, expected one of: record, replay", value, ModeEnvVar)
+	}
+}
+
+// Cassette holds the interactions recorded during a test
+type Cassette struct {
+	// RecordedAt is the time the recording started, expirations are shifted relative to it on replay
+	RecordedAt   time.Time     `json:"recorded_at"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response the server returned for it
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request identifies a recorded request. Request bodies are not recorded, as they carry secrets and
+// encrypted values which differ between runs.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+
+	// BodyBase64 is set if the body is not valid UTF-8 and has been encoded with base64
+	BodyBase64 bool `json:"body_base64,omitempty"`
+}
+
+// recordedHeaders lists the response headers the client relies on, all other headers are dropped
+var recordedHeaders = []string{"Content-Type", "Location", "Set-Cookie"}
+
+// Recorder records or replays the interactions of a client with the Vaultwarden server
+type Recorder struct {
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+
+	// replayed counts the replayed interactions per request key
+	replayed map[string]int
+}
+
+// New returns a recorder for the cassette at the given path. In replay mode the cassette is loaded
+// and must exist, in record mode it is written by Save.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		replayed: make(map[string]int),
+	}
+
+	switch mode {
+	case ModeRecord:
+		r.cassette.RecordedAt = time.Now().UTC()
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Middleware records or replays the requests passed to it, depending on the mode of the recorder.
+// It can be passed to vaultwarden.WithMiddleware.
+func (r *Recorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		switch r.mode {
+		case ModeRecord:
+			return r.record(next, req)
+		case ModeReplay:
+			// The transport is responsible for closing the body, even if it is not sent
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return r.replay(req)
+		default:
+			return next.RoundTrip(req)
+		}
+	})
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing unless recording.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// record sends the request to the server and records the response
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := Response{
+		StatusCode: resp.StatusCode,
+		Header:     make(http.Header),
+	}
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			recorded.Header[name] = values
+		}
+	}
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.Body = base64.StdEncoding.EncodeToString(body)
+		recorded.BodyBase64 = true
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  Request{Method: req.Method, URL: requestURL(req)},
+		Response: recorded,
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the recorded response for the request. Requests are matched by method and URL,
+// identical requests are answered with their recorded responses in the order they were recorded,
+// so that the order of unrelated requests, e.g. of resources refreshed in parallel, doesn't matter.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	url := requestURL(req)
+	key := req.Method + " " + url
+
+	r.mu.Lock()
+	skip := r.replayed[key]
+	var recorded *Response
+	for i := range r.cassette.Interactions {
+		interaction := &r.cassette.Interactions[i]
+		if interaction.Request.Method != req.Method || interaction.Request.URL != url {
+			continue
+		}
+		if skip == 0 {
+			recorded = &interaction.Response
+			break
+		}
+		skip--
+	}
+	if recorded != nil {
+		r.replayed[key]++
+	}
+	r.mu.Unlock()
+
+	if recorded == nil {
+		return nil, fmt.Errorf("no recorded interaction left for %s in cassette %s, record it again", key, r.path)
+	}
+
+	body := []byte(recorded.Body)
+	if recorded.BodyBase64 {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(recorded.Body); err != nil {
+			return nil, fmt.Errorf("failed to decode recorded response body: %w", err)
+		}
+	}
+
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	// The recorded sessions have long expired, move their expiration as far into the future as it was at recording time
+	shift := time.Since(r.cassette.RecordedAt)
+	body = shiftTokenExpirations(body, shift)
+	shiftCookieExpirations(header, shift)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// requestURL returns the path and query of the request, which identify it independently of the endpoint
+func requestURL(req *http.Request) string {
+	return req.URL.RequestURI()
+}
+
+// roundTripperFunc adapts an ordinary function to the http.RoundTripper interface
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,172 @@
+package recorder
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/fakeserver"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testEmail    = "test@example.com"
+	testPassword = "test-password-123!"
+)
+
+// newRecordedClient returns a client for the endpoint using the recorder
+func newRecordedClient(t *testing.T, endpoint string, rec *Recorder) *vaultwarden.Client {
+	t.Helper()
+
+	client, err := vaultwarden.New(endpoint,
+		vaultwarden.WithUserCredentials(testEmail, testPassword),
+		vaultwarden.WithAdminToken(fakeserver.DefaultAdminToken),
+		vaultwarden.WithMiddleware(rec.Middleware),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+// exercise performs the requests that are recorded and replayed by the tests. Like Terraform does
+// for every command, a new client is used after creating the organization, which loads the keys
+// from the server instead of using the ones it generated.
+func exercise(t *testing.T, endpoint string, rec *Recorder) (*models.Organization, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	org, err := newRecordedClient(t, endpoint, rec).CreateOrganization(ctx, models.Organization{
+		Name:           "Test Organization",
+		CollectionName: "Default Collection",
+	})
+	if err != nil {
+		t.Fatalf("failed to create organization: %v", err)
+	}
+
+	client := newRecordedClient(t, endpoint, rec)
+	collection, err := client.FindOrganizationCollectionByName(ctx, org.ID, "Default Collection")
+	if err != nil {
+		t.Fatalf("failed to find collection: %v", err)
+	}
+
+	if _, err := client.GetUserByEmail(ctx, testEmail); err != nil {
+		t.Fatalf("failed to get user: %v", err)
+	}
+
+	return org, collection.ID
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	server := fakeserver.New(t)
+	server.AddUser(testEmail, testPassword)
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+	recordedOrg, recordedCollectionID := exercise(t, server.URL, rec)
+	if err := rec.Save(); err != nil {
+		t.Fatalf("failed to save cassette: %v", err)
+	}
+
+	// Nothing must reach the server while replaying
+	requests := len(server.Requests())
+	server.Close()
+
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %v", err)
+	}
+	replayedOrg, replayedCollectionID := exercise(t, server.URL, rec)
+
+	if replayedOrg.ID != recordedOrg.ID || replayedCollectionID != recordedCollectionID {
+		t.Errorf("expected organization %s with collection %s, got %s with %s", recordedOrg.ID, recordedCollectionID, replayedOrg.ID, replayedCollectionID)
+	}
+	if len(rec.cassette.Interactions) != requests {
+		t.Errorf("expected %d recorded interactions, got %d", requests, len(rec.cassette.Interactions))
+	}
+
+	// Request bodies carry secrets and must not be recorded
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), fakeserver.DefaultAdminToken) {
+		t.Error("expected the admin token not to be recorded")
+	}
+}
+
+func TestReplayUnrecordedRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(`{"recorded_at": "2025-01-01T00:00:00Z", "interactions": []}`), 0o644); err != nil {
+		t.Fatalf("failed to write cassette: %v", err)
+	}
+
+	rec, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %v", err)
+	}
+
+	client := newRecordedClient(t, "http://vaultwarden.invalid", rec)
+	if _, err := client.DetectServerVersion(context.Background()); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("expected a missing interaction error, got: %v", err)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("expected an error for a missing cassette")
+	}
+}
+
+func TestShiftExpirations(t *testing.T) {
+	expiration := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	shift := 48 * time.Hour
+
+	claims, _ := json.Marshal(map[string]interface{}{"exp": expiration.Unix(), "sub": "user"})
+	token := "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(claims) + ".signature"
+	body, _ := json.Marshal(map[string]interface{}{"access_token": token, "expires_in": 7200})
+
+	var shifted struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(shiftTokenExpirations(body, shift), &shifted); err != nil {
+		t.Fatalf("failed to parse shifted body: %v", err)
+	}
+	if got, err := helpers.ParseJWTExpiration(shifted.AccessToken); err != nil || !got.Equal(expiration.Add(shift)) {
+		t.Errorf("expected token expiration %s, got %s (%v)", expiration.Add(shift), got, err)
+	}
+	if shifted.ExpiresIn != 7200 {
+		t.Errorf("expected the other fields to be kept, got expires_in %d", shifted.ExpiresIn)
+	}
+
+	// Bodies without a token are kept as they are
+	for _, body := range []string{`[1, 2]`, `{"data": []}`, `not json`} {
+		if got := string(shiftTokenExpirations([]byte(body), shift)); got != body {
+			t.Errorf("expected body %q to be unchanged, got %q", body, got)
+		}
+	}
+
+	header := http.Header{}
+	header.Add("Set-Cookie", (&http.Cookie{Name: "VW_ADMIN", Value: "session", Path: "/admin", Expires: expiration}).String())
+	shiftCookieExpirations(header, shift)
+
+	cookie, err := http.ParseSetCookie(header.Get("Set-Cookie"))
+	if err != nil {
+		t.Fatalf("failed to parse shifted cookie: %v", err)
+	}
+	if !cookie.Expires.Equal(expiration.Add(shift)) || cookie.Value != "session" || cookie.Path != "/admin" {
+		t.Errorf("unexpected shifted cookie: %s", cookie.String())
+	}
+}
@@ -0,0 +1,42 @@
+package vaultwarden
+
+import (
+	"regexp"
+)
+
+// redactedValue replaces sensitive values in messages
+const redactedValue = "[REDACTED]"
+
+// sensitiveFields lists the names of request and response fields that carry secrets
+const sensitiveFields = `access_token|accessToken|refresh_token|refreshToken|id_token|client_secret|clientSecret|` +
+	`password|masterPasswordHash|masterPassword|newMasterPasswordHash|token|key|privateKey|encryptedPrivateKey|` +
+	`encryptedKey|resetPasswordKey|apiKey|secret`
+
+var (
+	// Sensitive fields in JSON documents, e.g. "access_token": "..."
+	sensitiveJSONFieldPattern = regexp.MustCompile(`(?i)("(?:` + sensitiveFields + `)"\s*:\s*")(?:[^"\\]|\\.)*(")`)
+
+	// Sensitive fields in form-encoded data or query strings, e.g. password=...
+	sensitiveFormFieldPattern = regexp.MustCompile(`(?i)\b((?:` + sensitiveFields + `)=)[^&\s"]+`)
+
+	// Bearer tokens in authorization headers
+	bearerTokenPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-_.~+/]+=*`)
+
+	// JSON Web Tokens
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+	// Encrypted strings, e.g. 2.iv|data|mac, which include encrypted keys
+	encryptedStringPattern = regexp.MustCompile(`\b\d\.[A-Za-z0-9+/]+=*\|[A-Za-z0-9+/]+=*(?:\|[A-Za-z0-9+/]+=*)?`)
+)
+
+// RedactSecrets masks tokens, keys, password hashes and encrypted values in the given message,
+// so that it can be safely included in diagnostics or logs. Middlewares that log requests or
+// responses should pass them through this function.
+func RedactSecrets(message string) string {
+	message = sensitiveJSONFieldPattern.ReplaceAllString(message, "${1}"+redactedValue+"${2}")
+	message = sensitiveFormFieldPattern.ReplaceAllString(message, "${1}"+redactedValue)
+	message = bearerTokenPattern.ReplaceAllString(message, "${1}"+redactedValue)
+	message = jwtPattern.ReplaceAllString(message, redactedValue)
+	message = encryptedStringPattern.ReplaceAllString(message, redactedValue)
+	return message
+}
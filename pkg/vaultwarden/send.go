@@ -0,0 +1,48 @@
+package vaultwarden
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/crypt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/encryptedstring"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+)
+
+// GetSend retrieves a Send of the authenticated user by its ID
+func (c *Client) GetSend(ctx context.Context, sendID string) (*models.Send, error) {
+	if sendID == "" {
+		return nil, fmt.Errorf("send ID is required")
+	}
+
+	var send models.Send
+	if _, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/sends/%s", sendID), nil, &send); err != nil {
+		return nil, fmt.Errorf("failed to get send: %w", err)
+	}
+
+	return &send, nil
+}
+
+// SendAccessURL returns the public URL to access a Send. The URL contains the key of the Send in its
+// fragment, which is decrypted using the user key and never sent to the server by the web vault.
+func (c *Client) SendAccessURL(ctx context.Context, send *models.Send) (string, error) {
+	userKey, err := c.userKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer userKey.Zero()
+
+	encKey, err := encryptedstring.NewFromEncryptedValue(send.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse send key: %w", err)
+	}
+
+	sendKey, err := crypt.Decrypt(encKey, userKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt send key: %w", err)
+	}
+	defer clear(sendKey)
+
+	return fmt.Sprintf("%s/#/send/%s/%s", c.endpoint.String(), send.AccessID, base64.RawURLEncoding.EncodeToString(sendKey)), nil
+}
@@ -3,7 +3,7 @@ package symmetrickey
 import (
 	"crypto/sha256"
 	"fmt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/helpers"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/internal/helpers"
 )
 
 type Key struct {
@@ -16,11 +16,14 @@ type Key struct {
 type EncryptionType int
 
 const (
-	AesCbc256_B64            EncryptionType = 0
-	AesCbc128_HmacSha256_B64 EncryptionType = 1
-	AesCbc256_HmacSha256_B64 EncryptionType = 2
-	Rsa2048_OaepSha256_B64   EncryptionType = 3
-	Rsa2048_OaepSha1_B64     EncryptionType = 4
+	AesCbc256_B64                     EncryptionType = 0
+	AesCbc128_HmacSha256_B64          EncryptionType = 1
+	AesCbc256_HmacSha256_B64          EncryptionType = 2
+	Rsa2048_OaepSha256_B64            EncryptionType = 3
+	Rsa2048_OaepSha1_B64              EncryptionType = 4
+	Rsa2048_OaepSha256_HmacSha256_B64 EncryptionType = 5
+	Rsa2048_OaepSha1_HmacSha256_B64   EncryptionType = 6
+	CoseEncrypt0                      EncryptionType = 7
 )
 
 func NewFromRawBytesWithEncryptionType(rawKey []byte, encType EncryptionType) (*Key, error) {
@@ -52,6 +55,13 @@ func NewFromRawBytes(rawKey []byte) (*Key, error) {
 	return nil, fmt.Errorf("unsupported raw key len: %d", len(rawKey))
 }
 
+// Zero overwrites the key material with zeros. The key must not be used afterwards.
+func (key *Key) Zero() {
+	helpers.ZeroBytes(key.Key)
+	helpers.ZeroBytes(key.EncryptionKey)
+	helpers.ZeroBytes(key.MacKey)
+}
+
 func (key *Key) StretchKey() Key {
 	encKey := helpers.HKDFExpand(key.Key, []byte("enc"), sha256.New, 32)
 	macKey := helpers.HKDFExpand(key.Key, []byte("mac"), sha256.New, 32)
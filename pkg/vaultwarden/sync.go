@@ -0,0 +1,23 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+)
+
+// Sync retrieves the full account data of the authenticated user
+func (c *Client) Sync(ctx context.Context) (*models.Sync, error) {
+	// Ensure we have valid authentication
+	if err := c.ensureUserAuth(ctx); err != nil {
+		return nil, fmt.Errorf("authentication required: %w", err)
+	}
+
+	var sync models.Sync
+	if _, err := c.doRequest(ctx, http.MethodGet, "/api/sync?excludeDomains=true", nil, &sync); err != nil {
+		return nil, fmt.Errorf("failed to sync: %w", err)
+	}
+
+	return &sync, nil
+}
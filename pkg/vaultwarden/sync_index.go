@@ -0,0 +1,82 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"net/http"
+)
+
+// syncIndex is an in-memory index of the account data returned by the sync endpoint. It is loaded
+// once and serves reads until a write invalidates it, so that refreshing many resources doesn't
+// require listing the organization data again for every single resource.
+type syncIndex struct {
+	collections map[string]models.Collection
+}
+
+// collection returns the indexed collection with the given ID if it belongs to the organization
+func (i *syncIndex) collection(orgID, collectionID string) (models.Collection, bool) {
+	collection, exists := i.collections[collectionID]
+	if !exists || collection.OrganizationID != orgID {
+		return models.Collection{}, false
+	}
+	return collection, true
+}
+
+// loadSyncIndex returns the sync index, loading it if there is none or it was invalidated.
+// The organization keys returned by the sync are cached as well.
+func (c *Client) loadSyncIndex(ctx context.Context) (*syncIndex, error) {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+
+	if c.index != nil {
+		return c.index, nil
+	}
+
+	syncResp, err := c.Sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := decryptOrganizationSecrets(syncResp.Profile.Organizations, c.privateKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load organization keys: %w", err)
+	}
+	c.cacheOrganizationSecrets(secrets)
+
+	index := &syncIndex{
+		collections: make(map[string]models.Collection, len(syncResp.Collections)),
+	}
+	for _, collection := range syncResp.Collections {
+		index.collections[collection.ID] = collection
+	}
+
+	c.index = index
+
+	return index, nil
+}
+
+// invalidateSyncIndex discards the sync index, it is loaded again on the next read. As the lock
+// is held while loading, writes completing during a load discard its result once it is stored.
+func (c *Client) invalidateSyncIndex() {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+
+	c.index = nil
+}
+
+// syncIndexMiddleware invalidates the sync index and advances the write epoch of shared requests
+// after every authenticated write request, regardless of its outcome as failed writes might have
+// been partially applied
+func (c *Client) syncIndexMiddleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+
+		if requiresAuthentication(req) && req.Method != http.MethodGet && req.Method != http.MethodHead {
+			c.invalidateSyncIndex()
+			c.writeEpoch.Add(1)
+		}
+
+		return resp, err
+	})
+}
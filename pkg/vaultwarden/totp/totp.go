@@ -0,0 +1,94 @@
+// Package totp generates the time-based one-time passwords (RFC 6238) used by the authenticator
+// two-step login provider of Vaultwarden, with the parameters of the official clients: HMAC-SHA1,
+// 30 second time steps and 6 digits.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the duration of a time step, each step has its own code
+	Period = 30 * time.Second
+
+	// Digits is the number of digits of a code
+	Digits = 6
+
+	// secretLength is the number of random bytes of generated secrets, like Vaultwarden uses
+	secretLength = 20
+)
+
+// encoding is the base32 encoding of secrets, which are usually shown without padding
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a random base32 encoded secret
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("error generating random bytes: %w", err)
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// Code returns the code of the base32 encoded secret for the time step containing t. Spaces,
+// padding and lowercase letters are accepted in the secret, as authenticator apps show them.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return code(key, Step(t)), nil
+}
+
+// Step returns the number of the time step containing t
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// StepCode returns the code of the base32 encoded secret for the given time step
+func StepCode(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return code(key, step), nil
+}
+
+// decodeSecret decodes a base32 encoded secret
+func decodeSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+	if normalized == "" {
+		return nil, fmt.Errorf("the TOTP secret is empty")
+	}
+
+	key, err := encoding.DecodeString(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("unable to base32 decode TOTP secret: %w", err)
+	}
+	return key, nil
+}
+
+// code computes the code of the key for the time step as specified by RFC 4226
+func code(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1000000)
+}
@@ -0,0 +1,72 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 secret of the test vectors of RFC 6238, "12345678901234567890" encoded as base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// The RFC lists 8 digit codes, the codes with 6 digits are their last 6 digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("code at %d: got %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestCodeNormalizesSecret(t *testing.T) {
+	want, err := Code(rfcSecret, time.Unix(59, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, secret := range []string{"gezd gnbv gy3t qojq gezd gnbv gy3t qojq", rfcSecret + "===="} {
+		got, err := Code(secret, time.Unix(59, 0))
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", secret, err)
+		}
+		if got != want {
+			t.Errorf("code for %q: got %s, want %s", secret, got, want)
+		}
+	}
+}
+
+func TestCodeInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "not base32!"} {
+		if _, err := Code(secret, time.Now()); err == nil {
+			t.Errorf("expected an error for %q", secret)
+		}
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("expected a secret of 32 characters, got %q", secret)
+	}
+	if _, err := Code(secret, time.Now()); err != nil {
+		t.Errorf("generated secret cannot be used: %v", err)
+	}
+}
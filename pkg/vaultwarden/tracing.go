@@ -0,0 +1,81 @@
+package vaultwarden
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// tracerName is the instrumentation scope of the spans of the client
+	tracerName = "github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden"
+
+	// spanAttributeEndpoint is the endpoint of the Vaultwarden server a request was sent to
+	spanAttributeEndpoint = attribute.Key("vaultwarden.endpoint")
+
+	// spanAttributeRequestID is the correlation ID of a request, which is also sent in the X-Request-Id header
+	spanAttributeRequestID = attribute.Key("vaultwarden.request_id")
+)
+
+// startRequestSpan starts a client span for a request to the Vaultwarden server. The span covers the logins,
+// retries and failovers of the request, which are traced as child spans.
+func (c *Client) startRequestSpan(ctx context.Context, method string, reqURL *url.URL) (context.Context, trace.Span) {
+	route := requestRoute(c.apiPath(reqURL))
+
+	return c.tracer.Start(ctx, method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.HTTPRoute(route),
+			spanAttributeEndpoint.String(c.endpoints[c.endpointIndex(reqURL)].String()),
+		),
+		trace.WithAttributes(serverAttributes(reqURL)...),
+	)
+}
+
+// endRequestSpan records the outcome of a request on its span and ends it
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	defer span.End()
+
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+		// The request may have been sent to another endpoint after a failover
+		if resp.Request != nil {
+			span.SetAttributes(serverAttributes(resp.Request.URL)...)
+		}
+	}
+
+	if err != nil {
+		errorType := "request_failed"
+		if resp != nil {
+			errorType = strconv.Itoa(resp.StatusCode)
+		}
+		span.SetAttributes(semconv.ErrorTypeKey.String(errorType))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// serverAttributes returns the address and port of the server the URL points to
+func serverAttributes(reqURL *url.URL) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{semconv.ServerAddress(reqURL.Hostname())}
+
+	port := reqURL.Port()
+	if port == "" {
+		port = "80"
+		if reqURL.Scheme == "https" {
+			port = "443"
+		}
+	}
+	if value, err := strconv.Atoi(port); err == nil {
+		attributes = append(attributes, semconv.ServerPort(value))
+	}
+
+	return attributes
+}
@@ -0,0 +1,117 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/totp"
+	"net/http"
+	"time"
+)
+
+// TwoFactorRequest represents the request body of the two-step login endpoints, which ask for the master password
+type TwoFactorRequest struct {
+	MasterPasswordHash string `json:"masterPasswordHash"`
+}
+
+// EnableAuthenticatorRequest represents the request body for enabling the authenticator app provider
+type EnableAuthenticatorRequest struct {
+	Key                string `json:"key"`
+	Token              string `json:"token"`
+	MasterPasswordHash string `json:"masterPasswordHash"`
+}
+
+// DisableTwoFactorRequest represents the request body for disabling a two-step login provider
+type DisableTwoFactorRequest struct {
+	Type               models.TwoFactorType `json:"type"`
+	MasterPasswordHash string               `json:"masterPasswordHash"`
+}
+
+// GetTwoFactorProviders retrieves the two-step login providers the authenticated user has set up
+func (c *Client) GetTwoFactorProviders(ctx context.Context) ([]models.TwoFactorProvider, error) {
+	providers, err := getAllPages[models.TwoFactorProvider](ctx, c, "/api/two-factor")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list two-step login providers: %w", err)
+	}
+
+	return providers, nil
+}
+
+// GetAuthenticator retrieves the authenticator app provider of the authenticated user. If the provider is
+// not enabled yet, the returned key is a new random secret that can be passed to EnableAuthenticator.
+func (c *Client) GetAuthenticator(ctx context.Context) (*models.TwoFactorAuthenticator, error) {
+	hashedPassword, err := c.masterPasswordHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var authenticator models.TwoFactorAuthenticator
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/two-factor/get-authenticator", TwoFactorRequest{MasterPasswordHash: hashedPassword}, &authenticator); err != nil {
+		return nil, fmt.Errorf("failed to get authenticator: %w", err)
+	}
+
+	return &authenticator, nil
+}
+
+// EnableAuthenticator enables the authenticator app provider of the authenticated user with the base32
+// encoded secret, proving its possession with the current code. Existing sessions stay valid, but later
+// master password logins of the user require the secret to be set with WithTOTPSecret.
+func (c *Client) EnableAuthenticator(ctx context.Context, secret string) (*models.TwoFactorAuthenticator, error) {
+	code, err := totp.Code(secret, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TOTP code: %w", err)
+	}
+
+	hashedPassword, err := c.masterPasswordHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := EnableAuthenticatorRequest{
+		Key:                secret,
+		Token:              code,
+		MasterPasswordHash: hashedPassword,
+	}
+
+	var authenticator models.TwoFactorAuthenticator
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/two-factor/authenticator", body, &authenticator); err != nil {
+		return nil, fmt.Errorf("failed to enable authenticator: %w", err)
+	}
+
+	return &authenticator, nil
+}
+
+// GetTwoFactorRecoveryCode retrieves the recovery code of the authenticated user, which disables all
+// two-step login providers when used to log in. Vaultwarden generates it when the first provider is enabled.
+func (c *Client) GetTwoFactorRecoveryCode(ctx context.Context) (string, error) {
+	hashedPassword, err := c.masterPasswordHash(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var recoverResp models.TwoFactorRecover
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/two-factor/get-recover", TwoFactorRequest{MasterPasswordHash: hashedPassword}, &recoverResp); err != nil {
+		return "", fmt.Errorf("failed to get two-step login recovery code: %w", err)
+	}
+
+	return recoverResp.Code, nil
+}
+
+// DisableTwoFactor disables a two-step login provider of the authenticated user
+func (c *Client) DisableTwoFactor(ctx context.Context, providerType models.TwoFactorType) error {
+	hashedPassword, err := c.masterPasswordHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := DisableTwoFactorRequest{
+		Type:               providerType,
+		MasterPasswordHash: hashedPassword,
+	}
+
+	if _, err := c.doRequest(ctx, http.MethodPut, "/api/two-factor/disable", body, nil); err != nil {
+		return fmt.Errorf("failed to disable two-step login provider: %w", err)
+	}
+
+	return nil
+}
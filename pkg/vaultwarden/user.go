@@ -3,9 +3,11 @@ package vaultwarden
 import (
 	"context"
 	"fmt"
-	"github.com/ottramst/terraform-provider-vaultwarden/internal/vaultwarden/models"
+	"github.com/ottramst/terraform-provider-vaultwarden/pkg/vaultwarden/models"
 	"net/http"
 	"net/mail"
+	"net/url"
+	"strings"
 )
 
 // RegisterUserRequest represents the request body for registering a user
@@ -45,6 +47,12 @@ func (c *Client) InviteUser(ctx context.Context, user models.User) (*models.User
 	return &userResp, nil
 }
 
+// RegistrationURL returns the link of the web vault on the active endpoint to register an account with the email. It
+// carries no invitation token, Vaultwarden only sends that by email, but invited emails can register without it.
+func (c *Client) RegistrationURL(email string) string {
+	return fmt.Sprintf("%s/#/register?email=%s", strings.TrimSuffix(c.buildURL("").String(), "/"), url.QueryEscape(email))
+}
+
 // GetUser retrieves a user by their ID
 func (c *Client) GetUser(ctx context.Context, ID string) (*models.User, error) {
 	if ID == "" {
@@ -72,6 +80,19 @@ func (c *Client) DeleteUser(ctx context.Context, ID string) error {
 	return nil
 }
 
+// DeauthorizeUser revokes all sessions of a user by their ID, removing their devices
+func (c *Client) DeauthorizeUser(ctx context.Context, ID string) error {
+	if ID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	if _, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/admin/users/%s/deauth", ID), nil, nil); err != nil {
+		return fmt.Errorf("failed to deauthorize user: %w", err)
+	}
+
+	return nil
+}
+
 // GetUserByEmail retrieves a user by their email address
 func (c *Client) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
@@ -81,3 +102,13 @@ func (c *Client) GetUserByEmail(ctx context.Context, email string) (*models.User
 
 	return &user, nil
 }
+
+// GetUsers retrieves all users of the server, including the organizations they are a confirmed member of
+func (c *Client) GetUsers(ctx context.Context) ([]models.User, error) {
+	var users []models.User
+	if _, err := c.doRequest(ctx, http.MethodGet, "/admin/users", nil, &users); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,197 @@
+package vaultwarden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// versionPattern matches the numeric part of Vaultwarden versions, e.g. 1.32.5 or 1.33.0-1f2e3d4c
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
+
+// ServerVersion represents the version of a Vaultwarden server
+type ServerVersion struct {
+	Major int
+	Minor int
+	Patch int
+
+	// The version as reported by the server
+	Raw string
+}
+
+// ParseServerVersion parses a Vaultwarden version string
+func ParseServerVersion(version string) (*ServerVersion, error) {
+	matches := versionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("unrecognized Vaultwarden version %q", version)
+	}
+
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	patch, _ := strconv.Atoi(matches[3])
+
+	return &ServerVersion{
+		Major: major,
+		Minor: minor,
+		Patch: patch,
+		Raw:   version,
+	}, nil
+}
+
+// String returns the version as reported by the server
+func (v ServerVersion) String() string {
+	return v.Raw
+}
+
+// AtLeast returns whether the version is greater than or equal to the given version
+func (v ServerVersion) AtLeast(other ServerVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// Feature describes a behavior that requires a minimum Vaultwarden version, or that is deprecated as of a version
+type Feature struct {
+	Name       string
+	MinVersion ServerVersion
+
+	// The version that deprecated the behavior, if any
+	DeprecatedIn *ServerVersion
+}
+
+// NewFeature creates a feature that requires at least the given version
+func NewFeature(name, minVersion string) Feature {
+	return Feature{Name: name, MinVersion: mustParseFeatureVersion(name, minVersion)}
+}
+
+// NewDeprecatedFeature creates a feature that is deprecated as of the given version
+func NewDeprecatedFeature(name, deprecatedIn string) Feature {
+	version := mustParseFeatureVersion(name, deprecatedIn)
+	return Feature{Name: name, DeprecatedIn: &version}
+}
+
+// mustParseFeatureVersion parses a version of a feature, which are defined by the client
+func mustParseFeatureVersion(name, version string) ServerVersion {
+	parsed, err := ParseServerVersion(version)
+	if err != nil {
+		panic(fmt.Sprintf("BUG: invalid version of feature %s: %v", name, err))
+	}
+	return *parsed
+}
+
+var (
+	// FeatureCollectionPermissions is the collection permission model, which replaces the access_all flag of
+	// members with explicit collection permissions. Owners and admins can access all collections implicitly.
+	FeatureCollectionPermissions = NewFeature("Collection permissions", "1.32.0")
+
+	// FeatureManagerRole is the Manager role of members, which the collection permission model replaces with the
+	// Custom role and the manage permission of collections
+	FeatureManagerRole = NewDeprecatedFeature("The Manager role", "1.32.0")
+
+	// FeatureOrganizationGroups are the groups of organizations and their endpoints
+	FeatureOrganizationGroups = NewFeature("Organization groups", "1.27.0")
+
+	// FeatureCollectionExternalID is the external ID of collections, which older versions don't return
+	FeatureCollectionExternalID = NewFeature("The external ID of collections", "1.30.0")
+
+	// FeatureArgon2id is the Argon2id key derivation function of accounts
+	FeatureArgon2id = NewFeature("The Argon2id key derivation function", "1.28.0")
+)
+
+// UnsupportedFeatureError is returned when the server is too old to support a feature
+type UnsupportedFeatureError struct {
+	Feature       Feature
+	ServerVersion ServerVersion
+}
+
+// Error returns the string representation of the error
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s requires Vaultwarden >= %s, but the server is running %s", e.Feature.Name, e.Feature.MinVersion, e.ServerVersion)
+}
+
+// DetectServerVersion retrieves the version of the Vaultwarden server. The version reported by the server
+// is cached, so the server is only queried once per client. Failed requests are not cached, so a transient
+// error doesn't disable the feature gating for the life of the client.
+func (c *Client) DetectServerVersion(ctx context.Context) (*ServerVersion, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.versionDetected {
+		return c.serverVersion, c.serverVersionErr
+	}
+
+	var version string
+	if _, err := c.doUnauthenticatedRequest(ctx, http.MethodGet, "/api/version", nil, &version); err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+
+	// Unrecognized versions, e.g. of development builds, won't be recognized on the next request either
+	c.serverVersion, c.serverVersionErr = ParseServerVersion(version)
+	c.versionDetected = true
+
+	return c.serverVersion, c.serverVersionErr
+}
+
+// RequireFeature returns an UnsupportedFeatureError if the server is known to be too old to
+// support the feature. If the server version cannot be detected, e.g. for development builds,
+// the feature is assumed to be supported.
+func (c *Client) RequireFeature(ctx context.Context, feature Feature) error {
+	serverVersion, err := c.DetectServerVersion(ctx)
+	if err != nil {
+		return nil //nolint:nilerr // An unknown version must not block any features
+	}
+
+	if !serverVersion.AtLeast(feature.MinVersion) {
+		return &UnsupportedFeatureError{
+			Feature:       feature,
+			ServerVersion: *serverVersion,
+		}
+	}
+
+	return nil
+}
+
+// DeprecatedFeatureError is returned when the server is known to have deprecated a feature
+type DeprecatedFeatureError struct {
+	Feature       Feature
+	ServerVersion ServerVersion
+}
+
+// Error returns the string representation of the error
+func (e *DeprecatedFeatureError) Error() string {
+	return fmt.Sprintf("%s is deprecated as of Vaultwarden %s and the server is running %s", e.Feature.Name, e.Feature.DeprecatedIn, e.ServerVersion)
+}
+
+// CheckDeprecatedFeature returns a DeprecatedFeatureError if the server is known to have deprecated
+// the feature. If the server version cannot be detected, the feature is assumed not to be deprecated.
+func (c *Client) CheckDeprecatedFeature(ctx context.Context, feature Feature) error {
+	if feature.DeprecatedIn == nil {
+		return nil
+	}
+
+	serverVersion, err := c.DetectServerVersion(ctx)
+	if err != nil {
+		return nil //nolint:nilerr // An unknown version must not report any deprecations
+	}
+
+	if serverVersion.AtLeast(*feature.DeprecatedIn) {
+		return &DeprecatedFeatureError{
+			Feature:       feature,
+			ServerVersion: *serverVersion,
+		}
+	}
+
+	return nil
+}
+
+// SupportsFeature returns whether the server supports the feature. If the server version cannot
+// be detected, the feature is assumed to be supported.
+func (c *Client) SupportsFeature(ctx context.Context, feature Feature) bool {
+	return c.RequireFeature(ctx, feature) == nil
+}